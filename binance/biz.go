@@ -48,6 +48,10 @@ func (e *Binance) Init() *errs.Error {
 	}
 	e.streamBySubHash = map[string]string{}
 	e.wsRequestId = map[string]int{}
+	e.wsApiLogons = map[string]bool{}
+	e.wsUserSubs = map[string]int{}
+	e.oiStreamRefs = map[string]map[string]bool{}
+	e.bookTickerIds = map[string]int64{}
 	e.ExgInfo.NoHoliday = true
 	e.ExgInfo.FullDay = true
 	e.regReplayHandles()
@@ -121,18 +125,10 @@ func makeSign(e *Binance) banexg.FuncSign {
 			} else {
 				query = append(query, utils.UrlEncodeMap(extendParams, false))
 			}
-			var sign, method, hash string
+			var sign string
 			var digest = "hex"
 			var secret = creds.Secret
-			if strings.Contains(secret, "PRIVATE KEY") {
-				if len(secret) > 120 {
-					method, hash = "rsa", "sha256"
-				} else {
-					method, hash = "eddsa", "ed25519"
-				}
-			} else {
-				method, hash = "hmac", "sha256"
-			}
+			method, hash := getSignMethod(secret)
 			queryText := strings.Join(query, "&")
 			sign, err = utils.Signature(queryText, secret, method, hash, digest)
 			if err != nil {
@@ -154,6 +150,17 @@ func makeSign(e *Binance) banexg.FuncSign {
 	}
 }
 
+// getSignMethod 根据密钥格式返回签名方法：rsa/eddsa(ed25519)/hmac
+func getSignMethod(secret string) (string, string) {
+	if strings.Contains(secret, "PRIVATE KEY") {
+		if len(secret) > 120 {
+			return "rsa", "sha256"
+		}
+		return "eddsa", "ed25519"
+	}
+	return "hmac", "sha256"
+}
+
 /*
 fetches all available currencies on an exchange
 :see: https://binance-docs.github.io/apidocs/spot/en/#all-coins-39-information-user_data
@@ -213,6 +220,8 @@ func makeFetchCurr(e *Binance) banexg.FuncFetchCurr {
 						curr.Fee = withDrawFee
 					}
 				}
+				withdrawMin, _ := strconv.ParseFloat(net.WithdrawMin, 64)
+				withdrawMax, _ := strconv.ParseFloat(net.WithdrawMax, 64)
 				precisionTick := utils.PrecisionFromString(net.WithdrawIntegerMultiple)
 				if precisionTick != 0 {
 					curr.Precision = precisionTick
@@ -227,7 +236,11 @@ func makeFetchCurr(e *Binance) banexg.FuncFetchCurr {
 					Precision: precisionTick,
 					Deposit:   net.DepositEnable,
 					Withdraw:  net.WithdrawEnable,
-					Info:      nets[i],
+					IsDefault: net.IsDefault,
+					Limits: &banexg.CodeLimits{
+						Withdraw: &banexg.LimitRange{Min: withdrawMin, Max: withdrawMax},
+					},
+					Info: nets[i],
 				}
 			}
 			curr.Active = isDeposit && isWithDraw && item.Trading
@@ -670,11 +683,18 @@ func (e *Binance) LoadLeverageBrackets(reload bool, params map[string]interface{
 	if err != nil {
 		return err
 	}
+	isPapi := e.isPortfolioMargin(args)
 	var method string
 	if marketType == banexg.MarketLinear {
 		method = MethodFapiPrivateGetLeverageBracket
+		if isPapi {
+			method = MethodPapiGetUmLeverageBracket
+		}
 	} else if marketType == banexg.MarketInverse {
 		method = MethodDapiPrivateV2GetLeverageBracket
+		if isPapi {
+			method = MethodPapiGetCmLeverageBracket
+		}
 	} else {
 		return errs.NewMsg(errs.CodeUnsupportMarket, "LoadLeverageBrackets support linear/inverse contracts only")
 	}
@@ -835,13 +855,27 @@ func (e *Binance) Close() *errs.Error {
 	}
 	e.streamBySubHash = make(map[string]string)
 	e.streamIndex = -1
+	e.wsReqIdLock.Lock()
 	e.wsRequestId = map[string]int{}
+	e.wsReqIdLock.Unlock()
+	e.wsApiLock.Lock()
+	e.wsApiLogons = map[string]bool{}
+	e.wsUserSubs = map[string]int{}
+	e.wsApiLock.Unlock()
+	e.oiRefLock.Lock()
+	e.oiStreamRefs = map[string]map[string]bool{}
+	e.oiRefLock.Unlock()
+	e.bookTickerLock.Lock()
+	e.bookTickerIds = map[string]int64{}
+	e.bookTickerLock.Unlock()
 	return nil
 }
 
 func (e *Binance) nextId(client *banexg.WsClient) int {
+	e.wsReqIdLock.Lock()
 	requestId := e.wsRequestId[client.URL] + 1
 	e.wsRequestId[client.URL] = requestId
+	e.wsReqIdLock.Unlock()
 	return requestId
 }
 
@@ -976,6 +1010,53 @@ func (e *Binance) regReplayHandles() {
 			_, err := e.WatchMarkPrices(symbols, nil)
 			return err
 		},
+		"WatchTickers": func(item *banexg.WsLog) *errs.Error {
+			symbols, params, err := parseWsSubLog(item)
+			if err != nil {
+				return err
+			}
+			log.Debug("replay WatchTickers", zap.Strings("codes", symbols))
+			_, err = e.WatchTickers(symbols, params)
+			return err
+		},
+		"WatchOpenInterest": func(item *banexg.WsLog) *errs.Error {
+			var symbols = make([]string, 0)
+			err_ := utils.UnmarshalString(item.Content, &symbols, utils.JsonNumDefault)
+			if err_ != nil {
+				return errs.New(errs.CodeUnmarshalFail, err_)
+			}
+			log.Debug("replay WatchOpenInterest", zap.Strings("codes", symbols))
+			_, err := e.WatchOpenInterest(symbols, nil)
+			return err
+		},
+		"WatchFundingRates": func(item *banexg.WsLog) *errs.Error {
+			var symbols = make([]string, 0)
+			err_ := utils.UnmarshalString(item.Content, &symbols, utils.JsonNumDefault)
+			if err_ != nil {
+				return errs.New(errs.CodeUnmarshalFail, err_)
+			}
+			log.Debug("replay WatchFundingRates", zap.Strings("codes", symbols))
+			_, err := e.WatchFundingRates(symbols, nil)
+			return err
+		},
+		"WatchLiquidations": func(item *banexg.WsLog) *errs.Error {
+			symbols, params, err := parseWsSubLog(item)
+			if err != nil {
+				return err
+			}
+			log.Debug("replay WatchLiquidations", zap.Strings("codes", symbols))
+			_, err = e.WatchLiquidations(symbols, params)
+			return err
+		},
+		"WatchBookTickers": func(item *banexg.WsLog) *errs.Error {
+			symbols, params, err := parseWsSubLog(item)
+			if err != nil {
+				return err
+			}
+			log.Debug("replay WatchBookTickers", zap.Strings("codes", symbols))
+			_, err = e.WatchBookTickers(symbols, params)
+			return err
+		},
 		"OdBookShot": func(item *banexg.WsLog) *errs.Error {
 			var pak = &banexg.OdBookShotLog{}
 			err_ := utils.UnmarshalString(item.Content, pak, utils.JsonNumDefault)
@@ -1002,6 +1083,51 @@ func (e *Binance) regReplayHandles() {
 	}
 }
 
+/*
+dumpWsSub
+记录订阅的标的、市场类型和ParamName，symbols为空的全市场订阅需按市场类型回放
+*/
+func (e *Binance) dumpWsSub(name string, symbols []string, params map[string]interface{}) {
+	if e.WsEncoder == nil {
+		return
+	}
+	var first string
+	if len(symbols) > 0 {
+		first = symbols[0]
+	}
+	marketType, _ := e.GetArgsMarketType(utils.SafeParams(params), first)
+	e.DumpWS(name, &WsSubLog{
+		Symbols: symbols,
+		Market:  marketType,
+		Name:    utils.GetMapVal(params, banexg.ParamName, ""),
+	})
+}
+
+/*
+parseWsSubLog
+解析dumpWsSub记录的订阅，返回标的和回放时的参数；兼容只记录了标的列表的旧格式
+*/
+func parseWsSubLog(item *banexg.WsLog) ([]string, map[string]interface{}, *errs.Error) {
+	var sub = &WsSubLog{}
+	var err_ error
+	if strings.HasPrefix(item.Content, "[") {
+		err_ = utils.UnmarshalString(item.Content, &sub.Symbols, utils.JsonNumDefault)
+	} else {
+		err_ = utils.UnmarshalString(item.Content, sub, utils.JsonNumDefault)
+	}
+	if err_ != nil {
+		return nil, nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var params = map[string]interface{}{}
+	if sub.Market != "" {
+		params[banexg.ParamMarket] = sub.Market
+	}
+	if sub.Name != "" {
+		params[banexg.ParamName] = sub.Name
+	}
+	return sub.Symbols, params, nil
+}
+
 var rateCostMap = map[string]string{
 	"noCoin":   "coin",
 	"noSymbol": "symbol",
@@ -1,20 +1,270 @@
 package binance
 
 import (
+	"context"
+	"maps"
+	"strconv"
+	"strings"
+
 	"github.com/banbox/banexg"
 	"github.com/banbox/banexg/errs"
 	"github.com/banbox/banexg/utils"
 )
 
-func (e *Binance) WatchAccountConfig(params map[string]interface{}) (chan *banexg.AccountConfig, *errs.Error) {
-	_, client, err := e.getAuthClient(params)
+const (
+	codeNoNeedChangeMargin  = -4046 // No need to change margin type.
+	codeNoNeedChangePosSide = -4059 // No need to change position side.
+)
+
+/*
+SetMarginMode
+set margin mode to 'cross' or 'isolated'
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#change-margin-type-trade
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#change-margin-type-trade
+	:param str mode: MarginCross or MarginIsolated
+	:param str symbol: unified market symbol
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:returns dict: response from the exchange
+*/
+func (e *Binance) SetMarginMode(mode, symbol string, params map[string]interface{}) (map[string]interface{}, *errs.Error) {
+	if symbol == "" {
+		return nil, errs.NewMsg(errs.CodeParamRequired, "symbol is required for %v.SetMarginMode", e.Name)
+	}
+	var marginType string
+	if mode == banexg.MarginCross {
+		marginType = "CROSSED"
+	} else if mode == banexg.MarginIsolated {
+		marginType = "ISOLATED"
+	} else {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "mode should be cross or isolated, current: %s", mode)
+	}
+	args, market, err := e.LoadArgsMarket(symbol, params)
 	if err != nil {
 		return nil, err
 	}
+	var method string
+	if market.Linear {
+		method = MethodFapiPrivatePostMarginType
+	} else if market.Inverse {
+		method = MethodDapiPrivatePostMarginType
+	} else {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "%v SetMarginMode supports linear and inverse contracts only", e.Name)
+	}
+	args["symbol"] = market.ID
+	args["marginType"] = marginType
+	tryNum := e.GetRetryNum("SetMarginMode", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	return parseModeRsp(rsp, codeNoNeedChangeMargin)
+}
+
+/*
+SetPositionMode
+set hedged to true or false for a market
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#change-position-mode-trade
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#change-position-mode-trade
+	:param bool hedged: set to true to use dualSidePosition
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.market]: linear or inverse
+	:returns dict: response from the exchange
+*/
+func (e *Binance) SetPositionMode(hedged bool, params map[string]interface{}) (map[string]interface{}, *errs.Error) {
 	args := utils.SafeParams(params)
-	chanKey := client.Prefix("accConfig")
-	create := func(cap int) chan *banexg.AccountConfig { return make(chan *banexg.AccountConfig, cap) }
-	out := banexg.GetWsOutChan(e.Exchange, chanKey, create, args)
-	e.AddWsChanRefs(chanKey, "account")
-	return out, nil
+	marketType, _, err := e.LoadArgsMarketType(args)
+	if err != nil {
+		return nil, err
+	}
+	var method string
+	if marketType == banexg.MarketLinear {
+		method = MethodFapiPrivatePostPositionSideDual
+	} else if marketType == banexg.MarketInverse {
+		method = MethodDapiPrivatePostPositionSideDual
+	} else {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "%v SetPositionMode supports linear and inverse contracts only", e.Name)
+	}
+	args["dualSidePosition"] = strconv.FormatBool(hedged)
+	tryNum := e.GetRetryNum("SetPositionMode", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	return parseModeRsp(rsp, codeNoNeedChangePosSide)
+}
+
+/*
+parseModeRsp
+解析切换模式的返回结果，当前已是目标模式时视为成功
+*/
+func parseModeRsp(rsp *banexg.HttpRes, noChangeCode int) (map[string]interface{}, *errs.Error) {
+	if rsp.Error != nil {
+		if rsp.Error.BizCode == noChangeCode {
+			return map[string]interface{}{"code": noChangeCode, "msg": rsp.Error.Message()}, nil
+		}
+		return nil, rsp.Error
+	}
+	var res = make(map[string]interface{})
+	err_ := utils.UnmarshalString(rsp.Content, &res, utils.JsonNumAuto)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	return res, nil
+}
+
+/*
+FetchAccountConfig
+fetch leverage, margin mode and position mode of contract symbols
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#symbol-configuration-user_data
+	:see: https://binance-docs.github.io/apidocs/futures/en/#get-current-position-mode-user_data
+	:see: https://binance-docs.github.io/apidocs/futures/en/#get-current-multi-assets-mode-user_data
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#position-information-user_data
+	:param str[] [symbols]: unified market symbols, all symbols if empty
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:returns AccountConfig[]: a list of account config structures
+*/
+func (e *Binance) FetchAccountConfig(symbols []string, params map[string]interface{}) ([]*banexg.AccountConfig, *errs.Error) {
+	args := utils.SafeParams(params)
+	marketType, _, err := e.LoadArgsMarketType(args, symbols...)
+	if err != nil {
+		return nil, err
+	}
+	var dualMethod string
+	if marketType == banexg.MarketLinear {
+		dualMethod = MethodFapiPrivateGetPositionSideDual
+	} else if marketType == banexg.MarketInverse {
+		dualMethod = MethodDapiPrivateGetPositionSideDual
+	} else {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "FetchAccountConfig support linear/inverse contracts only")
+	}
+	// 查询账户级配置不需要symbol
+	dualArgs, multiArgs := maps.Clone(args), maps.Clone(args)
+	var symbolSet = make(map[string]bool)
+	if len(symbols) == 1 {
+		market, err := e.GetMarket(symbols[0])
+		if err != nil {
+			return nil, err
+		}
+		args["symbol"] = market.ID
+	}
+	for _, symbol := range symbols {
+		symbolSet[symbol] = true
+	}
+	tryNum := e.GetRetryNum("FetchAccountConfig", 1)
+	var acc = banexg.AccountConfig{}
+	rsp := e.RequestApiRetry(context.Background(), dualMethod, dualArgs, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var dual = struct {
+		DualSidePosition bool `json:"dualSidePosition"`
+	}{}
+	err_ := utils.UnmarshalString(rsp.Content, &dual, utils.JsonNumDefault)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	acc.Hedged = dual.DualSidePosition
+	var items []*banexg.AccountConfig
+	if marketType == banexg.MarketLinear {
+		rsp = e.RequestApiRetry(context.Background(), MethodFapiPrivateGetMultiAssetsMargin, multiArgs, tryNum)
+		if rsp.Error != nil {
+			return nil, rsp.Error
+		}
+		var multi = struct {
+			MultiAssetsMargin bool `json:"multiAssetsMargin"`
+		}{}
+		err_ = utils.UnmarshalString(rsp.Content, &multi, utils.JsonNumDefault)
+		if err_ != nil {
+			return nil, errs.New(errs.CodeUnmarshalFail, err_)
+		}
+		acc.MultiAssets = multi.MultiAssetsMargin
+		items, err = e.fetchSymbolConfigs(args, tryNum)
+	} else {
+		items, err = e.fetchInvSymbolConfigs(args, tryNum)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var res = make([]*banexg.AccountConfig, 0, len(items))
+	for _, it := range items {
+		if len(symbolSet) > 0 && !symbolSet[it.Symbol] {
+			continue
+		}
+		it.Hedged = acc.Hedged
+		it.MultiAssets = acc.MultiAssets
+		res = append(res, it)
+	}
+	return res, nil
+}
+
+func (e *Binance) fetchSymbolConfigs(args map[string]interface{}, tryNum int) ([]*banexg.AccountConfig, *errs.Error) {
+	rsp := e.RequestApiRetry(context.Background(), MethodFapiPrivateGetSymbolConfig, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var data = make([]*SymbolConfig, 0)
+	err_ := utils.UnmarshalString(banexg.EnsureArrStr(rsp.Content), &data, utils.JsonNumDefault)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var res = make([]*banexg.AccountConfig, 0, len(data))
+	for _, it := range data {
+		symbol := e.SafeSymbol(it.Symbol, "", banexg.MarketLinear)
+		if symbol == "" {
+			continue
+		}
+		res = append(res, &banexg.AccountConfig{
+			Symbol:     symbol,
+			Leverage:   it.Leverage,
+			MarginMode: parseMarginType(it.MarginType),
+		})
+	}
+	return res, nil
+}
+
+/*
+fetchInvSymbolConfigs
+币本位合约无symbolConfig接口，从positionRisk中读取杠杆和保证金模式
+*/
+func (e *Binance) fetchInvSymbolConfigs(args map[string]interface{}, tryNum int) ([]*banexg.AccountConfig, *errs.Error) {
+	if marketId, ok := args["symbol"]; ok {
+		// positionRisk only accept pair or marginAsset
+		delete(args, "symbol")
+		args["pair"] = strings.Split(marketId.(string), "_")[0]
+	}
+	rsp := e.RequestApiRetry(context.Background(), MethodDapiPrivateGetPositionRisk, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var data = make([]*InversePositionRisk, 0)
+	err_ := utils.UnmarshalString(banexg.EnsureArrStr(rsp.Content), &data, utils.JsonNumDefault)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var res = make([]*banexg.AccountConfig, 0, len(data))
+	var visited = make(map[string]bool)
+	for _, it := range data {
+		// 双向持仓时同一交易对有LONG/SHORT两条记录
+		symbol := e.SafeSymbol(it.Symbol, "", banexg.MarketInverse)
+		if symbol == "" || visited[symbol] {
+			continue
+		}
+		visited[symbol] = true
+		leverage, _ := strconv.Atoi(it.Leverage)
+		res = append(res, &banexg.AccountConfig{
+			Symbol:     symbol,
+			Leverage:   leverage,
+			MarginMode: parseMarginType(it.MarginType),
+		})
+	}
+	return res, nil
+}
+
+/*
+parseMarginType
+CROSSED/cross -> MarginCross, ISOLATED/isolated -> MarginIsolated
+*/
+func parseMarginType(marginType string) string {
+	marginType = strings.ToLower(marginType)
+	if marginType == "crossed" {
+		return banexg.MarginCross
+	}
+	return marginType
 }
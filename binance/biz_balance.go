@@ -36,7 +36,14 @@ func (e *Binance) FetchBalance(params map[string]interface{}) (*banexg.Balances,
 	}
 	marginMode := utils.PopMapVal(args, banexg.ParamMarginMode, "")
 	method := MethodPrivateGetAccount
-	if marketType == banexg.MarketLinear {
+	isPapi := e.isPortfolioMargin(args) && marketType != banexg.MarketSpot && marketType != banexg.MarketFunding
+	if isPapi {
+		// 统一账户：全仓杠杆、U本位、币本位共用同一钱包
+		if marginMode == banexg.MarginIsolated {
+			return nil, errs.NewMsg(errs.CodeUnsupportMarket, "isolated margin is not supported for portfolio margin account")
+		}
+		method = MethodPapiGetBalance
+	} else if marketType == banexg.MarketLinear {
 		method = MethodFapiPrivateV2GetAccount
 	} else if marketType == banexg.MarketInverse {
 		method = MethodDapiPrivateGetAccount
@@ -61,7 +68,7 @@ func (e *Binance) FetchBalance(params map[string]interface{}) (*banexg.Balances,
 		}
 	} else if marketType == banexg.MarketMargin || marginMode == banexg.MarginCross {
 		method = MethodSapiGetMarginAccount
-	} else if marketType == "funding" {
+	} else if marketType == banexg.MarketFunding {
 		method = MethodSapiPostAssetGetFundingAsset
 	}
 	tryNum := e.GetRetryNum("FetchBalance", 1)
@@ -85,6 +92,8 @@ func (e *Binance) FetchBalance(params map[string]interface{}) (*banexg.Balances,
 		return parseInverseBalances(getCurrCode, rsp)
 	case MethodSapiPostAssetGetFundingAsset:
 		return parseFundingBalances(e, rsp)
+	case MethodPapiGetBalance:
+		return parsePapiBalances(getCurrCode, rsp)
 	default:
 		return nil, errs.NewMsg(errs.CodeNotSupport, "unsupport parse balance method: %s", method)
 	}
@@ -112,11 +121,18 @@ func (e *Binance) FetchPositionsRisk(symbols []string, params map[string]interfa
 	if err != nil {
 		return nil, err
 	}
+	isPapi := e.isPortfolioMargin(args)
 	var method string
 	if marketType == banexg.MarketLinear {
 		method = MethodFapiPrivateV2GetPositionRisk
+		if isPapi {
+			method = MethodPapiGetUmPositionRisk
+		}
 	} else if marketType == banexg.MarketInverse {
 		method = MethodDapiPrivateGetPositionRisk
+		if isPapi {
+			method = MethodPapiGetCmPositionRisk
+		}
 	} else {
 		return nil, errs.NewMsg(errs.CodeInvalidRequest, "FetchPositionsRisk support linear/inverse contracts only")
 	}
@@ -151,11 +167,18 @@ func (e *Binance) FetchAccountPositions(symbols []string, params map[string]inte
 	if err != nil {
 		return nil, err
 	}
+	isPapi := e.isPortfolioMargin(args)
 	var method string
 	if marketType == banexg.MarketLinear {
 		method = MethodFapiPrivateV2GetAccount
+		if isPapi {
+			method = MethodPapiGetUmAccount
+		}
 	} else if marketType == banexg.MarketInverse {
 		method = MethodDapiPrivateGetAccount
+		if isPapi {
+			method = MethodPapiGetCmAccount
+		}
 	} else {
 		return nil, errs.NewMsg(errs.CodeInvalidRequest, "FetchAccountPositions support linear/inverse contracts only")
 	}
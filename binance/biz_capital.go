@@ -0,0 +1,285 @@
+package binance
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/bntp"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+// max time range of deposit/withdraw history for one request
+const capitalHisIntv = int64(90 * 24 * 3600 * 1000)
+
+/*
+FetchDepositAddress
+fetch the deposit address for a currency associated with this account
+
+	:see: https://binance-docs.github.io/apidocs/spot/en/#deposit-address-supporting-network-user_data
+	:param str code: unified currency code
+	:param str [network]: chain network id like ETH/BSC/TRX, default network is used if empty
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:returns DepositAddress: an address structure
+*/
+func (e *Binance) FetchDepositAddress(code, network string, params map[string]interface{}) (*banexg.DepositAddress, *errs.Error) {
+	if code == "" {
+		return nil, errs.NewMsg(errs.CodeParamRequired, "code is required for FetchDepositAddress")
+	}
+	args := utils.SafeParams(params)
+	// 只传递ctx，其他参数不应发送到市场信息接口
+	_, err := e.LoadMarkets(false, map[string]interface{}{banexg.ParamContext: banexg.GetParamCtx(params)})
+	if err != nil {
+		return nil, err
+	}
+	var net *banexg.ChainNetwork
+	if network != "" {
+		_, net, err = e.GetChainNetwork(code, network)
+		if err != nil {
+			return nil, err
+		}
+	} else if curr, ok := e.CurrenciesByCode[code]; ok && curr != nil {
+		// 未指定网络时使用币种的默认网络
+		net = curr.DefaultNetwork()
+	}
+	if net != nil {
+		if !net.Deposit {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "deposit disabled for %s on %s", code, net.Network)
+		}
+		args["network"] = net.ID
+		network = net.Network
+	}
+	args["coin"] = e.GetCurrencyID(code)
+	tryNum := e.GetRetryNum("FetchDepositAddress", 1)
+	rsp := e.RequestApiRetry(context.Background(), MethodSapiGetCapitalDepositAddress, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var data = BnbDepositAddress{}
+	info, err_ := utils.UnmarshalStringMap(rsp.Content, &data)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	return &banexg.DepositAddress{
+		Code:    e.SafeCurrencyCode(data.Coin),
+		Network: network,
+		Address: data.Address,
+		Tag:     data.Tag,
+		Info:    info,
+	}, nil
+}
+
+/*
+set startTime/endTime for capital history, binance require the range less than 90 days
+*/
+func setCapitalHisRange(args map[string]interface{}, since int64, limit int) {
+	until := utils.PopMapVal(args, banexg.ParamUntil, int64(0))
+	if since > 0 {
+		args["startTime"] = since
+		if until == 0 {
+			until = min(since+capitalHisIntv, bntp.UTCStamp())
+		}
+	}
+	if until > 0 {
+		args["endTime"] = until
+	}
+	if limit > 0 {
+		args["limit"] = min(limit, 1000)
+	}
+}
+
+/*
+FetchDeposits
+fetch all deposits made to an account
+
+	:see: https://binance-docs.github.io/apidocs/spot/en/#deposit-history-supporting-network-user_data
+	:param str [code]: unified currency code
+	:param int [since]: the earliest time in ms to fetch deposits for
+	:param int [limit]: the maximum number of deposits structures to retrieve, max 1000
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param int [params.until]: the latest time in ms to fetch deposits for, range should be less than 90 days
+	:returns Transaction[]: a list of transaction structures
+*/
+func (e *Binance) FetchDeposits(code string, since int64, limit int, params map[string]interface{}) ([]*banexg.Transaction, *errs.Error) {
+	args := utils.SafeParams(params)
+	if code != "" {
+		args["coin"] = e.GetCurrencyID(code)
+	}
+	setCapitalHisRange(args, since, limit)
+	tryNum := e.GetRetryNum("FetchDeposits", 1)
+	rsp := e.RequestApiRetry(context.Background(), MethodSapiGetCapitalDepositHisrec, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	return parseTransactions[*DepositRow](e, rsp)
+}
+
+/*
+FetchWithdrawals
+fetch all withdrawals made from an account
+
+	:see: https://binance-docs.github.io/apidocs/spot/en/#withdraw-history-supporting-network-user_data
+	:param str [code]: unified currency code
+	:param int [since]: the earliest time in ms to fetch withdrawals for
+	:param int [limit]: the maximum number of withdrawals structures to retrieve, max 1000
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param int [params.until]: the latest time in ms to fetch withdrawals for, range should be less than 90 days
+	:returns Transaction[]: a list of transaction structures
+*/
+func (e *Binance) FetchWithdrawals(code string, since int64, limit int, params map[string]interface{}) ([]*banexg.Transaction, *errs.Error) {
+	args := utils.SafeParams(params)
+	if code != "" {
+		args["coin"] = e.GetCurrencyID(code)
+	}
+	setCapitalHisRange(args, since, limit)
+	tryNum := e.GetRetryNum("FetchWithdrawals", 1)
+	rsp := e.RequestApiRetry(context.Background(), MethodSapiGetCapitalWithdrawHistory, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	return parseTransactions[*WithdrawRow](e, rsp)
+}
+
+/*
+Withdraw
+make a withdrawal. The currency, network and amount are checked with Currency.Networks before request:
+the Withdraw flag must be enabled, and amount must be in the range of Limits.Withdraw
+
+	:see: https://binance-docs.github.io/apidocs/spot/en/#withdraw-user_data
+	:param str code: unified currency code
+	:param float amount: the amount to withdraw
+	:param str address: the address to withdraw to
+	:param str [tag]: memo/tag for the address
+	:param str [network]: chain network id like ETH/BSC/TRX, default network is used if empty
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.withdrawOrderId]: client id for withdraw
+	:returns Transaction: a transaction structure
+*/
+func (e *Binance) Withdraw(code string, amount float64, address, tag, network string, params map[string]interface{}) (*banexg.Transaction, *errs.Error) {
+	if code == "" || address == "" {
+		return nil, errs.NewMsg(errs.CodeParamRequired, "code and address are required for Withdraw")
+	}
+	args := utils.SafeParams(params)
+	// 只传递ctx，其他参数不应发送到市场信息接口
+	_, err := e.LoadMarkets(false, map[string]interface{}{banexg.ParamContext: banexg.GetParamCtx(params)})
+	if err != nil {
+		return nil, err
+	}
+	net, err := e.CheckWithdraw(code, network, amount)
+	if err != nil {
+		return nil, err
+	}
+	if net != nil {
+		args["network"] = net.ID
+		// 未指定网络时返回实际使用的默认网络
+		network = net.Network
+	}
+	args["coin"] = e.GetCurrencyID(code)
+	args["address"] = address
+	if tag != "" {
+		args["addressTag"] = tag
+	}
+	args["amount"] = strconv.FormatFloat(amount, 'f', -1, 64)
+	tryNum := e.GetRetryNum("Withdraw", 1)
+	rsp := e.RequestApiRetry(context.Background(), MethodSapiPostCapitalWithdrawApply, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var data = struct {
+		ID string `json:"id"`
+	}{}
+	info, err_ := utils.UnmarshalStringMap(rsp.Content, &data)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	return &banexg.Transaction{
+		ID:        data.ID,
+		Type:      banexg.TxTypeWithdrawal,
+		Code:      code,
+		Network:   network,
+		Address:   address,
+		Tag:       tag,
+		Amount:    amount,
+		Status:    banexg.TransferStatusPending,
+		Timestamp: e.MilliSeconds(),
+		Info:      info,
+	}, nil
+}
+
+func parseTransactions[T IBnbTransaction](e *Binance, rsp *banexg.HttpRes) ([]*banexg.Transaction, *errs.Error) {
+	var data = make([]T, 0)
+	rspText := banexg.EnsureArrStr(rsp.Content)
+	items, err := utils.UnmarshalStringMapArr(rspText, &data)
+	if err != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err)
+	}
+	var result = make([]*banexg.Transaction, 0, len(data))
+	for i, item := range data {
+		result = append(result, item.ToStdTransaction(e, items[i]))
+	}
+	return result, nil
+}
+
+func (r *DepositRow) ToStdTransaction(e *Binance, info map[string]interface{}) *banexg.Transaction {
+	amount, _ := strconv.ParseFloat(r.Amount, 64)
+	var status string
+	switch r.Status {
+	case 1, 6:
+		status = banexg.TransferStatusOk
+	case 0, 8:
+		status = banexg.TransferStatusPending
+	case 2:
+		status = banexg.TransferStatusCanceled
+	default:
+		status = banexg.TransferStatusFailed
+	}
+	return &banexg.Transaction{
+		ID:        r.ID,
+		TxID:      r.TxId,
+		Type:      banexg.TxTypeDeposit,
+		Code:      e.SafeCurrencyCode(r.Coin),
+		Network:   r.Network,
+		Address:   r.Address,
+		Tag:       r.AddressTag,
+		Amount:    amount,
+		Status:    status,
+		Timestamp: r.InsertTime,
+		Info:      info,
+	}
+}
+
+func (r *WithdrawRow) ToStdTransaction(e *Binance, info map[string]interface{}) *banexg.Transaction {
+	amount, _ := strconv.ParseFloat(r.Amount, 64)
+	fee, _ := strconv.ParseFloat(r.TransactionFee, 64)
+	var stamp int64
+	if applyAt, err := time.ParseInLocation(time.DateTime, r.ApplyTime, time.UTC); err == nil {
+		stamp = applyAt.UnixMilli()
+	}
+	var status string
+	switch r.Status {
+	case 6:
+		status = banexg.TransferStatusOk
+	case 0, 2, 4:
+		status = banexg.TransferStatusPending
+	case 1:
+		status = banexg.TransferStatusCanceled
+	default:
+		status = banexg.TransferStatusFailed
+	}
+	return &banexg.Transaction{
+		ID:        r.ID,
+		TxID:      r.TxId,
+		Type:      banexg.TxTypeWithdrawal,
+		Code:      e.SafeCurrencyCode(r.Coin),
+		Network:   r.Network,
+		Address:   r.Address,
+		Tag:       r.AddressTag,
+		Amount:    amount,
+		Fee:       fee,
+		Status:    status,
+		Timestamp: stamp,
+		Info:      info,
+	}
+}
@@ -0,0 +1,66 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/banbox/banexg"
+	"github.com/h2non/gock"
+)
+
+func TestWithdrawDefaultNetwork(t *testing.T) {
+	defer gock.Off()
+	gock.DisableNetworking()
+	exg, err := New(map[string]interface{}{
+		banexg.OptApiKey:    "key",
+		banexg.OptApiSecret: "secret",
+	})
+	if err != nil {
+		panic(err)
+	}
+	gock.InterceptClient(exg.HttpClient)
+	exg.Markets = banexg.MarketMap{}
+	exg.CurrenciesByCode = banexg.CurrencyMap{
+		"USDT": {ID: "USDT", Code: "USDT", Withdraw: true, Networks: []*banexg.ChainNetwork{
+			{ID: "ETH", Network: "ERC20", Withdraw: true},
+			{ID: "TRX", Network: "TRC20", Withdraw: true, IsDefault: true},
+		}},
+	}
+	gock.New("https://api.binance.com").Post("/sapi/v1/capital/withdraw/apply").
+		BodyString("network=TRX").Reply(200).BodyString(`{"id":"w1"}`)
+	res, err := exg.Withdraw("USDT", 10, "addr", "", "", nil)
+	if err != nil {
+		t.Fatalf("withdraw fail: %v", err)
+	}
+	if res.Network != "TRC20" {
+		t.Errorf("withdraw should return default network TRC20, got %s", res.Network)
+	}
+}
+
+func TestDepositAddressDefaultNetwork(t *testing.T) {
+	defer gock.Off()
+	gock.DisableNetworking()
+	exg, err := New(map[string]interface{}{
+		banexg.OptApiKey:    "key",
+		banexg.OptApiSecret: "secret",
+	})
+	if err != nil {
+		panic(err)
+	}
+	gock.InterceptClient(exg.HttpClient)
+	exg.Markets = banexg.MarketMap{}
+	exg.CurrenciesByCode = banexg.CurrencyMap{
+		"USDT": {ID: "USDT", Code: "USDT", Networks: []*banexg.ChainNetwork{
+			{ID: "ETH", Network: "ERC20", Deposit: true},
+			{ID: "TRX", Network: "TRC20", Deposit: true, IsDefault: true},
+		}},
+	}
+	gock.New("https://api.binance.com").Get("/sapi/v1/capital/deposit/address").
+		MatchParam("network", "TRX").Reply(200).BodyString(`{"coin":"USDT","address":"addr","tag":""}`)
+	res, err := exg.FetchDepositAddress("USDT", "", nil)
+	if err != nil {
+		t.Fatalf("fetch deposit address fail: %v", err)
+	}
+	if res.Network != "TRC20" || res.Address != "addr" {
+		t.Errorf("should return default network TRC20, got %+v", res)
+	}
+}
@@ -0,0 +1,106 @@
+package binance
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/log"
+	"github.com/banbox/banexg/utils"
+	"go.uber.org/zap"
+)
+
+/*
+FetchLiquidations
+fetch the force orders (liquidation/ADL) of current account
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#user-39-s-force-orders-user_data
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#user-39-s-force-orders-user_data
+	:param str [symbol]: unified market symbol, market type is required in params if empty
+	:param int [since]: the earliest time in ms to fetch liquidations for
+	:param int [limit]: default 50, max 100
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.autoCloseType]: LIQUIDATION or ADL, all if empty
+	:param int [params.until]: the latest time in ms to fetch liquidations for
+	:returns Liquidation[]: a list of liquidation structures, sorted by time asc
+*/
+func (e *Binance) FetchLiquidations(symbol string, since int64, limit int, params map[string]interface{}) ([]*banexg.Liquidation, *errs.Error) {
+	var args map[string]interface{}
+	var marketType string
+	var err *errs.Error
+	if symbol != "" {
+		var market *banexg.Market
+		args, market, err = e.LoadArgsMarket(symbol, params)
+		if err != nil {
+			return nil, err
+		}
+		args["symbol"] = market.ID
+		marketType = market.Type
+	} else {
+		args = utils.SafeParams(params)
+		marketType, _, err = e.LoadArgsMarketType(args)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var method string
+	if marketType == banexg.MarketLinear {
+		method = MethodFapiPrivateGetForceOrders
+	} else if marketType == banexg.MarketInverse {
+		method = MethodDapiPrivateGetForceOrders
+	} else {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "FetchLiquidations support linear/inverse only")
+	}
+	if since > 0 {
+		args["startTime"] = since
+	}
+	until := utils.PopMapVal(args, banexg.ParamUntil, int64(0))
+	if until > 0 {
+		args["endTime"] = until
+	}
+	if limit > 0 {
+		args["limit"] = min(limit, 100)
+	}
+	tryNum := e.GetRetryNum("FetchLiquidations", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var data = make([]*FutureBase, 0)
+	items, err_ := utils.UnmarshalStringMapArr(banexg.EnsureArrStr(rsp.Content), &data)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var res = make([]*banexg.Liquidation, 0, len(data))
+	for i, it := range data {
+		market := e.GetMarketById(it.Symbol, marketType)
+		if market == nil {
+			log.Warn("no symbol for", zap.String("code", it.Symbol))
+			continue
+		}
+		price, _ := strconv.ParseFloat(it.Price, 64)
+		average, _ := strconv.ParseFloat(it.AvgPrice, 64)
+		amount, _ := strconv.ParseFloat(it.OrigQty, 64)
+		filled, _ := strconv.ParseFloat(it.ExecutedQty, 64)
+		res = append(res, &banexg.Liquidation{
+			Symbol:    market.Symbol,
+			Side:      strings.ToLower(it.Side),
+			Price:     price,
+			Average:   average,
+			Amount:    amount,
+			Filled:    filled,
+			Status:    mapOrderStatus(it.Status),
+			Timestamp: it.Time,
+			Info:      items[i],
+		})
+	}
+	// 接口按时间倒序返回，这里转为正序
+	if len(res) > 1 && res[0].Timestamp > res[len(res)-1].Timestamp {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,280 @@
+package binance
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+const (
+	hourMSecs = int64(3600000)
+	dayMSecs  = hourMSecs * 24
+)
+
+/*
+BorrowMargin
+create a loan to borrow margin, use papi for portfolio margin account
+
+	:see: https://binance-docs.github.io/apidocs/spot/en/#margin-account-borrow-repay-margin
+	:see: https://binance-docs.github.io/apidocs/pm/en/#margin-account-borrow-margin
+	:param str code: unified currency code of the currency to borrow
+	:param float amount: the amount to borrow
+	:param str [symbol]: unified market symbol for isolated margin, empty for cross margin
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:returns MarginLoan: a margin loan structure
+*/
+func (e *Binance) BorrowMargin(code string, amount float64, symbol string, params map[string]interface{}) (*banexg.MarginLoan, *errs.Error) {
+	return e.borrowRepay(code, amount, symbol, true, params)
+}
+
+/*
+RepayMargin
+repay borrowed margin and interest, use papi for portfolio margin account
+
+	:see: https://binance-docs.github.io/apidocs/spot/en/#margin-account-borrow-repay-margin
+	:see: https://binance-docs.github.io/apidocs/pm/en/#margin-account-repay-margin
+	:param str code: unified currency code of the currency to repay
+	:param float amount: the amount to repay
+	:param str [symbol]: unified market symbol for isolated margin, empty for cross margin
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:returns MarginLoan: a margin loan structure
+*/
+func (e *Binance) RepayMargin(code string, amount float64, symbol string, params map[string]interface{}) (*banexg.MarginLoan, *errs.Error) {
+	return e.borrowRepay(code, amount, symbol, false, params)
+}
+
+func (e *Binance) borrowRepay(code string, amount float64, symbol string, isBorrow bool, params map[string]interface{}) (*banexg.MarginLoan, *errs.Error) {
+	if code == "" {
+		return nil, errs.NewMsg(errs.CodeParamRequired, "code is required")
+	}
+	if amount <= 0 {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "amount must > 0")
+	}
+	_, err := e.LoadMarkets(false, map[string]interface{}{banexg.ParamContext: banexg.GetParamCtx(params)})
+	if err != nil {
+		return nil, err
+	}
+	args := utils.SafeParams(params)
+	args["asset"] = e.GetCurrencyID(code)
+	args["amount"] = strconv.FormatFloat(amount, 'f', -1, 64)
+	var method string
+	var market *banexg.Market
+	if e.isPortfolioMargin(args) {
+		if symbol != "" {
+			return nil, errs.NewMsg(errs.CodeUnsupportMarket, "isolated margin is not supported for portfolio margin account")
+		}
+		if isBorrow {
+			method = MethodPapiPostMarginLoan
+		} else {
+			method = MethodPapiPostRepayLoan
+		}
+	} else {
+		method = MethodSapiPostMarginBorrowRepay
+		if isBorrow {
+			args["type"] = "BORROW"
+		} else {
+			args["type"] = "REPAY"
+		}
+		if symbol != "" {
+			market, err = e.GetMarket(symbol)
+			if err != nil {
+				return nil, err
+			}
+			args["isIsolated"] = "TRUE"
+			args["symbol"] = market.ID
+		} else {
+			args["isIsolated"] = "FALSE"
+		}
+	}
+	tryNum := e.GetRetryNum("BorrowRepay", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var data = MarginTranRsp{}
+	info, err_ := utils.UnmarshalStringMap(rsp.Content, &data)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	res := &banexg.MarginLoan{
+		ID:        strconv.FormatInt(data.TranId, 10),
+		Code:      code,
+		Amount:    amount,
+		Timestamp: e.MilliSeconds(),
+		Info:      info,
+	}
+	if market != nil {
+		res.Symbol = market.Symbol
+	}
+	return res, nil
+}
+
+/*
+FetchBorrowRates
+fetch the borrow interest rates of currencies for cross margin.
+hourly rates are returned if codes is provided (max 20), else daily rates of all currencies for current vip level
+
+	:see: https://binance-docs.github.io/apidocs/spot/en/#query-next-hour-estimate-interest-rate-user_data
+	:see: https://binance-docs.github.io/apidocs/spot/en/#query-cross-margin-fee-data-user_data
+	:param str[] [codes]: unified currency codes
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:returns BorrowRate[]: a list of borrow rate structures
+*/
+func (e *Binance) FetchBorrowRates(codes []string, params map[string]interface{}) ([]*banexg.BorrowRate, *errs.Error) {
+	_, err := e.LoadMarkets(false, map[string]interface{}{banexg.ParamContext: banexg.GetParamCtx(params)})
+	if err != nil {
+		return nil, err
+	}
+	args := utils.SafeParams(params)
+	tryNum := e.GetRetryNum("FetchBorrowRates", 1)
+	stamp := e.MilliSeconds()
+	if len(codes) == 0 {
+		rsp := e.RequestApiRetry(context.Background(), MethodSapiGetMarginCrossMarginData, args, tryNum)
+		if rsp.Error != nil {
+			return nil, rsp.Error
+		}
+		var data = make([]*CrossMarginData, 0)
+		items, err_ := utils.UnmarshalStringMapArr(banexg.EnsureArrStr(rsp.Content), &data)
+		if err_ != nil {
+			return nil, errs.New(errs.CodeUnmarshalFail, err_)
+		}
+		var res = make([]*banexg.BorrowRate, 0, len(data))
+		for i, it := range data {
+			rate, _ := strconv.ParseFloat(it.DailyInterest, 64)
+			res = append(res, &banexg.BorrowRate{
+				Code:      e.SafeCurrencyCode(it.Coin),
+				Rate:      rate,
+				Period:    dayMSecs,
+				Timestamp: stamp,
+				Info:      items[i],
+			})
+		}
+		return res, nil
+	}
+	if len(codes) > 20 {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "max 20 codes for FetchBorrowRates")
+	}
+	var assets = make([]string, 0, len(codes))
+	for _, code := range codes {
+		assets = append(assets, e.GetCurrencyID(code))
+	}
+	args["assets"] = strings.Join(assets, ",")
+	args["isIsolated"] = "FALSE"
+	rsp := e.RequestApiRetry(context.Background(), MethodSapiGetMarginNextHourlyInterestRate, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var data = make([]*NextHourlyRate, 0)
+	items, err_ := utils.UnmarshalStringMapArr(banexg.EnsureArrStr(rsp.Content), &data)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var res = make([]*banexg.BorrowRate, 0, len(data))
+	for i, it := range data {
+		rate, _ := strconv.ParseFloat(it.NextHourlyInterestRate, 64)
+		res = append(res, &banexg.BorrowRate{
+			Code:      e.SafeCurrencyCode(it.Asset),
+			Rate:      rate,
+			Period:    hourMSecs,
+			Timestamp: stamp,
+			Info:      items[i],
+		})
+	}
+	return res, nil
+}
+
+/*
+FetchBorrowInterest
+fetch the interest owed by the user for borrowing currency for margin trading
+
+	:see: https://binance-docs.github.io/apidocs/spot/en/#get-interest-history-user_data
+	:see: https://binance-docs.github.io/apidocs/pm/en/#get-margin-borrow-loan-interest-history-user_data
+	:param str [code]: unified currency code
+	:param str [symbol]: unified market symbol of isolated margin, not support for portfolio margin
+	:param int [since]: the earliest time in ms to fetch interest for
+	:param int [limit]: the maximum number of structures to retrieve, max 100
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param int [params.until]: the latest time in ms to fetch interest for
+	:returns BorrowInterest[]: a list of borrow interest structures, sorted by time asc
+*/
+func (e *Binance) FetchBorrowInterest(code, symbol string, since int64, limit int, params map[string]interface{}) ([]*banexg.BorrowInterest, *errs.Error) {
+	_, err := e.LoadMarkets(false, map[string]interface{}{banexg.ParamContext: banexg.GetParamCtx(params)})
+	if err != nil {
+		return nil, err
+	}
+	args := utils.SafeParams(params)
+	method := MethodSapiGetMarginInterestHistory
+	var market *banexg.Market
+	if e.isPortfolioMargin(args) {
+		if symbol != "" {
+			return nil, errs.NewMsg(errs.CodeUnsupportMarket, "isolated margin is not supported for portfolio margin account")
+		}
+		method = MethodPapiGetMarginMarginInterestHistory
+	} else if symbol != "" {
+		market, err = e.GetMarket(symbol)
+		if err != nil {
+			return nil, err
+		}
+		args["isolatedSymbol"] = market.ID
+	}
+	if code != "" {
+		args["asset"] = e.GetCurrencyID(code)
+	}
+	if since > 0 {
+		args["startTime"] = since
+	}
+	until := utils.PopMapVal(args, banexg.ParamUntil, int64(0))
+	if until > 0 {
+		args["endTime"] = until
+	}
+	if limit > 0 {
+		args["size"] = min(limit, 100)
+	}
+	tryNum := e.GetRetryNum("FetchBorrowInterest", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var data = struct {
+		Rows  []map[string]interface{} `json:"rows"`
+		Total int                      `json:"total"`
+	}{}
+	err_ := utils.UnmarshalString(rsp.Content, &data, utils.JsonNumAuto)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var rows = make([]*MarginInterest, 0, len(data.Rows))
+	err_ = utils.DecodeStructMap(data.Rows, &rows, "json")
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var res = make([]*banexg.BorrowInterest, 0, len(rows))
+	for i, it := range rows {
+		interest, _ := strconv.ParseFloat(it.Interest, 64)
+		rate, _ := strconv.ParseFloat(it.InterestRate, 64)
+		principal, _ := strconv.ParseFloat(it.Principal, 64)
+		item := &banexg.BorrowInterest{
+			Code:         e.SafeCurrencyCode(it.Asset),
+			Interest:     interest,
+			InterestRate: rate,
+			Amount:       principal,
+			MarginMode:   banexg.MarginCross,
+			Timestamp:    it.InterestAccuredTime,
+			Info:         data.Rows[i],
+		}
+		if it.IsolatedSymbol != "" {
+			item.MarginMode = banexg.MarginIsolated
+			item.Symbol = e.SafeSymbol(it.IsolatedSymbol, "", banexg.MarketMargin)
+		}
+		res = append(res, item)
+	}
+	// 接口按时间倒序返回，这里转为正序
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res, nil
+}
@@ -0,0 +1,42 @@
+package binance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/banbox/banexg/utils"
+)
+
+func TestBorrowRepayMargin(t *testing.T) {
+	exg := getBinance(nil)
+	res, err := exg.BorrowMargin("USDT", 10, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(utils.MarshalString(res))
+	res, err = exg.RepayMargin("USDT", 10, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(utils.MarshalString(res))
+}
+
+func TestFetchBorrowRates(t *testing.T) {
+	exg := getBinance(nil)
+	items, err := exg.FetchBorrowRates([]string{"BTC", "USDT"}, nil)
+	if err != nil {
+		panic(err)
+	}
+	for _, it := range items {
+		it.Info = nil
+	}
+	fmt.Println(utils.MarshalString(items))
+	interests, err := exg.FetchBorrowInterest("USDT", "", 0, 10, nil)
+	if err != nil {
+		panic(err)
+	}
+	for _, it := range interests {
+		it.Info = nil
+	}
+	fmt.Println(utils.MarshalString(interests))
+}
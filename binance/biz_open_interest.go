@@ -0,0 +1,146 @@
+package binance
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+/*
+FetchOpenInterest
+retrieves the open interest of a contract trading pair
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#open-interest
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#open-interest
+	:see: https://binance-docs.github.io/apidocs/voptions/en/#open-interest
+	:param str symbol: unified CCXT market symbol
+	:param dict [params]: exchange specific parameters
+	:returns OpenInterest: an open interest structure
+*/
+func (e *Binance) FetchOpenInterest(symbol string, params map[string]interface{}) (*banexg.OpenInterest, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	var method string
+	if market.Option {
+		parts := strings.Split(market.ID, "-")
+		if len(parts) < 2 {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "invalid option symbol: %s", market.ID)
+		}
+		args["underlyingAsset"] = parts[0]
+		args["expiration"] = parts[1]
+		method = MethodEapiPublicGetOpenInterest
+	} else if market.Linear {
+		args["symbol"] = market.ID
+		method = MethodFapiPublicGetOpenInterest
+	} else if market.Inverse {
+		args["symbol"] = market.ID
+		method = MethodDapiPublicGetOpenInterest
+	} else {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "FetchOpenInterest support contract only")
+	}
+	tryNum := e.GetRetryNum("FetchOpenInterest", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	if market.Option {
+		// 期权按标的和到期日返回所有合约，这里筛选出当前合约
+		var data = make([]*OptionOpenInterest, 0)
+		items, err_ := utils.UnmarshalStringMapArr(rsp.Content, &data)
+		if err_ != nil {
+			return nil, errs.New(errs.CodeUnmarshalFail, err_)
+		}
+		for i, it := range data {
+			if it.Symbol == market.ID {
+				return it.ToStdOpenInterest(market.Symbol, items[i]), nil
+			}
+		}
+		return nil, errs.NewMsg(errs.CodeInvalidResponse, "no open interest for %s", market.ID)
+	}
+	var data = OpenInterestCur{}
+	info, err_ := utils.UnmarshalStringMap(rsp.Content, &data)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	amount, _ := strconv.ParseFloat(data.OpenInterest, 64)
+	return &banexg.OpenInterest{
+		Symbol:    market.Symbol,
+		Amount:    amount,
+		Timestamp: data.Time,
+		Info:      info,
+	}, nil
+}
+
+/*
+FetchOpenInterestHistory
+retrieves the open interest history of a currency, only data of latest 30 days is available
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#open-interest-statistics
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#open-interest-statistics
+	:param str symbol: unified CCXT market symbol
+	:param str period: "5m","15m","30m","1h","2h","4h","6h","12h", or "1d"
+	:param int [since]: the time(ms) of the earliest record to retrieve as a unix timestamp
+	:param int [limit]: default 30, max 500
+	:param dict [params]: exchange specific parameters
+	:param int [params.until]: the time(ms) of the latest record to retrieve as a unix timestamp
+	:returns OpenInterest[]: an array of open interest structure, sorted by time asc
+*/
+func (e *Binance) FetchOpenInterestHistory(symbol, period string, since int64, limit int, params map[string]interface{}) ([]*banexg.OpenInterest, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	if !market.Linear && !market.Inverse {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "FetchOpenInterestHistory support linear/inverse only")
+	}
+	method := MethodFapiDataGetOpenInterestHist
+	if market.Inverse {
+		method = MethodDapiDataGetOpenInterestHist
+	}
+	err = e.setFutDataArgs(args, market, period, since, limit, true)
+	if err != nil {
+		return nil, err
+	}
+	tryNum := e.GetRetryNum("FetchOpenInterestHistory", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var data = make([]*OpenInterestHis, 0)
+	items, err_ := utils.UnmarshalStringMapArr(banexg.EnsureArrStr(rsp.Content), &data)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var res = make([]*banexg.OpenInterest, 0, len(data))
+	for i, it := range data {
+		amount, _ := strconv.ParseFloat(it.SumOpenInterest, 64)
+		value, _ := strconv.ParseFloat(it.SumOpenInterestValue, 64)
+		res = append(res, &banexg.OpenInterest{
+			Symbol:    market.Symbol,
+			Amount:    amount,
+			Value:     value,
+			Timestamp: it.Timestamp,
+			Info:      items[i],
+		})
+	}
+	return res, nil
+}
+
+func (o *OptionOpenInterest) ToStdOpenInterest(symbol string, info map[string]interface{}) *banexg.OpenInterest {
+	amount, _ := strconv.ParseFloat(o.SumOpenInterest, 64)
+	value, _ := strconv.ParseFloat(o.SumOpenInterestUsd, 64)
+	stamp, _ := strconv.ParseInt(o.Timestamp, 10, 64)
+	return &banexg.OpenInterest{
+		Symbol:    symbol,
+		Amount:    amount,
+		Value:     value,
+		Timestamp: stamp,
+		Info:      info,
+	}
+}
@@ -39,8 +39,12 @@ func (e *Binance) FetchOrder(symbol, orderId string, params map[string]interface
 			args["origClientOrderId"] = clientOrderId
 		}
 	}
+	method, err = e.routeOrderMethod(method, args)
+	if err != nil {
+		return nil, err
+	}
 	tryNum := e.GetRetryNum("FetchOrder", 1)
-	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	rsp := e.requestOrderApi(context.Background(), method, args, tryNum)
 	if rsp.Error != nil {
 		return nil, rsp.Error
 	}
@@ -52,12 +56,14 @@ func (e *Binance) FetchOrder(symbol, orderId string, params map[string]interface
 		return parseOrder[*SpotOrder](mapSymbol, rsp)
 	case MethodEapiPrivateGetOrder:
 		return parseOrder[*OptionOrder](mapSymbol, rsp)
-	case MethodFapiPrivateGetOrder:
+	case MethodFapiPrivateGetOrder, MethodPapiGetUmOrder:
 		return parseOrder[*FutureOrder](mapSymbol, rsp)
-	case MethodDapiPrivateGetOrder:
+	case MethodDapiPrivateGetOrder, MethodPapiGetCmOrder:
 		return parseOrder[*InverseOrder](mapSymbol, rsp)
-	case MethodSapiGetMarginOrder:
+	case MethodSapiGetMarginOrder, MethodPapiGetMarginOrder:
 		return parseOrder[*MarginOrder](mapSymbol, rsp)
+	case MethodPapiGetUmConditionalOrderHistory, MethodPapiGetCmConditionalOrderHistory:
+		return parseOrder[*PapiStrategyOrder](mapSymbol, rsp)
 	default:
 		return nil, errs.NewMsg(errs.CodeNotSupport, "not support order method %s", method)
 	}
@@ -87,6 +93,10 @@ func (e *Binance) FetchOrders(symbol string, since int64, limit int, params map[
 			args["isIsolated"] = true
 		}
 	}
+	method, err = e.routeOrderMethod(method, args)
+	if err != nil {
+		return nil, err
+	}
 	until := utils.PopMapVal(args, banexg.ParamUntil, int64(0))
 	loopArgNum := 0
 	if until > 0 {
@@ -165,12 +175,14 @@ func (e *Binance) doFetchOrders(args map[string]interface{}, method string, mark
 		return parseOrders[*SpotOrder](mapSymbol, rsp)
 	case MethodEapiPrivateGetHistoryOrders:
 		return parseOrders[*OptionOrder](mapSymbol, rsp)
-	case MethodFapiPrivateGetAllOrders:
+	case MethodFapiPrivateGetAllOrders, MethodPapiGetUmAllOrders:
 		return parseOrders[*FutureOrder](mapSymbol, rsp)
-	case MethodDapiPrivateGetAllOrders:
+	case MethodDapiPrivateGetAllOrders, MethodPapiGetCmAllOrders:
 		return parseOrders[*InverseOrder](mapSymbol, rsp)
-	case MethodSapiGetMarginAllOrders:
+	case MethodSapiGetMarginAllOrders, MethodPapiGetMarginAllOrders:
 		return parseOrders[*MarginOrder](mapSymbol, rsp)
+	case MethodPapiGetUmConditionalAllOrders, MethodPapiGetCmConditionalAllOrders:
+		return parseOrders[*PapiStrategyOrder](mapSymbol, rsp)
 	default:
 		return nil, errs.NewMsg(errs.CodeNotSupport, "not support order method %s", method)
 	}
@@ -192,8 +204,10 @@ fetch all unfilled currently open orders
 :param str symbol: unified market symbol
 :param int [since]: the earliest time in ms to fetch open orders for
 :param int [limit]: the maximum number of open orders structures to retrieve
+:see: https://binance-docs.github.io/apidocs/pm/en/#query-all-current-um-open-orders-user_data
 :param dict [params]: extra parameters specific to the exchange API endpoint
 :param str [params.marginMode]: 'cross' or 'isolated', for spot margin trading
+:param bool [params.conditional]: fetch conditional orders of portfolio margin account
 :returns Order[]: a list of `order structures <https://docs.ccxt.com/#/?id=order-structure>`
 */
 func (e *Binance) FetchOpenOrders(symbol string, since int64, limit int, params map[string]interface{}) ([]*banexg.Order, *errs.Error) {
@@ -234,6 +248,10 @@ func (e *Binance) FetchOpenOrders(symbol string, since int64, limit int, params
 			}
 		}
 	}
+	method, err := e.routeOrderMethod(method, args)
+	if err != nil {
+		return nil, err
+	}
 	tryNum := e.GetRetryNum("FetchOpenOrders", 1)
 	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
 	if rsp.Error != nil {
@@ -253,12 +271,14 @@ func (e *Binance) FetchOpenOrders(symbol string, since int64, limit int, params
 		return parseOrders[*SpotOrder](mapSymbol, rsp)
 	case MethodEapiPrivateGetOpenOrders:
 		return parseOrders[*OptionOrder](mapSymbol, rsp)
-	case MethodFapiPrivateGetOpenOrders:
+	case MethodFapiPrivateGetOpenOrders, MethodPapiGetUmOpenOrders:
 		return parseOrders[*FutureOrder](mapSymbol, rsp)
-	case MethodDapiPrivateGetOpenOrders:
+	case MethodDapiPrivateGetOpenOrders, MethodPapiGetCmOpenOrders:
 		return parseOrders[*InverseOrder](mapSymbol, rsp)
-	case MethodSapiGetMarginOpenOrders:
+	case MethodSapiGetMarginOpenOrders, MethodPapiGetMarginOpenOrders:
 		return parseOrders[*MarginOrder](mapSymbol, rsp)
+	case MethodPapiGetUmConditionalOpenOrders, MethodPapiGetCmConditionalOpenOrders:
+		return parseOrders[*PapiStrategyOrder](mapSymbol, rsp)
 	default:
 		return nil, errs.NewMsg(errs.CodeNotSupport, "not support order method %s", method)
 	}
@@ -289,17 +309,21 @@ func (e *Binance) EditOrder(symbol, orderId, side string, amount, price float64,
 	} else {
 		return nil, errs.NewMsg(errs.CodeParamInvalid, "EditOrder not available in spot/margin market")
 	}
+	method, err = e.routeOrderMethod(method, args)
+	if err != nil {
+		return nil, err
+	}
 	tryNum := e.GetRetryNum("EditOrder", 1)
-	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	rsp := e.requestOrderApi(context.Background(), method, args, tryNum)
 	if rsp.Error != nil {
 		return nil, rsp.Error
 	}
 	var mapSymbol = func(mid string) string {
 		return market.Symbol
 	}
-	if method == MethodFapiPrivatePutOrder {
+	if method == MethodFapiPrivatePutOrder || method == MethodPapiPutUmOrder {
 		return parseOrder[*FutureOrder](mapSymbol, rsp)
-	} else if method == MethodDapiPrivatePutOrder {
+	} else if method == MethodDapiPrivatePutOrder || method == MethodPapiPutCmOrder {
 		return parseOrder[*InverseOrder](mapSymbol, rsp)
 	} else {
 		return nil, errs.NewMsg(errs.CodeRunTime, "invalid method for EditOrder: %s", method)
@@ -315,9 +339,11 @@ cancels an open order
 	:see: https://binance-docs.github.io/apidocs/delivery/en/#cancel-order-trade
 	:see: https://binance-docs.github.io/apidocs/voptions/en/#cancel-option-order-trade
 	:see: https://binance-docs.github.io/apidocs/spot/en/#margin-account-cancel-order-trade
+	:see: https://binance-docs.github.io/apidocs/pm/en/#cancel-um-order-trade
 	:param str id: order id
 	:param str symbol: unified symbol of the market the order was made in
 	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param bool [params.conditional]: cancel conditional order of portfolio margin account
 	:returns dict: An `order structure <https://docs.ccxt.com/#/?id=order-structure>`
 */
 func (e *Binance) CancelOrder(id string, symbol string, params map[string]interface{}) (*banexg.Order, *errs.Error) {
@@ -350,18 +376,24 @@ func (e *Binance) CancelOrder(id string, symbol string, params map[string]interf
 			args["isIsolated"] = true
 		}
 	}
+	method, err = e.routeOrderMethod(method, args)
+	if err != nil {
+		return nil, err
+	}
 	tryNum := e.GetRetryNum("CancelOrder", 1)
-	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	rsp := e.requestOrderApi(context.Background(), method, args, tryNum)
 	if rsp.Error != nil {
 		return nil, rsp.Error
 	}
 	var mapSymbol = func(mid string) string {
 		return market.Symbol
 	}
-	if method == MethodFapiPrivateDeleteOrder {
+	if method == MethodFapiPrivateDeleteOrder || method == MethodPapiDeleteUmOrder {
 		return parseOrder[*FutureOrder](mapSymbol, rsp)
-	} else if method == MethodDapiPrivateDeleteOrder {
+	} else if method == MethodDapiPrivateDeleteOrder || method == MethodPapiDeleteCmOrder {
 		return parseOrder[*InverseOrder](mapSymbol, rsp)
+	} else if method == MethodPapiDeleteUmConditionalOrder || method == MethodPapiDeleteCmConditionalOrder {
+		return parseOrder[*PapiStrategyOrder](mapSymbol, rsp)
 	} else if method == MethodEapiPrivateDeleteOrder {
 		return parseOrder[*OptionOrder](mapSymbol, rsp)
 	} else {
@@ -477,6 +509,9 @@ func (o *SpotBase) ToStdOrder(mapSymbol func(string) string, info map[string]int
 func (o *SpotOrder) ToStdOrder(mapSymbol func(string) string, info map[string]interface{}) *banexg.Order {
 	result := o.SpotBase.ToStdOrder(mapSymbol, info)
 	result.Info = info
+	if o.OrderListId > 0 {
+		result.ListID = strconv.Itoa(o.OrderListId)
+	}
 	timeStamp := int64(0)
 	if o.Time > 0 {
 		timeStamp = o.Time
@@ -0,0 +1,360 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+/*
+CreateOrders
+批量下单。U本位/币本位/期权使用batchOrders接口（每次最多5/5/10个），现货和杠杆逐个提交。
+不同账户(ParamAccount)的订单分开提交。
+单个订单失败时，错误记录在对应的OrderRes.Error中，不影响其他订单
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#place-multiple-orders-trade
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#place-multiple-orders-trade
+	:see: https://binance-docs.github.io/apidocs/voptions/en/#place-multiple-orders-trade
+	:param OrderArgs[] odArgs: orders to create, each is validated first, OrderArgs fields override params
+	:param dict [params]: extra parameters shared by all orders
+	:returns OrderRes[]: results in the same order as odArgs
+*/
+func (e *Binance) CreateOrders(odArgs []*banexg.OrderArgs, params map[string]interface{}) ([]*banexg.OrderRes, *errs.Error) {
+	var result = make([]*banexg.OrderRes, len(odArgs))
+	var reqArgs = make([]map[string]interface{}, len(odArgs))
+	var markets = make([]*banexg.Market, len(odArgs))
+	// 按(接口, 账户)分组，同一批次只能属于一个账户
+	var groups = make(map[string][]int)
+	var groupKeys = make([]string, 0, 2)
+	var groupMethods = make(map[string]string)
+	for i, a := range odArgs {
+		res := &banexg.OrderRes{}
+		result[i] = res
+		if err := a.Validate(); err != nil {
+			res.Error = err
+			continue
+		}
+		odParams := utils.SafeParams(params)
+		maps.Copy(odParams, a.ToParams())
+		args, market, method, err := e.makeOrderArgs(a.Symbol, a.Type, a.Side, a.Amount, a.Price, odParams)
+		if err != nil {
+			res.Error = err
+			continue
+		}
+		reqArgs[i] = args
+		markets[i] = market
+		accName := utils.GetMapVal(args, banexg.ParamAccount, "")
+		key := method + "@" + accName
+		if _, ok := groups[key]; !ok {
+			groupKeys = append(groupKeys, key)
+			groupMethods[key] = method
+		}
+		groups[key] = append(groups[key], i)
+	}
+	for _, key := range groupKeys {
+		idxList := groups[key]
+		method := groupMethods[key]
+		batchMethod, batchSize := getBatchCreateMethod(method)
+		if batchMethod == "" {
+			// 现货/杠杆/统一账户没有批量下单接口，逐个提交
+			for _, i := range idxList {
+				result[i].Order, result[i].Error = e.sendOrder(method, markets[i], reqArgs[i])
+			}
+			continue
+		}
+		for start := 0; start < len(idxList); start += batchSize {
+			batch := idxList[start:min(len(idxList), start+batchSize)]
+			e.sendBatchOrders(batchMethod, batch, reqArgs, markets, result)
+		}
+	}
+	return result, nil
+}
+
+func getBatchCreateMethod(method string) (string, int) {
+	switch method {
+	case MethodFapiPrivatePostOrder:
+		return MethodFapiPrivatePostBatchOrders, 5
+	case MethodDapiPrivatePostOrder:
+		return MethodDapiPrivatePostBatchOrders, 5
+	case MethodEapiPrivatePostOrder:
+		return MethodEapiPrivatePostBatchOrders, 10
+	default:
+		return "", 0
+	}
+}
+
+func (e *Binance) sendBatchOrders(method string, batch []int, reqArgs []map[string]interface{},
+	markets []*banexg.Market, result []*banexg.OrderRes) {
+	var items = make([]map[string]string, 0, len(batch))
+	var marketMap = make(map[string]*banexg.Market)
+	var accName string
+	var ctx = context.Background()
+	for _, i := range batch {
+		args := reqArgs[i]
+		accName = utils.PopMapVal(args, banexg.ParamAccount, "")
+		ctx = utils.PopMapVal(args, banexg.ParamContext, ctx)
+		item := make(map[string]string, len(args))
+		for k, v := range args {
+			item[k] = fmt.Sprintf("%v", v)
+		}
+		items = append(items, item)
+		marketMap[markets[i].ID] = markets[i]
+	}
+	setErr := func(err *errs.Error) {
+		for _, i := range batch {
+			result[i].Error = err
+		}
+	}
+	itemsText, err_ := utils.MarshalString(items)
+	if err_ != nil {
+		setErr(errs.New(errs.CodeMarshalFail, err_))
+		return
+	}
+	args := map[string]interface{}{}
+	if accName != "" {
+		args[banexg.ParamAccount] = accName
+	}
+	if method == MethodEapiPrivatePostBatchOrders {
+		args["orders"] = itemsText
+	} else {
+		args["batchOrders"] = itemsText
+	}
+	tryNum := e.GetRetryNum("CreateOrders", 1)
+	rsp := e.RequestApiRetry(ctx, method, args, tryNum)
+	if rsp.Error != nil {
+		setErr(rsp.Error)
+		return
+	}
+	var mapSymbol = func(mid string) string {
+		if market, ok := marketMap[mid]; ok {
+			return market.Symbol
+		}
+		return ""
+	}
+	var resList []*banexg.OrderRes
+	var err *errs.Error
+	if method == MethodFapiPrivatePostBatchOrders {
+		resList, err = parseBatchOrders[*FutureOrder](mapSymbol, rsp)
+	} else if method == MethodDapiPrivatePostBatchOrders {
+		resList, err = parseBatchOrders[*InverseOrder](mapSymbol, rsp)
+	} else {
+		resList, err = parseBatchOrders[*OptionOrder](mapSymbol, rsp)
+	}
+	if err != nil {
+		setErr(err)
+		return
+	}
+	for j, i := range batch {
+		if j < len(resList) {
+			result[i] = resList[j]
+		} else {
+			result[i].Error = errs.NewMsg(errs.CodeInvalidResponse, "batch order result missing")
+		}
+	}
+}
+
+/*
+parseBatchOrders
+解析批量接口返回，每一项为订单或{"code":xx,"msg":""}格式的错误
+*/
+func parseBatchOrders[T IBnbOrder](mapSymbol func(string) string, rsp *banexg.HttpRes) ([]*banexg.OrderRes, *errs.Error) {
+	var items = make([]map[string]interface{}, 0)
+	err := utils.UnmarshalString(rsp.Content, &items, utils.JsonNumAuto)
+	if err != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err)
+	}
+	var result = make([]*banexg.OrderRes, 0, len(items))
+	for _, item := range items {
+		res := &banexg.OrderRes{}
+		result = append(result, res)
+		if _, ok := item["orderId"]; !ok {
+			code := utils.GetMapVal(item, "code", int64(0))
+			msg := utils.GetMapVal(item, "msg", "")
+			res.Error = errs.NewMsg(errs.CodeRunTime, msg)
+			res.Error.BizCode = int(code)
+			continue
+		}
+		text, err_ := utils.MarshalString(item)
+		if err_ != nil {
+			res.Error = errs.New(errs.CodeMarshalFail, err_)
+			continue
+		}
+		res.Order, res.Error = parseOrder[T](mapSymbol, &banexg.HttpRes{Content: text})
+	}
+	return result, nil
+}
+
+/*
+CancelOrders
+批量撤销某个标的的订单。U本位/币本位/期权使用batchOrders接口（每次最多10个），现货、杠杆和统一账户逐个撤销。
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#cancel-multiple-orders-trade
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#cancel-multiple-orders-trade
+	:see: https://binance-docs.github.io/apidocs/voptions/en/#cancel-multiple-option-orders-trade
+	:param str symbol: unified market symbol
+	:param str[] ids: order ids, can be empty when params.origClientOrderIdList is provided
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str[] [params.origClientOrderIdList]: client order ids to cancel
+	:returns OrderRes[]: results in the same order as ids
+*/
+func (e *Binance) CancelOrders(symbol string, ids []string, params map[string]interface{}) ([]*banexg.OrderRes, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	clientIds := utils.PopMapVal(args, banexg.ParamOrigClientOrderIDs, []string(nil))
+	keys, isClient := ids, false
+	if len(keys) == 0 {
+		if len(clientIds) == 0 {
+			return nil, errs.NewMsg(errs.CodeParamRequired, "ids or %s is required", banexg.ParamOrigClientOrderIDs)
+		}
+		keys, isClient = clientIds, true
+	}
+	var method string
+	if market.Option {
+		method = MethodEapiPrivateDeleteBatchOrders
+	} else if e.isPortfolioMargin(args) {
+		// 统一账户papi没有批量撤单接口，由CancelOrder路由到papi逐个撤销
+	} else if market.Linear {
+		method = MethodFapiPrivateDeleteBatchOrders
+	} else if market.Inverse {
+		method = MethodDapiPrivateDeleteBatchOrders
+	}
+	if method == "" {
+		// 现货/杠杆/统一账户没有批量撤单接口，逐个撤销
+		var result = make([]*banexg.OrderRes, 0, len(keys))
+		for _, id := range keys {
+			odArgs := utils.SafeParams(params)
+			delete(odArgs, banexg.ParamOrigClientOrderIDs)
+			if isClient {
+				odArgs[banexg.ParamClientOrderId] = id
+			}
+			res := &banexg.OrderRes{}
+			res.Order, res.Error = e.CancelOrder(id, symbol, odArgs)
+			result = append(result, res)
+		}
+		return result, nil
+	}
+	args["symbol"] = market.ID
+	var mapSymbol = func(mid string) string {
+		return market.Symbol
+	}
+	var result = make([]*banexg.OrderRes, 0, len(keys))
+	batchSize := 10
+	for start := 0; start < len(keys); start += batchSize {
+		batch := keys[start:min(len(keys), start+batchSize)]
+		reqArgs := maps.Clone(args)
+		if isClient {
+			idText, _ := utils.MarshalString(batch)
+			if market.Option {
+				reqArgs["clientOrderIds"] = idText
+			} else {
+				reqArgs[banexg.ParamOrigClientOrderIDs] = idText
+			}
+		} else {
+			idText := "[" + strings.Join(batch, ",") + "]"
+			if market.Option {
+				reqArgs["orderIds"] = idText
+			} else {
+				reqArgs[banexg.ParamOrderIds] = idText
+			}
+		}
+		tryNum := e.GetRetryNum("CancelOrders", 1)
+		rsp := e.RequestApiRetry(context.Background(), method, reqArgs, tryNum)
+		var resList []*banexg.OrderRes
+		if rsp.Error != nil {
+			err = rsp.Error
+		} else if method == MethodFapiPrivateDeleteBatchOrders {
+			resList, err = parseBatchOrders[*FutureOrder](mapSymbol, rsp)
+		} else if method == MethodDapiPrivateDeleteBatchOrders {
+			resList, err = parseBatchOrders[*InverseOrder](mapSymbol, rsp)
+		} else {
+			resList, err = parseBatchOrders[*OptionOrder](mapSymbol, rsp)
+		}
+		for j := range batch {
+			if err != nil {
+				result = append(result, &banexg.OrderRes{Error: err})
+			} else if j < len(resList) {
+				result = append(result, resList[j])
+			} else {
+				result = append(result, &banexg.OrderRes{
+					Error: errs.NewMsg(errs.CodeInvalidResponse, "batch cancel result missing"),
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+/*
+CancelAllOrders
+撤销某个标的的全部挂单。现货/杠杆返回被撤销的订单，合约和期权接口不返回订单详情，结果为空列表
+
+	:see: https://binance-docs.github.io/apidocs/spot/en/#cancel-all-open-orders-on-a-symbol-trade
+	:see: https://binance-docs.github.io/apidocs/spot/en/#margin-account-cancel-all-open-orders-on-a-symbol-trade
+	:see: https://binance-docs.github.io/apidocs/futures/en/#cancel-all-open-orders-trade
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#cancel-all-open-orders-trade
+	:see: https://binance-docs.github.io/apidocs/voptions/en/#cancel-all-option-orders-on-specific-symbol-trade
+	:see: https://binance-docs.github.io/apidocs/pm/en/#cancel-all-um-open-orders-trade
+	:param str symbol: unified market symbol
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.marginMode]: 'cross' or 'isolated', for spot margin trading
+	:param bool [params.conditional]: cancel conditional orders of portfolio margin account
+	:returns Order[]: canceled orders
+*/
+func (e *Binance) CancelAllOrders(symbol string, params map[string]interface{}) ([]*banexg.Order, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	marginMode := utils.PopMapVal(args, banexg.ParamMarginMode, "")
+	args["symbol"] = market.ID
+	method := MethodPrivateDeleteOpenOrders
+	if market.Option {
+		method = MethodEapiPrivateDeleteAllOpenOrders
+	} else if market.Linear {
+		method = MethodFapiPrivateDeleteAllOpenOrders
+	} else if market.Inverse {
+		method = MethodDapiPrivateDeleteAllOpenOrders
+	} else if market.Type == banexg.MarketMargin || marginMode != "" {
+		method = MethodSapiDeleteMarginOpenOrders
+		if marginMode == banexg.MarginIsolated {
+			args["isIsolated"] = true
+		}
+	}
+	method, err = e.routeOrderMethod(method, args)
+	if err != nil {
+		return nil, err
+	}
+	tryNum := e.GetRetryNum("CancelAllOrders", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var mapSymbol = func(mid string) string {
+		return market.Symbol
+	}
+	switch method {
+	case MethodPrivateDeleteOpenOrders:
+		return parseOrders[*SpotOrder](mapSymbol, rsp)
+	case MethodSapiDeleteMarginOpenOrders, MethodPapiDeleteMarginAllOpenOrders:
+		return parseOrders[*MarginOrder](mapSymbol, rsp)
+	default:
+		var res = ErrRsp{}
+		err_ := utils.UnmarshalString(rsp.Content, &res, utils.JsonNumDefault)
+		if err_ != nil {
+			return nil, errs.New(errs.CodeUnmarshalFail, err_)
+		}
+		if res.Code != 0 && res.Code != 200 {
+			err = errs.NewMsg(errs.CodeRunTime, res.Msg)
+			err.BizCode = res.Code
+			return nil, err
+		}
+		return []*banexg.Order{}, nil
+	}
+}
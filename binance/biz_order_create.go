@@ -38,10 +38,30 @@ CreateOrder 提交订单到交易所
 	:returns dict: an `order structure <https://docs.ccxt.com/#/?id=order-structure>`
 */
 func (e *Binance) CreateOrder(symbol, odType, side string, amount float64, price float64, params map[string]interface{}) (*banexg.Order, *errs.Error) {
-	args, market, err := e.LoadArgsMarket(symbol, params)
+	args, market, method, err := e.makeOrderArgs(symbol, odType, side, amount, price, params)
 	if err != nil {
 		return nil, err
 	}
+	return e.sendOrder(method, market, args)
+}
+
+/*
+CreateOrderBy
+使用类型化参数下单，提交前检查参数有效性及互斥选项
+*/
+func (e *Binance) CreateOrderBy(args *banexg.OrderArgs) (*banexg.Order, *errs.Error) {
+	return banexg.CreateOrderBy(e, args)
+}
+
+/*
+makeOrderArgs
+校验下单参数，返回交易所请求参数和对应的接口method
+*/
+func (e *Binance) makeOrderArgs(symbol, odType, side string, amount float64, price float64, params map[string]interface{}) (map[string]interface{}, *banexg.Market, string, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, nil, "", err
+	}
 	marginMode := utils.PopMapVal(args, banexg.ParamMarginMode, "")
 	sor := utils.PopMapVal(args, banexg.ParamSor, false)
 	clientOrderId := utils.PopMapVal(args, banexg.ParamClientOrderId, "")
@@ -49,9 +69,9 @@ func (e *Binance) CreateOrder(symbol, odType, side string, amount float64, price
 	timeInForce := utils.GetMapVal(args, banexg.ParamTimeInForce, "")
 	if postOnly || timeInForce == banexg.TimeInForcePO || odType == banexg.OdTypeLimitMaker {
 		if timeInForce == banexg.TimeInForceIOC || timeInForce == banexg.TimeInForceFOK {
-			return nil, errs.NewMsg(errs.CodeParamInvalid, "postOnly orders cannot have timeInForce: %s", timeInForce)
+			return nil, nil, "", errs.NewMsg(errs.CodeParamInvalid, "postOnly orders cannot have timeInForce: %s", timeInForce)
 		} else if odType == banexg.OdTypeMarket {
-			return nil, errs.NewMsg(errs.CodeParamInvalid, "market orders cannot be postOnly")
+			return nil, nil, "", errs.NewMsg(errs.CodeParamInvalid, "market orders cannot be postOnly")
 		}
 		postOnly = true
 	}
@@ -130,10 +150,10 @@ func (e *Binance) CreateOrder(symbol, odType, side string, amount float64, price
 	exgOdType := strings.ToUpper(odType)
 	if market.Option {
 		if odType == banexg.OdTypeMarket {
-			return nil, errs.NewMsg(errs.CodeParamInvalid, "market order is invalid for option")
+			return nil, nil, "", errs.NewMsg(errs.CodeParamInvalid, "market order is invalid for option")
 		}
 	} else if !isBnbOrderType(market, exgOdType) {
-		return nil, errs.NewMsg(errs.CodeParamInvalid, "invalid order type %s for %s market", exgOdType, market.Type)
+		return nil, nil, "", errs.NewMsg(errs.CodeParamInvalid, "invalid order type %s for %s market", exgOdType, market.Type)
 	}
 	args["type"] = exgOdType
 	timeInForceRequired, priceRequired, stopPriceRequired, quantityRequired := false, false, false, false
@@ -167,7 +187,7 @@ func (e *Binance) CreateOrder(symbol, odType, side string, amount float64, price
 			if cost != 0 {
 				precRes, err := e.PrecCost(market, cost)
 				if err != nil {
-					return nil, err
+					return nil, nil, "", err
 				}
 				args["quoteOrderQty"] = precRes
 				quantityRequired = false
@@ -205,23 +225,23 @@ func (e *Binance) CreateOrder(symbol, odType, side string, amount float64, price
 		quantityRequired = true
 		callBackRate := utils.GetMapVal(args, banexg.ParamCallbackRate, 0.0)
 		if callBackRate == 0 {
-			return nil, errs.NewMsg(errs.CodeParamRequired, "createOrder require callbackRate for %s order", odType)
+			return nil, nil, "", errs.NewMsg(errs.CodeParamRequired, "createOrder require callbackRate for %s order", odType)
 		}
 	}
 	if quantityRequired {
 		amtStr, err := e.PrecAmount(market, amount)
 		if err != nil {
-			return nil, err
+			return nil, nil, "", err
 		}
 		args["quantity"] = amtStr
 	}
 	if priceRequired {
 		if price == 0 {
-			return nil, errs.NewMsg(errs.CodeParamRequired, "createOrder require price for %s order", odType)
+			return nil, nil, "", errs.NewMsg(errs.CodeParamRequired, "createOrder require price for %s order", odType)
 		}
 		priceStr, err := e.PrecPrice(market, price)
 		if err != nil {
-			return nil, err
+			return nil, nil, "", err
 		}
 		args["price"] = priceStr
 	}
@@ -234,15 +254,15 @@ func (e *Binance) CreateOrder(symbol, odType, side string, amount float64, price
 	if stopPriceRequired {
 		if market.Contract {
 			if stopPrice == 0 {
-				return nil, errs.NewMsg(errs.CodeParamRequired, "createOrder require stopPrice for %s order", odType)
+				return nil, nil, "", errs.NewMsg(errs.CodeParamRequired, "createOrder require stopPrice for %s order", odType)
 			}
 		} else if trailingDelta == 0 && stopPrice == 0 {
-			return nil, errs.NewMsg(errs.CodeParamRequired, "createOrder require stopPrice/trailingDelta for %s order", odType)
+			return nil, nil, "", errs.NewMsg(errs.CodeParamRequired, "createOrder require stopPrice/trailingDelta for %s order", odType)
 		}
 		if stopPrice != 0 {
 			stopPriceStr, err := e.PrecPrice(market, stopPrice)
 			if err != nil {
-				return nil, err
+				return nil, nil, "", err
 			}
 			args["stopPrice"] = stopPriceStr
 		}
@@ -268,18 +288,31 @@ func (e *Binance) CreateOrder(symbol, odType, side string, amount float64, price
 			method += "Test"
 		}
 	}
+	if e.isPortfolioMargin(args) {
+		// 统一账户的U本位、币本位、全仓杠杆订单通过papi提交
+		if marginMode == banexg.MarginIsolated {
+			return nil, nil, "", errs.NewMsg(errs.CodeUnsupportMarket, "isolated margin is not supported for portfolio margin account")
+		}
+		method = papiOrderMethod(method, args)
+	}
+	return args, market, method, nil
+}
+
+func (e *Binance) sendOrder(method string, market *banexg.Market, args map[string]interface{}) (*banexg.Order, *errs.Error) {
 	tryNum := e.GetRetryNum("CreateOrder", 1)
-	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	rsp := e.requestOrderApi(context.Background(), method, args, tryNum)
 	if rsp.Error != nil {
 		return nil, rsp.Error
 	}
 	var mapSymbol = func(mid string) string {
 		return market.Symbol
 	}
-	if method == MethodFapiPrivatePostOrder {
+	if method == MethodFapiPrivatePostOrder || method == MethodPapiPostUmOrder {
 		return parseOrder[*FutureOrder](mapSymbol, rsp)
-	} else if method == MethodDapiPrivatePostOrder {
+	} else if method == MethodDapiPrivatePostOrder || method == MethodPapiPostCmOrder {
 		return parseOrder[*InverseOrder](mapSymbol, rsp)
+	} else if method == MethodPapiPostUmConditionalOrder || method == MethodPapiPostCmConditionalOrder {
+		return parseOrder[*PapiStrategyOrder](mapSymbol, rsp)
 	} else if method == MethodEapiPrivatePostOrder {
 		return parseOrder[*OptionOrder](mapSymbol, rsp)
 	} else {
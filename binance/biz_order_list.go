@@ -0,0 +1,307 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+var orderListMethods = map[string]string{
+	banexg.OrderListOCO:   MethodPrivatePostOrderListOco,
+	banexg.OrderListOTO:   MethodPrivatePostOrderListOto,
+	banexg.OrderListOTOCO: MethodPrivatePostOrderListOtoco,
+}
+
+// orderListLegKeys 单个订单的请求参数: 订单组中子订单参数的后缀，如price -> abovePrice/workingPrice
+var orderListLegKeys = map[string]string{
+	"type":             "Type",
+	"side":             "Side",
+	"quantity":         "Quantity",
+	"price":            "Price",
+	"stopPrice":        "StopPrice",
+	"trailingDelta":    "TrailingDelta",
+	"timeInForce":      "TimeInForce",
+	"newClientOrderId": "ClientOrderId",
+	"icebergQty":       "IcebergQty",
+	"strategyId":       "StrategyId",
+	"strategyType":     "StrategyType",
+}
+
+/*
+CreateOrderList
+创建现货关联订单组，legs顺序：
+OCO: [订单1, 订单2]，需同方向同数量，按触发价/价格自动区分above/below，限价单自动转为LIMIT_MAKER
+OTO: [工作订单, 待执行订单]
+OTOCO: [工作订单, 待执行订单1, 待执行订单2]，待执行订单需同方向同数量，按触发价/价格区分above/below
+
+	:see: https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#new-order-list---oco-trade
+	:see: https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#new-order-list---oto-trade
+	:see: https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#new-order-list---otoco-trade
+	:param str kind: OrderListOCO/OrderListOTO/OrderListOTOCO
+	:param OrderArgs[] legs: child orders of the same symbol, each is validated first
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.clientOrderId]: client id of the order list
+	:returns OrderList: the order list with child orders
+*/
+func (e *Binance) CreateOrderList(kind string, legs []*banexg.OrderArgs, params map[string]interface{}) (*banexg.OrderList, *errs.Error) {
+	method, ok := orderListMethods[kind]
+	if !ok {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "invalid order list kind: %s", kind)
+	}
+	legNum := 2
+	if kind == banexg.OrderListOTOCO {
+		legNum = 3
+	}
+	if len(legs) != legNum {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "%s order list require %d legs, current: %d", kind, legNum, len(legs))
+	}
+	var legArgs = make([]map[string]interface{}, len(legs))
+	for i, leg := range legs {
+		err := leg.Validate()
+		if err != nil {
+			return nil, err
+		}
+		if leg.Symbol != legs[0].Symbol {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "legs of order list must have same symbol")
+		}
+		legParams := leg.ToParams()
+		if acc, ok := params[banexg.ParamAccount]; ok {
+			legParams[banexg.ParamAccount] = acc
+		}
+		args, _, legMethod, err := e.makeOrderArgs(leg.Symbol, leg.Type, leg.Side, leg.Amount, leg.Price, legParams)
+		if err != nil {
+			return nil, err
+		}
+		if legMethod != MethodPrivatePostOrder {
+			return nil, errs.NewMsg(errs.CodeUnsupportMarket, "order list only support spot market")
+		}
+		if _, ok := args["quoteOrderQty"]; ok {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "legs of order list must use amount instead of cost")
+		}
+		if leg.TrailingDelta != 0 {
+			args["trailingDelta"] = leg.TrailingDelta
+		}
+		legArgs[i] = args
+	}
+	args, market, err := e.LoadArgsMarket(legs[0].Symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	args["symbol"] = market.ID
+	listClientId := utils.PopMapVal(args, banexg.ParamClientOrderId, "")
+	if listClientId != "" {
+		args["listClientOrderId"] = listClientId
+	}
+	switch kind {
+	case banexg.OrderListOCO:
+		err = setOcoListLegs(args, "", legArgs[0], legArgs[1])
+	case banexg.OrderListOTO:
+		setListLeg(args, "working", legArgs[0])
+		setListLeg(args, "pending", legArgs[1])
+	case banexg.OrderListOTOCO:
+		setListLeg(args, "working", legArgs[0])
+		err = setOcoListLegs(args, "pending", legArgs[1], legArgs[2])
+	}
+	if err != nil {
+		return nil, err
+	}
+	tryNum := e.GetRetryNum("CreateOrder", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	return parseOrderList(func(mid string) string { return market.Symbol }, rsp)
+}
+
+/*
+CancelOrderList
+撤销现货关联订单组，组内未完成的订单全部撤销
+
+	:see: https://developers.binance.com/docs/binance-spot-api-docs/rest-api/trading-endpoints#cancel-order-list-trade
+	:param str id: order list id, can be empty when params.clientOrderId is set
+	:param str symbol: unified market symbol
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.clientOrderId]: client id of the order list
+	:returns OrderList: the canceled order list
+*/
+func (e *Binance) CancelOrderList(id string, symbol string, params map[string]interface{}) (*banexg.OrderList, *errs.Error) {
+	args, market, err := e.loadOrderListArgs(id, symbol, "listClientOrderId", params)
+	if err != nil {
+		return nil, err
+	}
+	tryNum := e.GetRetryNum("CancelOrder", 1)
+	rsp := e.RequestApiRetry(context.Background(), MethodPrivateDeleteOrderList, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	return parseOrderList(func(mid string) string { return market.Symbol }, rsp)
+}
+
+/*
+FetchOrderList
+查询现货关联订单组，并逐个查询子订单详情
+
+	:see: https://developers.binance.com/docs/binance-spot-api-docs/rest-api/account-endpoints#query-order-list-user_data
+	:param str id: order list id, can be empty when params.clientOrderId is set
+	:param str symbol: unified market symbol
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.clientOrderId]: client id of the order list
+	:returns OrderList: the order list with child orders
+*/
+func (e *Binance) FetchOrderList(id string, symbol string, params map[string]interface{}) (*banexg.OrderList, *errs.Error) {
+	args, market, err := e.loadOrderListArgs(id, symbol, "origClientOrderId", params)
+	if err != nil {
+		return nil, err
+	}
+	delete(args, "symbol")
+	tryNum := e.GetRetryNum("FetchOrder", 1)
+	rsp := e.RequestApiRetry(context.Background(), MethodPrivateGetOrderList, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	res, err := parseOrderList(func(mid string) string { return market.Symbol }, rsp)
+	if err != nil {
+		return nil, err
+	}
+	// 查询接口仅返回子订单ID，需查询子订单详情
+	odParams := utils.SafeParams(params)
+	delete(odParams, banexg.ParamClientOrderId)
+	for i, od := range res.Orders {
+		detail, err := e.FetchOrder(symbol, od.ID, odParams)
+		if err != nil {
+			return nil, err
+		}
+		detail.ListID = res.ID
+		res.Orders[i] = detail
+	}
+	return res, nil
+}
+
+func (e *Binance) loadOrderListArgs(id, symbol, clientIdKey string, params map[string]interface{}) (map[string]interface{}, *banexg.Market, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !market.Spot {
+		return nil, nil, errs.NewMsg(errs.CodeUnsupportMarket, "order list only support spot market")
+	}
+	args["symbol"] = market.ID
+	clientId := utils.PopMapVal(args, banexg.ParamClientOrderId, "")
+	if id != "" {
+		args["orderListId"] = id
+	} else if clientId != "" {
+		args[clientIdKey] = clientId
+	} else {
+		return nil, nil, errs.NewMsg(errs.CodeParamRequired, "id or clientOrderId is required for order list")
+	}
+	return args, market, nil
+}
+
+// listLegKey 组合订单组参数名，如: ("", "above") -> above, ("pending", "above") -> pendingAbove
+func listLegKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + strings.ToUpper(key[:1]) + key[1:]
+}
+
+func setListLeg(args map[string]interface{}, prefix string, leg map[string]interface{}, skips ...string) {
+	for k, v := range leg {
+		suffix, ok := orderListLegKeys[k]
+		if !ok || utils.ArrContains(skips, k) {
+			continue
+		}
+		args[prefix+suffix] = v
+	}
+}
+
+/*
+setOcoListLegs
+OCO的两个订单共用side和quantity，触发价/价格较高的为above，较低的为below
+*/
+func setOcoListLegs(args map[string]interface{}, prefix string, a, b map[string]interface{}) *errs.Error {
+	if a["side"] != b["side"] || fmt.Sprintf("%v", a["quantity"]) != fmt.Sprintf("%v", b["quantity"]) {
+		return errs.NewMsg(errs.CodeParamInvalid, "oco legs require same side and amount")
+	}
+	above, below := a, b
+	if ocoLegPrice(a) < ocoLegPrice(b) {
+		above, below = b, a
+	}
+	args[listLegKey(prefix, "side")] = a["side"]
+	args[listLegKey(prefix, "quantity")] = a["quantity"]
+	for _, leg := range []map[string]interface{}{above, below} {
+		if leg["type"] == "LIMIT" {
+			// OCO中的限价单只能是LIMIT_MAKER
+			leg["type"] = "LIMIT_MAKER"
+			delete(leg, "timeInForce")
+		}
+	}
+	setListLeg(args, listLegKey(prefix, "above"), above, "side", "quantity")
+	setListLeg(args, listLegKey(prefix, "below"), below, "side", "quantity")
+	return nil
+}
+
+func ocoLegPrice(leg map[string]interface{}) float64 {
+	text := fmt.Sprintf("%v", leg["stopPrice"])
+	if _, ok := leg["stopPrice"]; !ok {
+		text = fmt.Sprintf("%v", leg["price"])
+	}
+	price, _ := strconv.ParseFloat(text, 64)
+	return price
+}
+
+func parseOrderList(mapSymbol func(string) string, rsp *banexg.HttpRes) (*banexg.OrderList, *errs.Error) {
+	var data = OrderListRsp{}
+	info, err := utils.UnmarshalStringMap(rsp.Content, &data)
+	if err != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err)
+	}
+	return data.ToStdOrderList(mapSymbol, info), nil
+}
+
+func (o *OrderListRsp) ToStdOrderList(mapSymbol func(string) string, info map[string]interface{}) *banexg.OrderList {
+	listId := strconv.Itoa(o.OrderListId)
+	kind := strings.ToLower(o.ContingencyType)
+	if kind == banexg.OrderListOTO && len(o.Orders) == 3 {
+		// OTOCO返回的contingencyType也是OTO
+		kind = banexg.OrderListOTOCO
+	}
+	var orders = make([]*banexg.Order, 0, len(o.Orders))
+	if len(o.OrderReports) > 0 {
+		reports, _ := info["orderReports"].([]interface{})
+		for i, od := range o.OrderReports {
+			var odInfo map[string]interface{}
+			if i < len(reports) {
+				odInfo, _ = reports[i].(map[string]interface{})
+			}
+			item := od.ToStdOrder(mapSymbol, odInfo)
+			item.ListID = listId
+			orders = append(orders, item)
+		}
+	} else {
+		for _, od := range o.Orders {
+			orders = append(orders, &banexg.Order{
+				ID:            strconv.Itoa(od.OrderId),
+				ClientOrderID: od.ClientOrderId,
+				Symbol:        mapSymbol(od.Symbol),
+				ListID:        listId,
+			})
+		}
+	}
+	return &banexg.OrderList{
+		ID:           listId,
+		ClientListID: o.ListClientOrderId,
+		Kind:         kind,
+		Symbol:       mapSymbol(o.Symbol),
+		// EXECUTING/ALL_DONE/REJECT -> OdListStatusExecuting/OdListStatusAllDone/OdListStatusReject
+		Status:    strings.ToLower(o.ListOrderStatus),
+		Timestamp: o.TransactionTime,
+		Orders:    orders,
+		Info:      info,
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/banbox/banexg"
 	"github.com/banbox/banexg/log"
 	"github.com/banbox/banexg/utils"
+	"github.com/h2non/gock"
 	"go.uber.org/zap"
 	"testing"
 	"time"
@@ -111,3 +112,145 @@ func TestCalcelOrder(t *testing.T) {
 	resStr, _ := utils.MarshalString(res)
 	log.Info("cancel order", zap.String("res", resStr))
 }
+
+func TestCreateOrders(t *testing.T) {
+	exg := getBinance(nil)
+	symbol := "ETH/USDT:USDT"
+	var odArgs []*banexg.OrderArgs
+	for i := 0; i < 7; i++ {
+		odArgs = append(odArgs, &banexg.OrderArgs{
+			Symbol: symbol,
+			Type:   banexg.OdTypeLimit,
+			Side:   banexg.OdSideBuy,
+			Amount: 0.02,
+			Price:  float64(1000 + i*10),
+			Params: map[string]interface{}{
+				banexg.ParamPositionSide: "LONG",
+			},
+		})
+	}
+	resList, err := exg.CreateOrders(odArgs, nil)
+	if err != nil {
+		panic(err)
+	}
+	var ids []string
+	for i, res := range resList {
+		if res.Error != nil {
+			log.Error("create order fail", zap.Int("idx", i), zap.Error(res.Error))
+			continue
+		}
+		ids = append(ids, res.Order.ID)
+	}
+	cancelList, err := exg.CancelOrders(symbol, ids, nil)
+	if err != nil {
+		panic(err)
+	}
+	resStr, _ := utils.MarshalString(cancelList)
+	log.Info("cancel orders", zap.String("res", resStr))
+}
+
+func TestCreateOrdersGroupAccount(t *testing.T) {
+	defer gock.Off()
+	gock.DisableNetworking()
+	if err := LoadGockItems("testdata/gock.json"); err != nil {
+		panic(err)
+	}
+	exg, err := New(map[string]interface{}{
+		banexg.OptAccCreds: map[string]map[string]interface{}{
+			"acc1": {banexg.OptApiKey: "key1", banexg.OptApiSecret: "secret1"},
+			"acc2": {banexg.OptApiKey: "key2", banexg.OptApiSecret: "secret2"},
+		},
+		banexg.OptAccName:     "acc1",
+		banexg.OptCareMarkets: []string{banexg.MarketLinear, banexg.MarketInverse},
+	})
+	if err != nil {
+		panic(err)
+	}
+	gock.InterceptClient(exg.HttpClient)
+	_, err = exg.LoadMarkets(false, nil)
+	if err != nil {
+		panic(err)
+	}
+	batchRsp := `[{"orderId":1,"symbol":"BTCUSDT","status":"NEW"},{"orderId":2,"symbol":"BTCUSDT","status":"NEW"}]`
+	// 每个账户只应收到自己的订单
+	mock1 := gock.New("https://fapi.binance.com").Post("/fapi/v1/batchOrders").
+		MatchHeader("X-MBX-APIKEY", "^key1$").Reply(200).BodyString(batchRsp)
+	mock2 := gock.New("https://fapi.binance.com").Post("/fapi/v1/batchOrders").
+		MatchHeader("X-MBX-APIKEY", "^key2$").Reply(200).BodyString(batchRsp)
+	var odArgs []*banexg.OrderArgs
+	for i, acc := range []string{"acc1", "acc2", "acc1", "acc2"} {
+		odArgs = append(odArgs, &banexg.OrderArgs{
+			Symbol: "BTC/USDT:USDT",
+			Type:   banexg.OdTypeLimit,
+			Side:   banexg.OdSideBuy,
+			Amount: 0.01,
+			Price:  float64(30000 + i*10),
+			Params: map[string]interface{}{banexg.ParamAccount: acc},
+		})
+	}
+	resList, err := exg.CreateOrders(odArgs, nil)
+	if err != nil {
+		t.Fatalf("create orders fail: %v", err)
+	}
+	for i, res := range resList {
+		if res.Error != nil {
+			t.Errorf("order %d fail: %v", i, res.Error)
+		}
+	}
+	if !mock1.Done() || !mock2.Done() {
+		t.Errorf("orders of each account should be sent in separate batch, acc1: %v, acc2: %v",
+			mock1.Done(), mock2.Done())
+	}
+}
+
+func TestSetOcoListLegs(t *testing.T) {
+	stopLeg := map[string]interface{}{"type": "STOP_LOSS", "side": "SELL", "quantity": "0.1", "stopPrice": "90"}
+	limitLeg := map[string]interface{}{"type": "LIMIT", "side": "SELL", "quantity": "0.1", "price": "110",
+		"timeInForce": "GTC", "newClientOrderId": "tp1"}
+	args := map[string]interface{}{}
+	err := setOcoListLegs(args, "pending", stopLeg, limitLeg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expects := map[string]interface{}{
+		"pendingSide":               "SELL",
+		"pendingQuantity":           "0.1",
+		"pendingAboveType":          "LIMIT_MAKER",
+		"pendingAbovePrice":         "110",
+		"pendingAboveClientOrderId": "tp1",
+		"pendingBelowType":          "STOP_LOSS",
+		"pendingBelowStopPrice":     "90",
+	}
+	for k, v := range expects {
+		if args[k] != v {
+			t.Errorf("%s expect %v, got %v", k, v, args[k])
+		}
+	}
+	if _, ok := args["pendingAboveTimeInForce"]; ok {
+		t.Errorf("timeInForce should be removed for LIMIT_MAKER")
+	}
+	limitLeg["quantity"] = "0.2"
+	if err = setOcoListLegs(map[string]interface{}{}, "", stopLeg, limitLeg); err == nil {
+		t.Errorf("oco legs with different amount should fail")
+	}
+}
+
+func TestParseOrderList(t *testing.T) {
+	content := `{"orderListId":1,"contingencyType":"OTO","listStatusType":"EXEC_STARTED","listOrderStatus":"EXECUTING",
+"listClientOrderId":"list1","transactionTime":1712289389158,"symbol":"LTCBTC",
+"orders":[{"symbol":"LTCBTC","orderId":4,"clientOrderId":"a"},{"symbol":"LTCBTC","orderId":5,"clientOrderId":"b"},
+{"symbol":"LTCBTC","orderId":6,"clientOrderId":"c"}],
+"orderReports":[{"symbol":"LTCBTC","orderId":4,"orderListId":1,"clientOrderId":"a","transactTime":1712289389158,
+"price":"1.00000000","origQty":"1.00000000","executedQty":"0.00000000","status":"NEW","timeInForce":"GTC",
+"type":"LIMIT","side":"BUY"}]}`
+	res, err := parseOrderList(func(mid string) string { return "LTC/BTC" }, &banexg.HttpRes{Content: content})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ID != "1" || res.Kind != banexg.OrderListOTOCO || res.Status != banexg.OdListStatusExecuting {
+		t.Errorf("invalid order list: %+v", res)
+	}
+	if len(res.Orders) != 1 || res.Orders[0].ListID != "1" || res.Orders[0].ID != "4" || res.Orders[0].Symbol != "LTC/BTC" {
+		t.Errorf("invalid child orders: %+v", res.Orders)
+	}
+}
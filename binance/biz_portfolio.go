@@ -0,0 +1,301 @@
+package binance
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+const pmStreamPrefix = "pm"
+
+/*
+isPortfolioMargin
+whether the account of params is a Portfolio Margin account, requests should be sent to papi.
+read from the account creds first (OptAccCreds: {"name": {"PortfolioMargin": true}}), then e.Options
+*/
+func (e *Binance) isPortfolioMargin(params map[string]interface{}) bool {
+	acc, err := e.GetAccount(e.GetAccName(params))
+	if err != nil {
+		return utils.GetMapVal(e.Options, banexg.OptPortfolioMargin, false)
+	}
+	return e.isAccPortfolioMargin(acc)
+}
+
+func (e *Binance) isAccPortfolioMargin(acc *banexg.Account) bool {
+	acc.LockData.Lock()
+	val, ok := acc.Data[banexg.OptPortfolioMargin]
+	acc.LockData.Unlock()
+	if ok {
+		if res, ok := val.(bool); ok {
+			return res
+		}
+	}
+	return utils.GetMapVal(e.Options, banexg.OptPortfolioMargin, false)
+}
+
+/*
+userStreamPrefix
+用户数据流在acc.Data中的键前缀，统一账户的U本位、币本位、全仓杠杆共用一个listenKey
+*/
+func (e *Binance) userStreamPrefix(acc *banexg.Account, marketType string) string {
+	if marketType == banexg.MarketLinear || marketType == banexg.MarketInverse || marketType == banexg.MarketMargin {
+		if e.isAccPortfolioMargin(acc) {
+			return pmStreamPrefix
+		}
+	}
+	return marketType
+}
+
+func (e *Binance) userWsHost(prefix string) string {
+	if prefix == pmStreamPrefix {
+		return e.GetHost(WssPApi)
+	}
+	return e.GetHost(prefix)
+}
+
+/*
+wsMarketType
+统一账户用户数据流中，合约事件通过fs字段区分U本位(UM)和币本位(CM)，其他为全仓杠杆事件
+*/
+func (e *Binance) wsMarketType(client *banexg.WsClient, msg map[string]string) string {
+	pmHost := e.GetHost(WssPApi)
+	if pmHost == "" || !strings.HasPrefix(client.URL, pmHost) {
+		return client.MarketType
+	}
+	switch msg["fs"] {
+	case "UM":
+		return banexg.MarketLinear
+	case "CM":
+		return banexg.MarketInverse
+	default:
+		return banexg.MarketMargin
+	}
+}
+
+/*
+FetchAccountStatus
+fetch the unified margin summary of portfolio margin account
+
+	:see: https://binance-docs.github.io/apidocs/pm/en/#account-information-user_data
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:returns AccountStatus: uniMMR, account equity and margins
+*/
+func (e *Binance) FetchAccountStatus(params map[string]interface{}) (*banexg.AccountStatus, *errs.Error) {
+	args := utils.SafeParams(params)
+	if !e.isPortfolioMargin(args) {
+		return nil, errs.NewMsg(errs.CodeNotSupport, "FetchAccountStatus support portfolio margin account only, set %s", banexg.OptPortfolioMargin)
+	}
+	tryNum := e.GetRetryNum("FetchAccountStatus", 1)
+	rsp := e.RequestApiRetry(context.Background(), MethodPapiGetAccount, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var data = PapiAccount{}
+	info, err_ := utils.UnmarshalStringMap(rsp.Content, &data)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	uniMMR, _ := strconv.ParseFloat(data.UniMMR, 64)
+	equity, _ := strconv.ParseFloat(data.AccountEquity, 64)
+	actualEquity, _ := strconv.ParseFloat(data.ActualEquity, 64)
+	initMargin, _ := strconv.ParseFloat(data.AccountInitialMargin, 64)
+	maintMargin, _ := strconv.ParseFloat(data.AccountMaintMargin, 64)
+	available, _ := strconv.ParseFloat(data.TotalAvailableBalance, 64)
+	return &banexg.AccountStatus{
+		UniMMR:       uniMMR,
+		Equity:       equity,
+		ActualEquity: actualEquity,
+		InitMargin:   initMargin,
+		MaintMargin:  maintMargin,
+		Available:    available,
+		Status:       data.AccountStatus,
+		Timestamp:    data.UpdateTime,
+		Info:         info,
+	}, nil
+}
+
+/*
+parsePapiBalances
+统一账户资产：全仓杠杆、U本位合约、币本位合约共用同一钱包
+*/
+func parsePapiBalances(getCurrCode func(string) string, rsp *banexg.HttpRes) (*banexg.Balances, *errs.Error) {
+	var data = make([]*PapiBalance, 0)
+	info, err := utils.UnmarshalStringMapArr(rsp.Content, &data)
+	if err != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err)
+	}
+	var result = banexg.Balances{
+		Info:   map[string]interface{}{"assets": info},
+		Assets: map[string]*banexg.Asset{},
+	}
+	for _, item := range data {
+		total, _ := strconv.ParseFloat(item.TotalWalletBalance, 64)
+		locked, _ := strconv.ParseFloat(item.CrossMarginLocked, 64)
+		borrowed, _ := strconv.ParseFloat(item.CrossMarginBorrowed, 64)
+		interest, _ := strconv.ParseFloat(item.CrossMarginInterest, 64)
+		umPnl, _ := strconv.ParseFloat(item.UmUnrealizedPNL, 64)
+		cmPnl, _ := strconv.ParseFloat(item.CmUnrealizedPNL, 64)
+		asset := &banexg.Asset{
+			Code:  getCurrCode(item.Asset),
+			Free:  total - locked,
+			Used:  locked,
+			Total: total,
+			Debt:  borrowed + interest,
+			UPol:  umPnl + cmPnl,
+		}
+		if asset.IsEmpty() {
+			continue
+		}
+		result.Assets[asset.Code] = asset
+		if item.UpdateTime > result.TimeStamp {
+			result.TimeStamp = item.UpdateTime
+		}
+	}
+	return result.Init(), nil
+}
+
+/*
+papiOrderMethod
+将下单接口转为统一账户对应的papi接口，U本位/币本位的条件单使用conditional接口
+*/
+func papiOrderMethod(method string, args map[string]interface{}) string {
+	switch method {
+	case MethodFapiPrivatePostOrder:
+		if isPapiStrategyType(args) {
+			toPapiStrategyArgs(args)
+			return MethodPapiPostUmConditionalOrder
+		}
+		return MethodPapiPostUmOrder
+	case MethodDapiPrivatePostOrder:
+		if isPapiStrategyType(args) {
+			toPapiStrategyArgs(args)
+			return MethodPapiPostCmConditionalOrder
+		}
+		return MethodPapiPostCmOrder
+	case MethodSapiPostMarginOrder:
+		return MethodPapiPostMarginOrder
+	}
+	return method
+}
+
+// 订单查询/修改/撤销接口对应的统一账户papi接口：普通订单, 条件单(为空表示不支持)
+var papiMethodMap = map[string][2]string{
+	MethodFapiPrivateGetOrder:            {MethodPapiGetUmOrder, MethodPapiGetUmConditionalOrderHistory},
+	MethodDapiPrivateGetOrder:            {MethodPapiGetCmOrder, MethodPapiGetCmConditionalOrderHistory},
+	MethodSapiGetMarginOrder:             {MethodPapiGetMarginOrder, ""},
+	MethodFapiPrivateGetAllOrders:        {MethodPapiGetUmAllOrders, MethodPapiGetUmConditionalAllOrders},
+	MethodDapiPrivateGetAllOrders:        {MethodPapiGetCmAllOrders, MethodPapiGetCmConditionalAllOrders},
+	MethodSapiGetMarginAllOrders:         {MethodPapiGetMarginAllOrders, ""},
+	MethodFapiPrivateGetOpenOrders:       {MethodPapiGetUmOpenOrders, MethodPapiGetUmConditionalOpenOrders},
+	MethodDapiPrivateGetOpenOrders:       {MethodPapiGetCmOpenOrders, MethodPapiGetCmConditionalOpenOrders},
+	MethodSapiGetMarginOpenOrders:        {MethodPapiGetMarginOpenOrders, ""},
+	MethodFapiPrivatePutOrder:            {MethodPapiPutUmOrder, ""},
+	MethodDapiPrivatePutOrder:            {MethodPapiPutCmOrder, ""},
+	MethodFapiPrivateDeleteOrder:         {MethodPapiDeleteUmOrder, MethodPapiDeleteUmConditionalOrder},
+	MethodDapiPrivateDeleteOrder:         {MethodPapiDeleteCmOrder, MethodPapiDeleteCmConditionalOrder},
+	MethodSapiDeleteMarginOrder:          {MethodPapiDeleteMarginOrder, ""},
+	MethodFapiPrivateDeleteAllOpenOrders: {MethodPapiDeleteUmAllOpenOrders, MethodPapiDeleteUmConditionalAllOpenOrders},
+	MethodDapiPrivateDeleteAllOpenOrders: {MethodPapiDeleteCmAllOpenOrders, MethodPapiDeleteCmConditionalAllOpenOrders},
+	MethodSapiDeleteMarginOpenOrders:     {MethodPapiDeleteMarginAllOpenOrders, ""},
+}
+
+/*
+papiMethod
+将订单查询、修改、撤销接口转为统一账户对应的papi接口，现货、期权等其他接口原样返回。
+params.conditional为true时使用U本位/币本位的条件单接口，并将orderId/origClientOrderId转为strategyId/newClientStrategyId
+*/
+func papiMethod(method string, args map[string]interface{}) (string, *errs.Error) {
+	conditional := utils.PopMapVal(args, banexg.ParamConditional, false)
+	items, ok := papiMethodMap[method]
+	if !ok {
+		if conditional {
+			return "", errs.NewMsg(errs.CodeNotSupport, "conditional order is not supported for %s", method)
+		}
+		return method, nil
+	}
+	if utils.GetMapVal(args, "isIsolated", false) {
+		return "", errs.NewMsg(errs.CodeUnsupportMarket, "isolated margin is not supported for portfolio margin account")
+	}
+	if !conditional {
+		return items[0], nil
+	}
+	if items[1] == "" {
+		return "", errs.NewMsg(errs.CodeNotSupport, "conditional order is not supported for %s", method)
+	}
+	if id := utils.PopMapVal(args, "orderId", ""); id != "" {
+		args["strategyId"] = id
+	}
+	if clientId := utils.PopMapVal(args, "origClientOrderId", ""); clientId != "" {
+		args["newClientStrategyId"] = clientId
+	}
+	return items[1], nil
+}
+
+/*
+routeOrderMethod
+统一账户时将订单接口转为papi接口，否则原样返回
+*/
+func (e *Binance) routeOrderMethod(method string, args map[string]interface{}) (string, *errs.Error) {
+	if !e.isPortfolioMargin(args) {
+		if utils.PopMapVal(args, banexg.ParamConditional, false) {
+			return "", errs.NewMsg(errs.CodeNotSupport, "%s is for portfolio margin account only", banexg.ParamConditional)
+		}
+		return method, nil
+	}
+	return papiMethod(method, args)
+}
+
+func isPapiStrategyType(args map[string]interface{}) bool {
+	odType := utils.GetMapVal(args, "type", "")
+	return odType != "" && odType != "LIMIT" && odType != "MARKET"
+}
+
+func toPapiStrategyArgs(args map[string]interface{}) {
+	args["strategyType"] = utils.PopMapVal(args, "type", "")
+	args["newClientStrategyId"] = utils.PopMapVal(args, "newClientOrderId", "")
+	delete(args, "newOrderRespType")
+}
+
+var papiStrategyStateMap = map[string]string{
+	"TRIGGERED": banexg.OdStatusOpen,
+	"FINISHED":  banexg.OdStatusFilled,
+}
+
+func (o *PapiStrategyOrder) ToStdOrder(mapSymbol func(string) string, info map[string]interface{}) *banexg.Order {
+	status, ok := papiStrategyStateMap[o.StrategyStatus]
+	if !ok {
+		status = mapOrderStatus(o.StrategyStatus)
+	}
+	price, _ := strconv.ParseFloat(o.Price, 64)
+	stopPrice, _ := strconv.ParseFloat(o.StopPrice, 64)
+	amount, _ := strconv.ParseFloat(o.OrigQty, 64)
+	timeStamp := o.BookTime
+	if timeStamp == 0 {
+		timeStamp = o.UpdateTime
+	}
+	return &banexg.Order{
+		Info:                info,
+		ID:                  strconv.Itoa(o.StrategyId),
+		ClientOrderID:       o.NewClientStrategyId,
+		Datetime:            utils.ISO8601(timeStamp),
+		Timestamp:           timeStamp,
+		LastUpdateTimestamp: o.UpdateTime,
+		Status:              status,
+		Symbol:              mapSymbol(o.Symbol),
+		Type:                strings.ToLower(o.StrategyType),
+		TimeInForce:         o.TimeInForce,
+		PositionSide:        strings.ToLower(o.PositionSide),
+		Side:                strings.ToLower(o.Side),
+		Price:               price,
+		Amount:              amount,
+		Remaining:           amount,
+		TriggerPrice:        stopPrice,
+		ReduceOnly:          o.ReduceOnly,
+		Fee:                 &banexg.Fee{},
+		Trades:              make([]*banexg.Trade, 0),
+	}
+}
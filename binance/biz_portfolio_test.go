@@ -0,0 +1,177 @@
+package binance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+	"github.com/h2non/gock"
+)
+
+func TestPortfolioMargin(t *testing.T) {
+	exg := getBinance(map[string]interface{}{
+		banexg.OptPortfolioMargin: true,
+	})
+	status, err := exg.FetchAccountStatus(nil)
+	if err != nil {
+		panic(err)
+	}
+	status.Info = nil
+	fmt.Println(utils.MarshalString(status))
+	bals, err := exg.FetchBalance(map[string]interface{}{
+		banexg.ParamMarket: banexg.MarketLinear,
+	})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(utils.MarshalString(bals.Assets))
+	posList, err := exg.FetchPositions(nil, map[string]interface{}{
+		banexg.ParamMarket: banexg.MarketLinear,
+	})
+	if err != nil {
+		panic(err)
+	}
+	for _, p := range posList {
+		p.Info = nil
+	}
+	fmt.Println(utils.MarshalString(posList))
+}
+
+func TestPapiOrderRoute(t *testing.T) {
+	defer gock.Off()
+	gock.DisableNetworking()
+	if err := LoadGockItems("testdata/gock.json"); err != nil {
+		panic(err)
+	}
+	exg, err := New(map[string]interface{}{
+		banexg.OptApiKey:          "key",
+		banexg.OptApiSecret:       "secret",
+		banexg.OptPortfolioMargin: true,
+		banexg.OptCareMarkets:     []string{banexg.MarketLinear, banexg.MarketInverse},
+	})
+	if err != nil {
+		panic(err)
+	}
+	gock.InterceptClient(exg.HttpClient)
+	_, err = exg.LoadMarkets(false, nil)
+	if err != nil {
+		panic(err)
+	}
+	linear, inverse := "BTC/USDT:USDT", "BTC/USD:BTC"
+	cond := map[string]interface{}{banexg.ParamConditional: true}
+	odRsp := `{"orderId":1,"symbol":"BTCUSDT","status":"NEW"}`
+	stgRsp := `{"strategyId":1,"symbol":"BTCUSDT","strategyStatus":"NEW"}`
+	cancelAllRsp := `{"code":200,"msg":"The operation of cancel all open order is done."}`
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		rsp    string
+		call   func() *errs.Error
+	}{
+		{"FetchOrder um", "GET", "/papi/v1/um/order", odRsp, func() *errs.Error {
+			_, err := exg.FetchOrder(linear, "1", nil)
+			return err
+		}},
+		{"FetchOrder cm", "GET", "/papi/v1/cm/order", odRsp, func() *errs.Error {
+			_, err := exg.FetchOrder(inverse, "1", nil)
+			return err
+		}},
+		{"FetchOrder um conditional", "GET", "/papi/v1/um/conditional/orderHistory", stgRsp, func() *errs.Error {
+			_, err := exg.FetchOrder(linear, "1", cond)
+			return err
+		}},
+		{"FetchOpenOrders um", "GET", "/papi/v1/um/openOrders", "[]", func() *errs.Error {
+			_, err := exg.FetchOpenOrders(linear, 0, 0, nil)
+			return err
+		}},
+		{"FetchOpenOrders cm conditional", "GET", "/papi/v1/cm/conditional/openOrders", "[]", func() *errs.Error {
+			_, err := exg.FetchOpenOrders(inverse, 0, 0, cond)
+			return err
+		}},
+		{"EditOrder um", "PUT", "/papi/v1/um/order", odRsp, func() *errs.Error {
+			_, err := exg.EditOrder(linear, "1", banexg.OdSideBuy, 0.01, 30000, nil)
+			return err
+		}},
+		{"EditOrder cm", "PUT", "/papi/v1/cm/order", odRsp, func() *errs.Error {
+			_, err := exg.EditOrder(inverse, "1", banexg.OdSideBuy, 1, 30000, nil)
+			return err
+		}},
+		{"CancelOrder um", "DELETE", "/papi/v1/um/order", odRsp, func() *errs.Error {
+			_, err := exg.CancelOrder("1", linear, nil)
+			return err
+		}},
+		{"CancelOrder cm conditional", "DELETE", "/papi/v1/cm/conditional/order", stgRsp, func() *errs.Error {
+			_, err := exg.CancelOrder("1", inverse, cond)
+			return err
+		}},
+		{"CancelOrders um", "DELETE", "/papi/v1/um/order", odRsp, func() *errs.Error {
+			res, err := exg.CancelOrders(linear, []string{"1"}, nil)
+			if err == nil && len(res) == 1 {
+				err = res[0].Error
+			}
+			return err
+		}},
+		{"CancelAllOrders um", "DELETE", "/papi/v1/um/allOpenOrders", cancelAllRsp, func() *errs.Error {
+			_, err := exg.CancelAllOrders(linear, nil)
+			return err
+		}},
+		{"CancelAllOrders um conditional", "DELETE", "/papi/v1/um/conditional/allOpenOrders", cancelAllRsp, func() *errs.Error {
+			_, err := exg.CancelAllOrders(linear, cond)
+			return err
+		}},
+	}
+	for _, c := range cases {
+		req := gock.New("https://papi.binance.com")
+		req.Method = c.method
+		req.Path(c.path).Reply(200).BodyString(c.rsp)
+		if err := c.call(); err != nil {
+			t.Errorf("%s fail: %v", c.name, err)
+		}
+		if !req.Mock.Done() {
+			t.Errorf("%s not routed to %s %s", c.name, c.method, c.path)
+			gock.Flush()
+		}
+	}
+	if _, err := exg.EditOrder(linear, "1", banexg.OdSideBuy, 0.01, 30000, cond); err == nil {
+		t.Errorf("EditOrder conditional should be rejected")
+	}
+}
+
+func TestPapiMethod(t *testing.T) {
+	cases := []struct {
+		method string
+		args   map[string]interface{}
+		expect string
+	}{
+		{MethodSapiGetMarginOrder, map[string]interface{}{}, MethodPapiGetMarginOrder},
+		{MethodSapiGetMarginOpenOrders, map[string]interface{}{}, MethodPapiGetMarginOpenOrders},
+		{MethodSapiDeleteMarginOrder, map[string]interface{}{}, MethodPapiDeleteMarginOrder},
+		{MethodSapiDeleteMarginOpenOrders, map[string]interface{}{}, MethodPapiDeleteMarginAllOpenOrders},
+		{MethodPrivateDeleteOrder, map[string]interface{}{}, MethodPrivateDeleteOrder},
+		{MethodFapiPrivateDeleteOrder, map[string]interface{}{"orderId": "12", banexg.ParamConditional: true},
+			MethodPapiDeleteUmConditionalOrder},
+		{MethodSapiDeleteMarginOrder, map[string]interface{}{"isIsolated": true}, ""},
+		{MethodSapiDeleteMarginOrder, map[string]interface{}{banexg.ParamConditional: true}, ""},
+		{MethodPrivateGetOrder, map[string]interface{}{banexg.ParamConditional: true}, ""},
+	}
+	for _, c := range cases {
+		res, err := papiMethod(c.method, c.args)
+		if c.expect == "" {
+			if err == nil {
+				t.Errorf("%s %v should fail, got %s", c.method, c.args, res)
+			}
+			continue
+		}
+		if err != nil || res != c.expect {
+			t.Errorf("%s expect %s, got %s %v", c.method, c.expect, res, err)
+		}
+	}
+	args := map[string]interface{}{"orderId": "12", "origClientOrderId": "abc", banexg.ParamConditional: true}
+	_, _ = papiMethod(MethodFapiPrivateGetOrder, args)
+	if args["strategyId"] != "12" || args["newClientStrategyId"] != "abc" || len(args) != 2 {
+		t.Errorf("conditional args not converted: %v", args)
+	}
+}
@@ -0,0 +1,209 @@
+package binance
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+/*
+AddMargin
+add margin to an isolated position
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#modify-isolated-position-margin-trade
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#modify-isolated-position-margin-trade
+	:param str symbol: unified market symbol
+	:param float amount: amount of margin to add
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.positionSide]: LONG/SHORT in hedge mode, default BOTH
+	:returns MarginAdjustment: a margin adjustment structure with the updated position
+*/
+func (e *Binance) AddMargin(symbol string, amount float64, params map[string]interface{}) (*banexg.MarginAdjustment, *errs.Error) {
+	return e.modifyMarginHelper(symbol, amount, 1, params)
+}
+
+/*
+ReduceMargin
+remove margin from an isolated position
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#modify-isolated-position-margin-trade
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#modify-isolated-position-margin-trade
+	:param str symbol: unified market symbol
+	:param float amount: amount of margin to remove
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.positionSide]: LONG/SHORT in hedge mode, default BOTH
+	:returns MarginAdjustment: a margin adjustment structure with the updated position
+*/
+func (e *Binance) ReduceMargin(symbol string, amount float64, params map[string]interface{}) (*banexg.MarginAdjustment, *errs.Error) {
+	return e.modifyMarginHelper(symbol, amount, 2, params)
+}
+
+func (e *Binance) modifyMarginHelper(symbol string, amount float64, adjType int, params map[string]interface{}) (*banexg.MarginAdjustment, *errs.Error) {
+	if amount <= 0 {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "amount must > 0 for modify margin")
+	}
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	var method string
+	if market.Linear {
+		method = MethodFapiPrivatePostPositionMargin
+	} else if market.Inverse {
+		method = MethodDapiPrivatePostPositionMargin
+	} else {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "%v modify margin supports linear and inverse contracts only", e.Name)
+	}
+	posSide := strings.ToUpper(utils.PopMapVal(args, banexg.ParamPositionSide, ""))
+	if posSide != "" {
+		args["positionSide"] = posSide
+	}
+	// 查询更新后的持仓需要的参数，需在发送请求前复制
+	posArgs := utils.SafeParams(params)
+	args["symbol"] = market.ID
+	args["amount"] = strconv.FormatFloat(amount, 'f', -1, 64)
+	args["type"] = adjType
+	tryNum := e.GetRetryNum("ModifyMargin", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var data = PositionMarginRsp{}
+	info, err_ := utils.UnmarshalStringMap(rsp.Content, &data)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	status := banexg.TransferStatusOk
+	if data.Code != 200 {
+		status = banexg.TransferStatusFailed
+	}
+	res := &banexg.MarginAdjustment{
+		Symbol:    market.Symbol,
+		Type:      parseMarginAdjType(adjType),
+		Amount:    amount,
+		Code:      market.Settle,
+		Status:    status,
+		Timestamp: e.MilliSeconds(),
+		Info:      info,
+	}
+	if status == banexg.TransferStatusOk {
+		res.Position, err = e.fetchUpdatedPosition(market, posSide, posArgs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+/*
+fetchUpdatedPosition
+从positionRisk查询指定交易对的持仓，经calcPositionRisk计算后返回
+*/
+func (e *Binance) fetchUpdatedPosition(market *banexg.Market, posSide string, params map[string]interface{}) (*banexg.Position, *errs.Error) {
+	utils.PopMapVal(params, banexg.ParamPositionSide, "")
+	if market.Linear {
+		params["symbol"] = market.ID
+	} else {
+		params["pair"] = strings.Split(market.ID, "_")[0]
+	}
+	items, err := e.FetchPositionsRisk([]string{market.Symbol}, params)
+	if err != nil {
+		return nil, err
+	}
+	posSide = strings.ToLower(posSide)
+	for _, p := range items {
+		if p.Symbol != market.Symbol {
+			continue
+		}
+		if posSide == "" || posSide == banexg.PosSideBoth || p.Side == posSide {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+/*
+FetchMarginAdjustmentHistory
+fetches the history of margin added or reduced from isolated positions
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#get-position-margin-change-history-trade
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#get-position-margin-change-history-trade
+	:param str symbol: unified market symbol
+	:param int [since]: timestamp in ms of the earliest change to fetch
+	:param int [limit]: the maximum amount of changes to fetch, default 500
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.type]: MarginAdjAdd or MarginAdjReduce, all if empty
+	:param int [params.until]: timestamp in ms of the latest change to fetch
+	:returns MarginAdjustment[]: a list of margin adjustment structures, sorted by time asc
+*/
+func (e *Binance) FetchMarginAdjustmentHistory(symbol string, since int64, limit int, params map[string]interface{}) ([]*banexg.MarginAdjustment, *errs.Error) {
+	if symbol == "" {
+		return nil, errs.NewMsg(errs.CodeParamRequired, "symbol is required for %v.FetchMarginAdjustmentHistory", e.Name)
+	}
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	var method string
+	if market.Linear {
+		method = MethodFapiPrivateGetPositionMarginHistory
+	} else if market.Inverse {
+		method = MethodDapiPrivateGetPositionMarginHistory
+	} else {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "FetchMarginAdjustmentHistory supports linear and inverse contracts only")
+	}
+	adjType := utils.PopMapVal(args, "type", "")
+	if adjType == banexg.MarginAdjAdd {
+		args["type"] = 1
+	} else if adjType == banexg.MarginAdjReduce {
+		args["type"] = 2
+	} else if adjType != "" {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "invalid type: %s", adjType)
+	}
+	args["symbol"] = market.ID
+	if since > 0 {
+		args["startTime"] = since
+	}
+	until := utils.PopMapVal(args, banexg.ParamUntil, int64(0))
+	if until > 0 {
+		args["endTime"] = until
+	}
+	if limit > 0 {
+		args["limit"] = limit
+	}
+	tryNum := e.GetRetryNum("FetchMarginAdjustmentHistory", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var data = make([]*PositionMarginHis, 0)
+	items, err_ := utils.UnmarshalStringMapArr(banexg.EnsureArrStr(rsp.Content), &data)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var res = make([]*banexg.MarginAdjustment, 0, len(data))
+	for i, it := range data {
+		amount, _ := strconv.ParseFloat(it.Amount, 64)
+		res = append(res, &banexg.MarginAdjustment{
+			Symbol:    market.Symbol,
+			Type:      parseMarginAdjType(it.Type),
+			Amount:    amount,
+			Code:      e.SafeCurrencyCode(it.Asset),
+			Status:    banexg.TransferStatusOk,
+			Timestamp: it.Time,
+			Info:      items[i],
+		})
+	}
+	return res, nil
+}
+
+func parseMarginAdjType(adjType int) string {
+	if adjType == 1 {
+		return banexg.MarginAdjAdd
+	}
+	return banexg.MarginAdjReduce
+}
@@ -0,0 +1,222 @@
+package binance
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/bntp"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+// max items of futures/data statistics(openInterestHist, longShortRatio...) for one request
+const maxFutDataBatch = 500
+
+/*
+FetchLongShortRatioHistory
+retrieves the long/short ratio history of a contract, only data of latest 30 days is available
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#long-short-ratio
+	:see: https://binance-docs.github.io/apidocs/futures/en/#top-trader-long-short-ratio-accounts
+	:see: https://binance-docs.github.io/apidocs/futures/en/#top-trader-long-short-ratio-positions
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#long-short-ratio
+	:param str symbol: unified CCXT market symbol
+	:param str period: "5m","15m","30m","1h","2h","4h","6h","12h", or "1d"
+	:param int [since]: the time(ms) of the earliest record to retrieve as a unix timestamp
+	:param int [limit]: default 30, max 500
+	:param dict [params]: exchange specific parameters
+	:param str [params.ratioType]: globalAccount(default), topAccount, topPosition
+	:param int [params.until]: the time(ms) of the latest record to retrieve as a unix timestamp
+	:returns LongShortRatio[]: an array of long short ratio structure, sorted by time asc
+*/
+func (e *Binance) FetchLongShortRatioHistory(symbol, period string, since int64, limit int, params map[string]interface{}) ([]*banexg.LongShortRatio, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	ratioType := utils.PopMapVal(args, banexg.ParamRatioType, banexg.LSRatioGlobalAccount)
+	var method string
+	if market.Linear {
+		switch ratioType {
+		case banexg.LSRatioGlobalAccount:
+			method = MethodFapiDataGetGlobalLongShortAccountRatio
+		case banexg.LSRatioTopAccount:
+			method = MethodFapiDataGetTopLongShortAccountRatio
+		case banexg.LSRatioTopPosition:
+			method = MethodFapiDataGetTopLongShortPositionRatio
+		}
+	} else if market.Inverse {
+		switch ratioType {
+		case banexg.LSRatioGlobalAccount:
+			method = MethodDapiDataGetGlobalLongShortAccountRatio
+		case banexg.LSRatioTopAccount:
+			method = MethodDapiDataGetTopLongShortAccountRatio
+		case banexg.LSRatioTopPosition:
+			method = MethodDapiDataGetTopLongShortPositionRatio
+		}
+	} else {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "FetchLongShortRatioHistory support linear/inverse only")
+	}
+	if method == "" {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "invalid %s: %s", banexg.ParamRatioType, ratioType)
+	}
+	err = e.setFutDataArgs(args, market, period, since, limit, false)
+	if err != nil {
+		return nil, err
+	}
+	tryNum := e.GetRetryNum("FetchLongShortRatioHistory", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var items = make([]map[string]interface{}, 0)
+	err_ := utils.UnmarshalString(banexg.EnsureArrStr(rsp.Content), &items, utils.JsonNumAuto)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	// 币本位大户持仓多空比返回longPosition/shortPosition，其他返回longAccount/shortAccount
+	longKey, shortKey := "longAccount", "shortAccount"
+	if market.Inverse && ratioType == banexg.LSRatioTopPosition {
+		longKey, shortKey = "longPosition", "shortPosition"
+	}
+	var res = make([]*banexg.LongShortRatio, 0, len(items))
+	for _, it := range items {
+		res = append(res, &banexg.LongShortRatio{
+			Symbol:     market.Symbol,
+			LongRatio:  anyFloat(it[longKey]),
+			ShortRatio: anyFloat(it[shortKey]),
+			Ratio:      anyFloat(it["longShortRatio"]),
+			Timestamp:  int64(anyFloat(it["timestamp"])),
+			Info:       it,
+		})
+	}
+	return res, nil
+}
+
+/*
+FetchTakerVolumeHistory
+retrieves the taker buy/sell volume history of a contract, only data of latest 30 days is available
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#taker-buy-sell-volume
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#taker-buy-sell-volume
+	:param str symbol: unified CCXT market symbol
+	:param str period: "5m","15m","30m","1h","2h","4h","6h","12h", or "1d"
+	:param int [since]: the time(ms) of the earliest record to retrieve as a unix timestamp
+	:param int [limit]: default 30, max 500
+	:param dict [params]: exchange specific parameters
+	:param int [params.until]: the time(ms) of the latest record to retrieve as a unix timestamp
+	:returns TakerVolume[]: an array of taker volume structure, sorted by time asc
+*/
+func (e *Binance) FetchTakerVolumeHistory(symbol, period string, since int64, limit int, params map[string]interface{}) ([]*banexg.TakerVolume, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	var method string
+	if market.Linear {
+		method = MethodFapiDataGetTakerlongshortRatio
+	} else if market.Inverse {
+		method = MethodDapiDataGetTakerBuySellVol
+	} else {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "FetchTakerVolumeHistory support linear/inverse only")
+	}
+	err = e.setFutDataArgs(args, market, period, since, limit, true)
+	if err != nil {
+		return nil, err
+	}
+	tryNum := e.GetRetryNum("FetchTakerVolumeHistory", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var items = make([]map[string]interface{}, 0)
+	err_ := utils.UnmarshalString(banexg.EnsureArrStr(rsp.Content), &items, utils.JsonNumAuto)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var res = make([]*banexg.TakerVolume, 0, len(items))
+	for _, it := range items {
+		var row = &banexg.TakerVolume{
+			Symbol:    market.Symbol,
+			Timestamp: int64(anyFloat(it["timestamp"])),
+			Info:      it,
+		}
+		if market.Linear {
+			row.BuyVol = anyFloat(it["buyVol"])
+			row.SellVol = anyFloat(it["sellVol"])
+			row.Ratio = anyFloat(it["buySellRatio"])
+		} else {
+			// 币本位返回的量单位为张，额单位为基础币
+			row.BuyVol = anyFloat(it["takerBuyVol"])
+			row.SellVol = anyFloat(it["takerSellVol"])
+			row.BuyValue = anyFloat(it["takerBuyVolValue"])
+			row.SellValue = anyFloat(it["takerSellVolValue"])
+			if row.SellVol > 0 {
+				row.Ratio = row.BuyVol / row.SellVol
+			}
+		}
+		res = append(res, row)
+	}
+	return res, nil
+}
+
+/*
+setFutDataArgs
+set common args for futures/data statistics endpoints: symbol/pair, period, limit, startTime, endTime
+inverse openInterestHist/takerBuySellVol requires contractType additionally
+*/
+func (e *Binance) setFutDataArgs(args map[string]interface{}, market *banexg.Market, period string, since int64,
+	limit int, withContract bool) *errs.Error {
+	if period == "" {
+		period = "5m"
+	}
+	periodSecs, err := utils.ParseTimeFrame(period)
+	if err != nil {
+		return err
+	}
+	if market.Inverse {
+		args["pair"] = strings.Split(market.ID, "_")[0]
+		if withContract {
+			contractType := utils.GetMapVal(market.Info, "contractType", "")
+			if contractType == "" {
+				contractType = "ALL"
+			}
+			args["contractType"] = contractType
+		}
+	} else {
+		args["symbol"] = market.ID
+	}
+	args["period"] = period
+	if limit <= 0 {
+		limit = 30
+	}
+	limit = min(limit, maxFutDataBatch)
+	args["limit"] = limit
+	until := utils.PopMapVal(args, banexg.ParamUntil, int64(0))
+	if since > 0 {
+		args["startTime"] = since
+		if until <= 0 {
+			until = min(since+int64(limit*periodSecs)*1000, bntp.UTCStamp())
+		}
+	}
+	if until > 0 {
+		args["endTime"] = until
+	}
+	return nil
+}
+
+// anyFloat parse float from number or string returned by futures/data endpoints
+func anyFloat(val interface{}) float64 {
+	switch v := val.(type) {
+	case float64:
+		return v
+	case int64:
+		return float64(v)
+	case string:
+		res, _ := strconv.ParseFloat(v, 64)
+		return res
+	}
+	return 0
+}
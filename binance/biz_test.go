@@ -5,6 +5,7 @@ import (
 	"github.com/banbox/banexg"
 	"github.com/banbox/banexg/log"
 	"github.com/banbox/banexg/utils"
+	"github.com/h2non/gock"
 	"go.uber.org/zap"
 	"os"
 	"strings"
@@ -466,6 +467,39 @@ func TestSetLeverage(t *testing.T) {
 	fmt.Printf("set leverage: %v", res)
 }
 
+func TestAccountConfig(t *testing.T) {
+	exg := getBinance(nil)
+	res, err := exg.SetMarginMode(banexg.MarginIsolated, "GAS/USDT:USDT", nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("set margin mode: %v\n", res)
+	items, err := exg.FetchAccountConfig([]string{"GAS/USDT:USDT"}, nil)
+	if err != nil {
+		panic(err)
+	}
+	for _, it := range items {
+		fmt.Printf("%+v\n", *it)
+	}
+}
+
+func TestModifyMargin(t *testing.T) {
+	exg := getBinance(nil)
+	res, err := exg.AddMargin("GAS/USDT:USDT", 1, nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(utils.MarshalString(res))
+	items, err := exg.FetchMarginAdjustmentHistory("GAS/USDT:USDT", 0, 10, nil)
+	if err != nil {
+		panic(err)
+	}
+	for _, it := range items {
+		it.Info = nil
+	}
+	fmt.Println(utils.MarshalString(items))
+}
+
 func TestLoadLeverageBrackets(t *testing.T) {
 	exg := getBinance(nil)
 	exg.MarketType = banexg.MarketLinear
@@ -552,3 +586,32 @@ func TestFetchAccountPositions(t *testing.T) {
 	text, _ := utils.MarshalString(posList)
 	fmt.Println(text)
 }
+
+func TestFetchLiquidationsLoadMarkets(t *testing.T) {
+	defer gock.Off()
+	gock.DisableNetworking()
+	if err := LoadGockItems("testdata/gock.json"); err != nil {
+		panic(err)
+	}
+	exg, err := New(map[string]interface{}{
+		banexg.OptApiKey:      "key",
+		banexg.OptApiSecret:   "secret",
+		banexg.OptCareMarkets: []string{banexg.MarketLinear, banexg.MarketInverse},
+	})
+	if err != nil {
+		panic(err)
+	}
+	gock.InterceptClient(exg.HttpClient)
+	gock.New("https://fapi.binance.com").Get("/fapi/v1/forceOrders").MatchParam("symbol", "BTCUSDT").
+		Reply(200).BodyString(`[{"orderId":1,"symbol":"BTCUSDT","status":"FILLED","price":"30000","avgPrice":"30000",
+"origQty":"0.01","executedQty":"0.01","side":"SELL","type":"LIMIT","time":1700000000000,"updateTime":1700000000000}]`)
+	// 未调用LoadMarkets时应自动加载
+	items, err := exg.FetchLiquidations("BTC/USDT:USDT", 0, 0, nil)
+	if err != nil {
+		t.Fatalf("fetch liquidations fail: %v", err)
+	}
+	if len(items) != 1 || items[0].Symbol != "BTC/USDT:USDT" {
+		text, _ := utils.MarshalString(items)
+		t.Errorf("unexpected liquidations: %s", text)
+	}
+}
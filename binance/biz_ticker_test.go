@@ -61,3 +61,43 @@ func TestFetchTickerPrice(t *testing.T) {
 	}
 	fmt.Println(utils.MarshalString(prices))
 }
+
+func TestFetchOpenInterest(t *testing.T) {
+	exg := getBinance(nil)
+	oi, err := exg.FetchOpenInterest("BTC/USDT:USDT", nil)
+	if err != nil {
+		panic(err)
+	}
+	oi.Info = nil
+	fmt.Println(utils.MarshalString(oi))
+	items, err := exg.FetchOpenInterestHistory("BTC/USDT:USDT", "1h", 0, 10, nil)
+	if err != nil {
+		panic(err)
+	}
+	for _, it := range items {
+		it.Info = nil
+	}
+	fmt.Println(utils.MarshalString(items))
+}
+
+func TestFetchLongShortRatio(t *testing.T) {
+	exg := getBinance(nil)
+	items, err := exg.FetchLongShortRatioHistory("BTC/USDT:USDT", "1h", 0, 10, map[string]interface{}{
+		banexg.ParamRatioType: banexg.LSRatioTopPosition,
+	})
+	if err != nil {
+		panic(err)
+	}
+	for _, it := range items {
+		it.Info = nil
+	}
+	fmt.Println(utils.MarshalString(items))
+	vols, err := exg.FetchTakerVolumeHistory("BTC/USD:BTC", "1h", 0, 10, nil)
+	if err != nil {
+		panic(err)
+	}
+	for _, it := range vols {
+		it.Info = nil
+	}
+	fmt.Println(utils.MarshalString(vols))
+}
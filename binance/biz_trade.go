@@ -0,0 +1,450 @@
+package binance
+
+import (
+	"context"
+	"maps"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/bntp"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+/*
+FetchTrades
+get the list of most recent trades for a particular symbol
+
+	:see: https://binance-docs.github.io/apidocs/spot/en/#compressed-aggregate-trades-list
+	:see: https://binance-docs.github.io/apidocs/spot/en/#recent-trades-list
+	:see: https://binance-docs.github.io/apidocs/spot/en/#old-trade-lookup
+	:see: https://binance-docs.github.io/apidocs/futures/en/#compressed-aggregate-trades-list
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#compressed-aggregate-trades-list
+	:see: https://binance-docs.github.io/apidocs/voptions/en/#recent-trades-list
+	:see: https://binance-docs.github.io/apidocs/voptions/en/#old-trade-lookup-market_data
+	:param str symbol: unified symbol of the market to fetch trades for
+	:param int [since]: timestamp in ms of the earliest trade to fetch, only for aggTrades
+	:param int [limit]: the maximum amount of trades to fetch
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.method]: aggTrades(default) or trades or historicalTrades, option default trades
+	:param int [params.until]: the latest time in ms to fetch trades for, only for aggTrades
+	:param int [params.fromId]: trade id to fetch from, for aggTrades/historicalTrades
+	:returns Trade[]: a list of trade structures
+*/
+func (e *Binance) FetchTrades(symbol string, since int64, limit int, params map[string]interface{}) ([]*banexg.Trade, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	name := utils.PopMapVal(args, banexg.ParamMethod, "")
+	until := utils.PopMapVal(args, banexg.ParamUntil, int64(0))
+	if name == "" {
+		if market.Option {
+			name = "trades"
+		} else {
+			name = "aggTrades"
+		}
+	}
+	args["symbol"] = market.ID
+	if limit > 0 {
+		args["limit"] = limit
+	}
+	var method string
+	switch name {
+	case "aggTrades":
+		if market.Option {
+			return nil, errs.NewMsg(errs.CodeNotSupport, "aggTrades not support for option")
+		}
+		if since > 0 {
+			args["startTime"] = since
+		}
+		if until > 0 {
+			args["endTime"] = until
+		}
+		if market.Linear {
+			method = MethodFapiPublicGetAggTrades
+		} else if market.Inverse {
+			method = MethodDapiPublicGetAggTrades
+		} else {
+			method = MethodPublicGetAggTrades
+		}
+	case "trades", "historicalTrades":
+		if since > 0 || until > 0 {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "%s not support since/until, use aggTrades instead", name)
+		}
+		isHis := name == "historicalTrades"
+		if market.Option {
+			method = MethodEapiPublicGetTrades
+			if isHis {
+				method = MethodEapiPublicGetHistoricalTrades
+			}
+		} else if market.Linear {
+			method = MethodFapiPublicGetTrades
+			if isHis {
+				method = MethodFapiPublicGetHistoricalTrades
+			}
+		} else if market.Inverse {
+			method = MethodDapiPublicGetTrades
+			if isHis {
+				method = MethodDapiPublicGetHistoricalTrades
+			}
+		} else {
+			method = MethodPublicGetTrades
+			if isHis {
+				method = MethodPublicGetHistoricalTrades
+			}
+		}
+	default:
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "invalid method for FetchTrades: %s", name)
+	}
+	tryNum := e.GetRetryNum("FetchTrades", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	if name == "aggTrades" {
+		return parseTrades[*AggTrade](rsp, market.Symbol)
+	} else if market.Option {
+		return parseTrades[*OptionPubTrade](rsp, market.Symbol)
+	}
+	return parseTrades[*PubTrade](rsp, market.Symbol)
+}
+
+func parseTrades[T IBnbTrade](rsp *banexg.HttpRes, symbol string) ([]*banexg.Trade, *errs.Error) {
+	var data = make([]T, 0)
+	rspText := banexg.EnsureArrStr(rsp.Content)
+	items, err := utils.UnmarshalStringMapArr(rspText, &data)
+	if err != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err)
+	}
+	var result = make([]*banexg.Trade, 0, len(data))
+	for i, item := range data {
+		result = append(result, item.ToStdTrade(symbol, items[i]))
+	}
+	return result, nil
+}
+
+func newPubTrade(id, symbol, priceStr, qtyStr string, stamp int64, isBuyerMaker bool, info map[string]interface{}) *banexg.Trade {
+	price, _ := strconv.ParseFloat(priceStr, 64)
+	amount, _ := strconv.ParseFloat(qtyStr, 64)
+	amount = math.Abs(amount)
+	side := banexg.OdSideBuy
+	if isBuyerMaker {
+		side = banexg.OdSideSell
+	}
+	return &banexg.Trade{
+		ID:        id,
+		Symbol:    symbol,
+		Side:      side,
+		Amount:    amount,
+		Price:     price,
+		Cost:      price * amount,
+		Timestamp: stamp,
+		Maker:     isBuyerMaker,
+		Info:      info,
+	}
+}
+
+func (t *AggTrade) ToStdTrade(symbol string, info map[string]interface{}) *banexg.Trade {
+	id := strconv.FormatInt(t.ID, 10)
+	return newPubTrade(id, symbol, t.Price, t.Quantity, t.Time, t.IsBuyerMaker, info)
+}
+
+func (t *PubTrade) ToStdTrade(symbol string, info map[string]interface{}) *banexg.Trade {
+	id := strconv.FormatInt(t.ID, 10)
+	return newPubTrade(id, symbol, t.Price, t.Qty, t.Time, t.IsBuyerMaker, info)
+}
+
+func (t *OptionPubTrade) ToStdTrade(symbol string, info map[string]interface{}) *banexg.Trade {
+	id := t.TradeId
+	if id == "" {
+		id = t.ID
+	}
+	// side为taker方向，-1表示主动卖出，即买方为maker
+	return newPubTrade(id, symbol, t.Price, t.Qty, t.Time, t.Side < 0, info)
+}
+
+const (
+	myTradesBatch   = 1000                    // 单次请求最多返回条数
+	spotMyTradeIntv = int64(24 * 3600 * 1000) // 现货/杠杆 startTime和endTime最大间隔24小时
+	contMyTradeIntv = int64(7 * 24 * 3600 * 1000)
+)
+
+/*
+FetchMyTrades
+fetch all trades made by the user. 超过交易所允许的时间范围时，自动拆分为多个窗口请求
+
+	:see: https://binance-docs.github.io/apidocs/spot/en/#account-trade-list-user_data
+	:see: https://binance-docs.github.io/apidocs/spot/en/#query-margin-account-39-s-trade-list-user_data
+	:see: https://binance-docs.github.io/apidocs/futures/en/#account-trade-list-user_data
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#account-trade-list-user_data
+	:see: https://binance-docs.github.io/apidocs/voptions/en/#account-trade-list-user_data
+	:param str symbol: unified market symbol
+	:param int [since]: the earliest time in ms to fetch trades for
+	:param int [limit]: the maximum number of trades structures to retrieve
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param int [params.until]: the latest time in ms to fetch entries for, default now
+	:param int [params.loopIntv]: window size in ms, can not exceed the exchange limit (spot 24h, others 7d)
+	:param str [params.marginMode]: 'cross' or 'isolated', for spot margin trading
+	:returns MyTrade[]: a list of trade structures, sorted by time asc
+*/
+func (e *Binance) FetchMyTrades(symbol string, since int64, limit int, params map[string]interface{}) ([]*banexg.MyTrade, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	args["symbol"] = market.ID
+	marginMode := utils.PopMapVal(args, banexg.ParamMarginMode, "")
+	method := MethodPrivateGetMyTrades
+	maxIntv := spotMyTradeIntv
+	if market.Option {
+		method = MethodEapiPrivateGetUserTrades
+		maxIntv = contMyTradeIntv
+	} else if market.Linear {
+		method = MethodFapiPrivateGetUserTrades
+		maxIntv = contMyTradeIntv
+	} else if market.Inverse {
+		method = MethodDapiPrivateGetUserTrades
+		maxIntv = contMyTradeIntv
+	} else if market.Type == banexg.MarketMargin || marginMode != "" {
+		method = MethodSapiGetMarginMyTrades
+		if marginMode == banexg.MarginIsolated {
+			args["isIsolated"] = true
+		}
+	}
+	until := utils.PopMapVal(args, banexg.ParamUntil, int64(0))
+	loopIntv := utils.PopMapVal(args, banexg.ParamLoopIntv, int64(0))
+	if loopIntv <= 0 || loopIntv > maxIntv {
+		loopIntv = maxIntv
+	}
+	batchSize := myTradesBatch
+	if limit > 0 {
+		batchSize = min(limit, myTradesBatch)
+	}
+	args["limit"] = batchSize
+	if since <= 0 {
+		// 未指定开始时间，仅请求一次，由交易所返回最近的成交
+		if until > 0 {
+			args["endTime"] = until
+		}
+		return e.doFetchMyTrades(args, method, market)
+	}
+	if until <= 0 {
+		until = bntp.UTCStamp()
+	}
+	fetch := func(args map[string]interface{}) ([]*banexg.MyTrade, *errs.Error) {
+		return e.doFetchMyTrades(args, method, market)
+	}
+	return pageMyTrades(args, since, until, loopIntv, batchSize, limit, fetch)
+}
+
+/*
+pageMyTrades
+按时间窗口分页获取成交，返回结果按时间升序。
+一批成交都在窗口起始的同一毫秒内时，无法按时间推进，改用fromId分页取完该毫秒的成交
+*/
+func pageMyTrades(args map[string]interface{}, since, until, loopIntv int64, batchSize, limit int,
+	fetch func(args map[string]interface{}) ([]*banexg.MyTrade, *errs.Error)) ([]*banexg.MyTrade, *errs.Error) {
+	var result []*banexg.MyTrade
+	var visited = make(map[string]bool)
+	addItems := func(list []*banexg.MyTrade) {
+		for _, t := range list {
+			if _, ok := visited[t.ID]; ok {
+				continue
+			}
+			visited[t.ID] = true
+			result = append(result, t)
+		}
+	}
+	curStart := since
+	for curStart < until {
+		curEnd := min(until, curStart+loopIntv)
+		args["startTime"] = curStart
+		args["endTime"] = curEnd
+		list, err := fetch(args)
+		if err != nil {
+			return result, err
+		}
+		addItems(list)
+		if limit > 0 && len(result) >= limit {
+			return result[:limit], nil
+		}
+		if len(list) < batchSize {
+			curStart = curEnd
+			continue
+		}
+		// 当前窗口数据未取完，从最后一条的时间继续
+		lastMS := list[len(list)-1].Timestamp
+		if lastMS > curStart {
+			curStart = lastMS
+			continue
+		}
+		more, err := pageMyTradesById(args, list[len(list)-1].ID, curStart, batchSize, fetch)
+		addItems(more)
+		if err != nil {
+			return result, err
+		}
+		if limit > 0 && len(result) >= limit {
+			return result[:limit], nil
+		}
+		curStart += 1
+	}
+	return result, nil
+}
+
+/*
+pageMyTradesById
+从lastID之后按fromId分页，返回时间戳为stampMS的成交。fromId不能和startTime/endTime同时使用
+*/
+func pageMyTradesById(args map[string]interface{}, lastID string, stampMS int64, batchSize int,
+	fetch func(args map[string]interface{}) ([]*banexg.MyTrade, *errs.Error)) ([]*banexg.MyTrade, *errs.Error) {
+	idArgs := maps.Clone(args)
+	delete(idArgs, "startTime")
+	delete(idArgs, "endTime")
+	var result []*banexg.MyTrade
+	for {
+		lastNum, err_ := strconv.ParseInt(lastID, 10, 64)
+		if err_ != nil {
+			return result, errs.NewMsg(errs.CodeRunTime, "more than %d trades at %d, invalid trade id to page: %s",
+				batchSize, stampMS, lastID)
+		}
+		idArgs["fromId"] = lastNum + 1
+		list, err := fetch(idArgs)
+		if err != nil {
+			return result, err
+		}
+		for _, t := range list {
+			if t.Timestamp > stampMS {
+				return result, nil
+			}
+			result = append(result, t)
+		}
+		if len(list) < batchSize {
+			return result, nil
+		}
+		lastID = list[len(list)-1].ID
+	}
+}
+
+func (e *Binance) doFetchMyTrades(args map[string]interface{}, method string, market *banexg.Market) ([]*banexg.MyTrade, *errs.Error) {
+	tryNum := e.GetRetryNum("FetchMyTrades", 1)
+	rsp := e.RequestApiRetry(context.Background(), method, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	switch method {
+	case MethodPrivateGetMyTrades, MethodSapiGetMarginMyTrades:
+		return parseMyTrades[*SpotMyTrade](rsp, market)
+	case MethodFapiPrivateGetUserTrades:
+		return parseMyTrades[*FutureMyTrade](rsp, market)
+	case MethodDapiPrivateGetUserTrades:
+		return parseMyTrades[*InverseMyTrade](rsp, market)
+	case MethodEapiPrivateGetUserTrades:
+		return parseMyTrades[*OptionMyTrade](rsp, market)
+	default:
+		return nil, errs.NewMsg(errs.CodeNotSupport, "not support myTrades method %s", method)
+	}
+}
+
+func parseMyTrades[T IBnbMyTrade](rsp *banexg.HttpRes, market *banexg.Market) ([]*banexg.MyTrade, *errs.Error) {
+	var data = make([]T, 0)
+	rspText := banexg.EnsureArrStr(rsp.Content)
+	items, err := utils.UnmarshalStringMapArr(rspText, &data)
+	if err != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err)
+	}
+	var result = make([]*banexg.MyTrade, 0, len(data))
+	for i, item := range data {
+		result = append(result, item.ToStdMyTrade(market, items[i]))
+	}
+	return result, nil
+}
+
+func newMyTrade(market *banexg.Market, id, orderId, side, priceStr, qtyStr string, stamp int64, isMaker bool,
+	feeStr, feeCurr string, info map[string]interface{}) *banexg.MyTrade {
+	price, _ := strconv.ParseFloat(priceStr, 64)
+	amount, _ := strconv.ParseFloat(qtyStr, 64)
+	amount = math.Abs(amount)
+	feeCost, _ := strconv.ParseFloat(feeStr, 64)
+	feeCost = math.Abs(feeCost)
+	res := &banexg.MyTrade{
+		Trade: banexg.Trade{
+			ID:        id,
+			Symbol:    market.Symbol,
+			Side:      strings.ToLower(side),
+			Amount:    amount,
+			Price:     price,
+			Cost:      price * amount,
+			Order:     orderId,
+			Timestamp: stamp,
+			Maker:     isMaker,
+			Fee: &banexg.Fee{
+				IsMaker:   isMaker,
+				Currency:  feeCurr,
+				Cost:      feeCost,
+				QuoteCost: feeCost,
+			},
+		},
+		Info: info,
+	}
+	if feeCurr != "" && feeCurr == market.Base {
+		res.Fee.QuoteCost *= price
+	}
+	return res
+}
+
+func (t *SpotMyTrade) ToStdMyTrade(market *banexg.Market, info map[string]interface{}) *banexg.MyTrade {
+	side := banexg.OdSideSell
+	if t.IsBuyer {
+		side = banexg.OdSideBuy
+	}
+	id := strconv.FormatInt(t.ID, 10)
+	orderId := strconv.FormatInt(t.OrderId, 10)
+	res := newMyTrade(market, id, orderId, side, t.Price, t.Qty, t.Time, t.IsMaker, t.Commission,
+		t.CommissionAsset, info)
+	quoteQty, _ := strconv.ParseFloat(t.QuoteQty, 64)
+	if quoteQty > 0 {
+		res.Cost = quoteQty
+	}
+	return res
+}
+
+func (t *FutureMyTrade) ToStdMyTrade(market *banexg.Market, info map[string]interface{}) *banexg.MyTrade {
+	id := strconv.FormatInt(t.ID, 10)
+	orderId := strconv.FormatInt(t.OrderId, 10)
+	res := newMyTrade(market, id, orderId, t.Side, t.Price, t.Qty, t.Time, t.Maker, t.Commission,
+		t.CommissionAsset, info)
+	res.PosSide = strings.ToLower(t.PositionSide)
+	quoteQty, _ := strconv.ParseFloat(t.QuoteQty, 64)
+	if quoteQty > 0 {
+		res.Cost = quoteQty
+	}
+	return res
+}
+
+func (t *InverseMyTrade) ToStdMyTrade(market *banexg.Market, info map[string]interface{}) *banexg.MyTrade {
+	res := t.FutureMyTrade.ToStdMyTrade(market, info)
+	// 币本位数量为合约张数，花费以币计价
+	baseQty, _ := strconv.ParseFloat(t.BaseQty, 64)
+	if baseQty > 0 {
+		res.Cost = baseQty
+	}
+	if t.CommissionAsset == market.Base {
+		res.Fee.QuoteCost = res.Fee.Cost
+	}
+	return res
+}
+
+func (t *OptionMyTrade) ToStdMyTrade(market *banexg.Market, info map[string]interface{}) *banexg.MyTrade {
+	id := strconv.FormatInt(t.TradeId, 10)
+	if t.TradeId == 0 {
+		id = strconv.FormatInt(t.ID, 10)
+	}
+	orderId := strconv.FormatInt(t.OrderId, 10)
+	res := newMyTrade(market, id, orderId, t.Side, t.Price, t.Quantity, t.Time, t.Liquidity == "MAKER", t.Fee,
+		t.QuoteAsset, info)
+	res.Type = strings.ToLower(t.Type)
+	return res
+}
@@ -0,0 +1,90 @@
+package binance
+
+import (
+	"fmt"
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/bntp"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+	"strconv"
+	"testing"
+)
+
+func TestFetchTrades(t *testing.T) {
+	exg := getBinance(nil)
+	symbols := []string{"BTC/USDT", "BTC/USDT:USDT", "BTC/USD:BTC"}
+	for _, symbol := range symbols {
+		trades, err := exg.FetchTrades(symbol, 0, 5, nil)
+		if err != nil {
+			panic(err)
+		}
+		for _, trade := range trades {
+			trade.Info = nil
+		}
+		fmt.Println(symbol)
+		fmt.Println(utils.MarshalString(trades))
+	}
+}
+
+func TestFetchMyTrades(t *testing.T) {
+	exg := getBinance(nil)
+	// 跨越多个7天窗口，自动拆分请求
+	since := bntp.UTCStamp() - 20*24*3600*1000
+	trades, err := exg.FetchMyTrades("ETH/USDT:USDT", since, 0, nil)
+	if err != nil {
+		panic(err)
+	}
+	for _, trade := range trades {
+		trade.Info = nil
+	}
+	fmt.Println(utils.MarshalString(trades))
+}
+
+func TestPageMyTradesSameMS(t *testing.T) {
+	// 7 trades in the same millisecond, more than one batch
+	var all []*banexg.MyTrade
+	for i := 1; i <= 7; i++ {
+		all = append(all, &banexg.MyTrade{Trade: banexg.Trade{ID: strconv.Itoa(i), Timestamp: 1000}})
+	}
+	all = append(all, &banexg.MyTrade{Trade: banexg.Trade{ID: "8", Timestamp: 1500}})
+	batchSize := 3
+	fetch := func(args map[string]interface{}) ([]*banexg.MyTrade, *errs.Error) {
+		fromId := utils.GetMapVal(args, "fromId", int64(0))
+		start := utils.GetMapVal(args, "startTime", int64(0))
+		end := utils.GetMapVal(args, "endTime", int64(0))
+		if fromId > 0 && (start > 0 || end > 0) {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "fromId can not be used with time range")
+		}
+		var res []*banexg.MyTrade
+		for _, tr := range all {
+			id, _ := strconv.ParseInt(tr.ID, 10, 64)
+			if id < fromId || start > 0 && tr.Timestamp < start || end > 0 && tr.Timestamp > end {
+				continue
+			}
+			res = append(res, tr)
+			if len(res) >= batchSize {
+				break
+			}
+		}
+		return res, nil
+	}
+	res, err := pageMyTrades(map[string]interface{}{}, 1000, 2000, 2000, batchSize, 0, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != len(all) {
+		t.Fatalf("expect %d trades, got %d", len(all), len(res))
+	}
+	for i, tr := range res {
+		if tr.ID != all[i].ID {
+			t.Errorf("trade %d expect id %s, got %s", i, all[i].ID, tr.ID)
+		}
+	}
+	res, err = pageMyTrades(map[string]interface{}{}, 1000, 2000, 2000, batchSize, 5, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 5 {
+		t.Errorf("expect 5 trades with limit, got %d", len(res))
+	}
+}
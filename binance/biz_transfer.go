@@ -0,0 +1,222 @@
+package binance
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+// unified account type to binance universal transfer wallet
+var transferAccMap = map[string]string{
+	banexg.MarketSpot:    "MAIN",
+	banexg.MarketMargin:  "MARGIN",
+	banexg.MarketLinear:  "UMFUTURE",
+	banexg.MarketInverse: "CMFUTURE",
+	banexg.MarketOption:  "OPTION",
+	banexg.MarketFunding: "FUNDING",
+}
+
+func getTransferAcc(account string) (string, *errs.Error) {
+	if account == "" {
+		return "", errs.NewMsg(errs.CodeParamRequired, "transfer account is required")
+	}
+	if acc, ok := transferAccMap[account]; ok {
+		return acc, nil
+	}
+	// raw binance wallet such as ISOLATEDMARGIN is allowed
+	acc := strings.ToUpper(account)
+	for _, v := range transferAccMap {
+		if v == acc {
+			return acc, nil
+		}
+	}
+	if acc == "ISOLATEDMARGIN" {
+		return acc, nil
+	}
+	return "", errs.NewMsg(errs.CodeParamInvalid, "unsupported transfer account: %s", account)
+}
+
+func parseTransferAcc(acc string) string {
+	for k, v := range transferAccMap {
+		if v == acc {
+			return k
+		}
+	}
+	return strings.ToLower(acc)
+}
+
+func parseTransferStatus(status string) string {
+	switch status {
+	case "CONFIRMED", "SUCCESS":
+		return banexg.TransferStatusOk
+	case "PENDING":
+		return banexg.TransferStatusPending
+	case "FAILED":
+		return banexg.TransferStatusFailed
+	default:
+		return strings.ToLower(status)
+	}
+}
+
+/*
+Transfer
+transfer currency internally between wallets on the same account
+
+	:see: https://binance-docs.github.io/apidocs/spot/en/#user-universal-transfer-user_data
+	:param str code: unified currency code
+	:param float amount: amount to transfer
+	:param str fromAccount: account to transfer from: spot/margin/linear/inverse/option/funding
+	:param str toAccount: account to transfer to
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:returns TransferResult: a transfer structure
+*/
+func (e *Binance) Transfer(code string, amount float64, fromAccount, toAccount string, params map[string]interface{}) (*banexg.TransferResult, *errs.Error) {
+	if code == "" {
+		return nil, errs.NewMsg(errs.CodeParamRequired, "code is required for Transfer")
+	}
+	if amount <= 0 {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "amount must > 0 for Transfer")
+	}
+	fromAcc, err := getTransferAcc(fromAccount)
+	if err != nil {
+		return nil, err
+	}
+	toAcc, err := getTransferAcc(toAccount)
+	if err != nil {
+		return nil, err
+	}
+	if fromAcc == toAcc {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "fromAccount and toAccount are same: %s", fromAcc)
+	}
+	args := utils.SafeParams(params)
+	args["type"] = fromAcc + "_" + toAcc
+	args["asset"] = e.GetCurrencyID(code)
+	args["amount"] = strconv.FormatFloat(amount, 'f', -1, 64)
+	tryNum := e.GetRetryNum("Transfer", 1)
+	rsp := e.RequestApiRetry(banexg.GetParamCtx(params), MethodSapiPostAssetTransfer, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var data = struct {
+		TranId int64 `json:"tranId"`
+	}{}
+	info, err_ := utils.UnmarshalStringMap(rsp.Content, &data)
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	return &banexg.TransferResult{
+		ID:          strconv.FormatInt(data.TranId, 10),
+		Code:        code,
+		Amount:      amount,
+		FromAccount: parseTransferAcc(fromAcc),
+		ToAccount:   parseTransferAcc(toAcc),
+		Status:      banexg.TransferStatusOk,
+		Timestamp:   e.MilliSeconds(),
+		Info:        info,
+	}, nil
+}
+
+/*
+FetchTransfers
+fetch a history of internal transfers made on an account, fromAccount and toAccount are required by binance,
+default is spot -> linear
+
+	:see: https://binance-docs.github.io/apidocs/spot/en/#query-user-universal-transfer-history-user_data
+	:param str [code]: unified currency code of the currency transferred, filter on result and fetch next pages until limit if provided
+	:param int [since]: the earliest time in ms to fetch transfers for
+	:param int [limit]: the maximum number of transfers structures to retrieve, max 100 if code is empty
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.fromAccount]: account transfer from, default: spot
+	:param str [params.toAccount]: account transfer to, default: linear
+	:param int [params.until]: the latest time in ms to fetch transfers for
+	:returns TransferResult[]: a list of transfer structures
+*/
+func (e *Binance) FetchTransfers(code string, since int64, limit int, params map[string]interface{}) ([]*banexg.TransferResult, *errs.Error) {
+	args := utils.SafeParams(params)
+	fromAccount := utils.PopMapVal(args, banexg.ParamFromAccount, banexg.MarketSpot)
+	toAccount := utils.PopMapVal(args, banexg.ParamToAccount, banexg.MarketLinear)
+	fromAcc, err := getTransferAcc(fromAccount)
+	if err != nil {
+		return nil, err
+	}
+	toAcc, err := getTransferAcc(toAccount)
+	if err != nil {
+		return nil, err
+	}
+	args["type"] = fromAcc + "_" + toAcc
+	if since > 0 {
+		args["startTime"] = since
+	}
+	until := utils.PopMapVal(args, banexg.ParamUntil, int64(0))
+	if until > 0 {
+		args["endTime"] = until
+	}
+	// 接口不支持按币种过滤，指定code时按页获取，直到满足limit或没有更多记录
+	pageSize := 100
+	if limit > 0 && code == "" {
+		pageSize = min(limit, 100)
+	}
+	args["size"] = pageSize
+	page := utils.PopMapVal(args, "current", 1)
+	ctx := banexg.GetParamCtx(params)
+	tryNum := e.GetRetryNum("FetchTransfers", 1)
+	var res = make([]*banexg.TransferResult, 0)
+	fetched := 0
+	for {
+		args["current"] = page
+		rsp := e.RequestApiRetry(ctx, MethodSapiGetAssetTransfer, args, tryNum)
+		if rsp.Error != nil {
+			return nil, rsp.Error
+		}
+		var data = struct {
+			Total int                      `json:"total"`
+			Rows  []map[string]interface{} `json:"rows"`
+		}{}
+		err_ := utils.UnmarshalString(rsp.Content, &data, utils.JsonNumAuto)
+		if err_ != nil {
+			return nil, errs.New(errs.CodeUnmarshalFail, err_)
+		}
+		var rows = make([]*TransferRow, 0, len(data.Rows))
+		err_ = utils.DecodeStructMap(data.Rows, &rows, "json")
+		if err_ != nil {
+			return nil, errs.New(errs.CodeUnmarshalFail, err_)
+		}
+		for i, row := range rows {
+			item := row.ToStdTransfer(e)
+			if code != "" && item.Code != code {
+				continue
+			}
+			item.Info = data.Rows[i]
+			res = append(res, item)
+		}
+		fetched += len(data.Rows)
+		if limit > 0 && len(res) >= limit {
+			return res[:limit], nil
+		}
+		if code == "" || len(data.Rows) < pageSize || fetched >= data.Total {
+			break
+		}
+		page += 1
+	}
+	return res, nil
+}
+
+func (r *TransferRow) ToStdTransfer(e *Binance) *banexg.TransferResult {
+	amount, _ := strconv.ParseFloat(r.Amount, 64)
+	var fromAcc, toAcc string
+	if parts := strings.SplitN(r.Type, "_", 2); len(parts) == 2 {
+		fromAcc, toAcc = parseTransferAcc(parts[0]), parseTransferAcc(parts[1])
+	}
+	return &banexg.TransferResult{
+		ID:          strconv.FormatInt(r.TranId, 10),
+		Code:        e.SafeCurrencyCode(r.Asset),
+		Amount:      amount,
+		FromAccount: fromAcc,
+		ToAccount:   toAcc,
+		Status:      parseTransferStatus(r.Status),
+		Timestamp:   r.Timestamp,
+	}
+}
@@ -0,0 +1,96 @@
+package binance
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/bntp"
+	"github.com/banbox/banexg/utils"
+	"github.com/h2non/gock"
+)
+
+func TestTransfer(t *testing.T) {
+	exg := getBinance(nil)
+	res, err := exg.Transfer("USDT", 1, banexg.MarketSpot, banexg.MarketLinear, nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(utils.MarshalString(res))
+}
+
+func TestFetchTransfers(t *testing.T) {
+	exg := getBinance(nil)
+	since := bntp.UTCStamp() - 7*24*3600*1000
+	items, err := exg.FetchTransfers("USDT", since, 0, map[string]interface{}{
+		banexg.ParamFromAccount: banexg.MarketSpot,
+		banexg.ParamToAccount:   banexg.MarketLinear,
+	})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(utils.MarshalString(items))
+}
+
+func TestFetchTransfersPaging(t *testing.T) {
+	defer gock.Off()
+	gock.DisableNetworking()
+	exg, err := New(map[string]interface{}{
+		banexg.OptApiKey:    "key",
+		banexg.OptApiSecret: "secret",
+	})
+	if err != nil {
+		panic(err)
+	}
+	gock.InterceptClient(exg.HttpClient)
+	// 共150条记录，BTC和USDT交替，每页100条
+	makePage := func(start, num int) string {
+		rows := make([]string, 0, num)
+		for i := start; i < start+num; i++ {
+			asset := "BTC"
+			if i%2 == 0 {
+				asset = "USDT"
+			}
+			rows = append(rows, fmt.Sprintf(`{"asset":"%s","amount":"1","type":"MAIN_UMFUTURE","status":"CONFIRMED","tranId":%d,"timestamp":%d}`,
+				asset, i+1, 1700000000000+int64(i)))
+		}
+		return fmt.Sprintf(`{"total":150,"rows":[%s]}`, strings.Join(rows, ","))
+	}
+	mockPages := func() {
+		gock.New("https://api.binance.com").Get("/sapi/v1/asset/transfer").MatchParam("current", "^1$").
+			Reply(200).BodyString(makePage(0, 100))
+		gock.New("https://api.binance.com").Get("/sapi/v1/asset/transfer").MatchParam("current", "^2$").
+			Reply(200).BodyString(makePage(100, 50))
+	}
+	cases := []struct {
+		code   string
+		limit  int
+		expNum int
+	}{
+		{"USDT", 60, 60},
+		{"USDT", 0, 75},
+		{"", 20, 20},
+	}
+	for _, c := range cases {
+		gock.Flush()
+		mockPages()
+		items, err := exg.FetchTransfers(c.code, 0, c.limit, nil)
+		if err != nil {
+			t.Fatalf("fetch transfers %s %d fail: %v", c.code, c.limit, err)
+		}
+		if len(items) != c.expNum {
+			t.Errorf("fetch transfers %s %d expect %d items, got %d", c.code, c.limit, c.expNum, len(items))
+		}
+		for _, it := range items {
+			if c.code != "" && it.Code != c.code {
+				t.Errorf("unexpected transfer code: %s", it.Code)
+				break
+			}
+			if fmt.Sprint(it.Info["tranId"]) != it.ID {
+				t.Errorf("transfer info not match: %s %v", it.ID, it.Info)
+				break
+			}
+		}
+	}
+}
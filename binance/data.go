@@ -23,6 +23,9 @@ const (
 	HostFApiData      = "fapiData"
 	HostPApi          = "papi"
 	WssApi            = "ws"
+	WssPApi           = "wsPapi" // 统一账户用户数据流
+	WssFApi           = "wsFapi" // U本位合约ws-api
+	WssDApi           = "wsDapi" // 币本位合约ws-api
 )
 
 const (
@@ -292,6 +295,7 @@ const (
 	MethodSapiPostMarginTransfer                                      = "sapiPostMarginTransfer"
 	MethodSapiPostMarginLoan                                          = "sapiPostMarginLoan"
 	MethodSapiPostMarginRepay                                         = "sapiPostMarginRepay"
+	MethodSapiPostMarginBorrowRepay                                   = "sapiPostMarginBorrowRepay"
 	MethodSapiPostMarginOrder                                         = "sapiPostMarginOrder"
 	MethodSapiPostMarginOrderOco                                      = "sapiPostMarginOrderOco"
 	MethodSapiPostMarginDust                                          = "sapiPostMarginDust"
@@ -513,6 +517,7 @@ const (
 	MethodFapiPrivateGetCommissionRate                                = "fapiPrivateGetCommissionRate"
 	MethodFapiPrivateGetApiTradingStatus                              = "fapiPrivateGetApiTradingStatus"
 	MethodFapiPrivateGetMultiAssetsMargin                             = "fapiPrivateGetMultiAssetsMargin"
+	MethodFapiPrivateGetSymbolConfig                                  = "fapiPrivateGetSymbolConfig"
 	MethodFapiPrivateGetApiReferralIfNewUser                          = "fapiPrivateGetApiReferralIfNewUser"
 	MethodFapiPrivateGetApiReferralCustomization                      = "fapiPrivateGetApiReferralCustomization"
 	MethodFapiPrivateGetApiReferralUserCustomization                  = "fapiPrivateGetApiReferralUserCustomization"
@@ -621,6 +626,9 @@ const (
 	MethodPrivateGetMyAllocations                                     = "privateGetMyAllocations"
 	MethodPrivateGetAccountCommission                                 = "privateGetAccountCommission"
 	MethodPrivatePostOrderOco                                         = "privatePostOrderOco"
+	MethodPrivatePostOrderListOco                                     = "privatePostOrderListOco"
+	MethodPrivatePostOrderListOto                                     = "privatePostOrderListOto"
+	MethodPrivatePostOrderListOtoco                                   = "privatePostOrderListOtoco"
 	MethodPrivatePostSorOrder                                         = "privatePostSorOrder"
 	MethodPrivatePostSorOrderTest                                     = "privatePostSorOrderTest"
 	MethodPrivatePostOrder                                            = "privatePostOrder"
@@ -700,6 +708,8 @@ const (
 	MethodPapiPostListenKey                                           = "papiPostListenKey"
 	MethodPapiPostAssetCollection                                     = "papiPostAssetCollection"
 	MethodPapiPutListenKey                                            = "papiPutListenKey"
+	MethodPapiPutUmOrder                                              = "papiPutUmOrder"
+	MethodPapiPutCmOrder                                              = "papiPutCmOrder"
 	MethodPapiDeleteUmOrder                                           = "papiDeleteUmOrder"
 	MethodPapiDeleteUmConditionalOrder                                = "papiDeleteUmConditionalOrder"
 	MethodPapiDeleteUmAllOpenOrders                                   = "papiDeleteUmAllOpenOrders"
@@ -33,6 +33,8 @@ func New(Options map[string]interface{}) (*Binance, *errs.Error) {
 					banexg.MarketInverse: "wss://dstream.binancefuture.com/ws",
 					banexg.MarketOption:  "wss://nbstream.binancefuture.com/eoptions",
 					WssApi:               "wss://testnet.binance.vision/ws-api/v3",
+					WssFApi:              "wss://testnet.binancefuture.com/ws-fapi/v1",
+					WssDApi:              "wss://testnet.binancefuture.com/ws-dapi/v1",
 				},
 				Prod: map[string]string{
 					HostSApi:             "https://api.binance.com/sapi/v1",
@@ -60,6 +62,9 @@ func New(Options map[string]interface{}) (*Binance, *errs.Error) {
 					banexg.MarketInverse: "wss://dstream.binance.com/ws",
 					banexg.MarketOption:  "wss://nbstream.binance.com/eoptions",
 					WssApi:               "wss://ws-api.binance.com:443/ws-api/v3",
+					WssFApi:              "wss://ws-fapi.binance.com/ws-fapi/v1",
+					WssDApi:              "wss://ws-dapi.binance.com/ws-dapi/v1",
+					WssPApi:              "wss://fstream.binance.com/pm/ws",
 				},
 				Www: "https://www.binance.com",
 				Doc: []string{
@@ -385,6 +390,7 @@ func New(Options map[string]interface{}) (*Binance, *errs.Error) {
 				MethodSapiPostCapitalDepositCreditApply:                           {Path: "capital/deposit/credit-apply", Host: HostSApi, Method: "POST", Cost: 0.1},
 				MethodSapiPostMarginTransfer:                                      {Path: "margin/transfer", Host: HostSApi, Method: "POST", Cost: 4.0002},
 				MethodSapiPostMarginLoan:                                          {Path: "margin/loan", Host: HostSApi, Method: "POST", Cost: 20.001},
+				MethodSapiPostMarginBorrowRepay:                                   {Path: "margin/borrow-repay", Host: HostSApi, Method: "POST", Cost: 20.001},
 				MethodSapiPostMarginRepay:                                         {Path: "margin/repay", Host: HostSApi, Method: "POST", Cost: 20.001},
 				MethodSapiPostMarginOrder:                                         {Path: "margin/order", Host: HostSApi, Method: "POST", Cost: 0.040002},
 				MethodSapiPostMarginOrderOco:                                      {Path: "margin/order/oco", Host: HostSApi, Method: "POST", Cost: 0.040002},
@@ -607,6 +613,7 @@ func New(Options map[string]interface{}) (*Binance, *errs.Error) {
 				MethodFapiPrivateGetCommissionRate:                                {Path: "commissionRate", Host: HostFApiPrivate, Method: "GET", Cost: 20},
 				MethodFapiPrivateGetApiTradingStatus:                              {Path: "apiTradingStatus", Host: HostFApiPrivate, Method: "GET", Cost: 1},
 				MethodFapiPrivateGetMultiAssetsMargin:                             {Path: "multiAssetsMargin", Host: HostFApiPrivate, Method: "GET", Cost: 30},
+				MethodFapiPrivateGetSymbolConfig:                                  {Path: "symbolConfig", Host: HostFApiPrivate, Method: "GET", Cost: 5},
 				MethodFapiPrivateGetApiReferralIfNewUser:                          {Path: "apiReferral/ifNewUser", Host: HostFApiPrivate, Method: "GET", Cost: 1},
 				MethodFapiPrivateGetApiReferralCustomization:                      {Path: "apiReferral/customization", Host: HostFApiPrivate, Method: "GET", Cost: 1},
 				MethodFapiPrivateGetApiReferralUserCustomization:                  {Path: "apiReferral/userCustomization", Host: HostFApiPrivate, Method: "GET", Cost: 1},
@@ -715,6 +722,9 @@ func New(Options map[string]interface{}) (*Binance, *errs.Error) {
 				MethodPrivateGetMyAllocations:                                     {Path: "myAllocations", Host: HostPrivate, Method: "GET", Cost: 4},
 				MethodPrivateGetAccountCommission:                                 {Path: "account/commission", Host: HostPrivate, Method: "GET", Cost: 4},
 				MethodPrivatePostOrderOco:                                         {Path: "order/oco", Host: HostPrivate, Method: "POST", Cost: 0.2},
+				MethodPrivatePostOrderListOco:                                     {Path: "orderList/oco", Host: HostPrivate, Method: "POST", Cost: 0.2},
+				MethodPrivatePostOrderListOto:                                     {Path: "orderList/oto", Host: HostPrivate, Method: "POST", Cost: 0.2},
+				MethodPrivatePostOrderListOtoco:                                   {Path: "orderList/otoco", Host: HostPrivate, Method: "POST", Cost: 0.2},
 				MethodPrivatePostSorOrder:                                         {Path: "sor/order", Host: HostPrivate, Method: "POST", Cost: 0.2},
 				MethodPrivatePostSorOrderTest:                                     {Path: "sor/order/test", Host: HostPrivate, Method: "POST", Cost: 0.2},
 				MethodPrivatePostOrder:                                            {Path: "order", Host: HostPrivate, Method: "POST", Cost: 0.2},
@@ -794,6 +804,8 @@ func New(Options map[string]interface{}) (*Binance, *errs.Error) {
 				MethodPapiPostListenKey:                                           {Path: "listenKey", Host: HostPApi, Method: "POST", Cost: 1},
 				MethodPapiPostAssetCollection:                                     {Path: "asset-collection", Host: HostPApi, Method: "POST", Cost: 3},
 				MethodPapiPutListenKey:                                            {Path: "listenKey", Host: HostPApi, Method: "PUT", Cost: 1},
+				MethodPapiPutUmOrder:                                              {Path: "um/order", Host: HostPApi, Method: "PUT", Cost: 1},
+				MethodPapiPutCmOrder:                                              {Path: "cm/order", Host: HostPApi, Method: "PUT", Cost: 1},
 				MethodPapiDeleteUmOrder:                                           {Path: "um/order", Host: HostPApi, Method: "DELETE", Cost: 1},
 				MethodPapiDeleteUmConditionalOrder:                                {Path: "um/conditional/order", Host: HostPApi, Method: "DELETE", Cost: 1},
 				MethodPapiDeleteUmAllOpenOrders:                                   {Path: "um/allOpenOrders", Host: HostPApi, Method: "DELETE", Cost: 1},
@@ -809,36 +821,77 @@ func New(Options map[string]interface{}) (*Binance, *errs.Error) {
 			},
 			Has: map[string]map[string]int{
 				"": {
-					banexg.ApiFetchTicker:           banexg.HasOk,
-					banexg.ApiFetchTickers:          banexg.HasOk,
-					banexg.ApiFetchTickerPrice:      banexg.HasOk,
-					banexg.ApiLoadLeverageBrackets:  banexg.HasOk,
-					banexg.ApiGetLeverage:           banexg.HasOk,
-					banexg.ApiFetchOHLCV:            banexg.HasOk,
-					banexg.ApiFetchOrderBook:        banexg.HasOk,
-					banexg.ApiFetchOrder:            banexg.HasOk,
-					banexg.ApiFetchOrders:           banexg.HasOk,
-					banexg.ApiFetchBalance:          banexg.HasOk,
-					banexg.ApiFetchAccountPositions: banexg.HasOk,
-					banexg.ApiFetchPositions:        banexg.HasOk,
-					banexg.ApiFetchOpenOrders:       banexg.HasOk,
-					banexg.ApiCreateOrder:           banexg.HasOk,
-					banexg.ApiEditOrder:             banexg.HasOk,
-					banexg.ApiCancelOrder:           banexg.HasOk,
-					banexg.ApiSetLeverage:           banexg.HasOk,
-					banexg.ApiCalcMaintMargin:       banexg.HasOk,
-					banexg.ApiWatchOrderBooks:       banexg.HasOk,
-					banexg.ApiUnWatchOrderBooks:     banexg.HasOk,
-					banexg.ApiWatchOHLCVs:           banexg.HasOk,
-					banexg.ApiUnWatchOHLCVs:         banexg.HasOk,
-					banexg.ApiWatchMarkPrices:       banexg.HasOk,
-					banexg.ApiUnWatchMarkPrices:     banexg.HasOk,
-					banexg.ApiWatchTrades:           banexg.HasOk,
-					banexg.ApiUnWatchTrades:         banexg.HasOk,
-					banexg.ApiWatchMyTrades:         banexg.HasOk,
-					banexg.ApiWatchBalance:          banexg.HasOk,
-					banexg.ApiWatchPositions:        banexg.HasOk,
-					banexg.ApiWatchAccountConfig:    banexg.HasOk,
+					banexg.ApiFetchTicker:                  banexg.HasOk,
+					banexg.ApiFetchTickers:                 banexg.HasOk,
+					banexg.ApiFetchTrades:                  banexg.HasOk,
+					banexg.ApiFetchTickerPrice:             banexg.HasOk,
+					banexg.ApiFetchOpenInterest:            banexg.HasOk,
+					banexg.ApiFetchOpenInterestHistory:     banexg.HasOk,
+					banexg.ApiFetchLongShortRatioHistory:   banexg.HasOk,
+					banexg.ApiFetchTakerVolumeHistory:      banexg.HasOk,
+					banexg.ApiFetchLiquidations:            banexg.HasOk,
+					banexg.ApiLoadLeverageBrackets:         banexg.HasOk,
+					banexg.ApiGetLeverage:                  banexg.HasOk,
+					banexg.ApiFetchOHLCV:                   banexg.HasOk,
+					banexg.ApiFetchOrderBook:               banexg.HasOk,
+					banexg.ApiFetchOrder:                   banexg.HasOk,
+					banexg.ApiFetchOrders:                  banexg.HasOk,
+					banexg.ApiFetchBalance:                 banexg.HasOk,
+					banexg.ApiFetchAccountPositions:        banexg.HasOk,
+					banexg.ApiFetchPositions:               banexg.HasOk,
+					banexg.ApiFetchOpenOrders:              banexg.HasOk,
+					banexg.ApiFetchMyTrades:                banexg.HasOk,
+					banexg.ApiCreateOrder:                  banexg.HasOk,
+					banexg.ApiEditOrder:                    banexg.HasOk,
+					banexg.ApiCancelOrder:                  banexg.HasOk,
+					banexg.ApiCreateOrderBy:                banexg.HasOk,
+					banexg.ApiCreateOrders:                 banexg.HasOk,
+					banexg.ApiCancelOrders:                 banexg.HasOk,
+					banexg.ApiCancelAllOrders:              banexg.HasOk,
+					banexg.ApiCreateOrderList:              banexg.HasOk,
+					banexg.ApiCancelOrderList:              banexg.HasOk,
+					banexg.ApiFetchOrderList:               banexg.HasOk,
+					banexg.ApiSetLeverage:                  banexg.HasOk,
+					banexg.ApiSetMarginMode:                banexg.HasOk,
+					banexg.ApiSetPositionMode:              banexg.HasOk,
+					banexg.ApiFetchAccountConfig:           banexg.HasOk,
+					banexg.ApiFetchAccountStatus:           banexg.HasOk,
+					banexg.ApiAddMargin:                    banexg.HasOk,
+					banexg.ApiReduceMargin:                 banexg.HasOk,
+					banexg.ApiFetchMarginAdjustmentHistory: banexg.HasOk,
+					banexg.ApiBorrowMargin:                 banexg.HasOk,
+					banexg.ApiRepayMargin:                  banexg.HasOk,
+					banexg.ApiFetchBorrowRates:             banexg.HasOk,
+					banexg.ApiFetchBorrowInterest:          banexg.HasOk,
+					banexg.ApiTransfer:                     banexg.HasOk,
+					banexg.ApiFetchTransfers:               banexg.HasOk,
+					banexg.ApiFetchDepositAddress:          banexg.HasOk,
+					banexg.ApiFetchDeposits:                banexg.HasOk,
+					banexg.ApiFetchWithdrawals:             banexg.HasOk,
+					banexg.ApiWithdraw:                     banexg.HasOk,
+					banexg.ApiCalcMaintMargin:              banexg.HasOk,
+					banexg.ApiWatchOrderBooks:              banexg.HasOk,
+					banexg.ApiUnWatchOrderBooks:            banexg.HasOk,
+					banexg.ApiWatchOHLCVs:                  banexg.HasOk,
+					banexg.ApiUnWatchOHLCVs:                banexg.HasOk,
+					banexg.ApiWatchMarkPrices:              banexg.HasOk,
+					banexg.ApiUnWatchMarkPrices:            banexg.HasOk,
+					banexg.ApiWatchFundingRates:            banexg.HasOk,
+					banexg.ApiUnWatchFundingRates:          banexg.HasOk,
+					banexg.ApiWatchTickers:                 banexg.HasOk,
+					banexg.ApiUnWatchTickers:               banexg.HasOk,
+					banexg.ApiWatchBookTickers:             banexg.HasOk,
+					banexg.ApiUnWatchBookTickers:           banexg.HasOk,
+					banexg.ApiWatchOpenInterest:            banexg.HasOk,
+					banexg.ApiUnWatchOpenInterest:          banexg.HasOk,
+					banexg.ApiWatchLiquidations:            banexg.HasOk,
+					banexg.ApiUnWatchLiquidations:          banexg.HasOk,
+					banexg.ApiWatchTrades:                  banexg.HasOk,
+					banexg.ApiUnWatchTrades:                banexg.HasOk,
+					banexg.ApiWatchMyTrades:                banexg.HasOk,
+					banexg.ApiWatchBalance:                 banexg.HasOk,
+					banexg.ApiWatchPositions:               banexg.HasOk,
+					banexg.ApiWatchAccountConfig:           banexg.HasOk,
 				},
 			},
 			CredKeys: map[string]bool{"ApiKey": true, "Secret": true},
@@ -3,6 +3,7 @@ package binance
 import (
 	"github.com/banbox/banexg"
 	"github.com/banbox/banexg/errs"
+	"github.com/sasha-s/go-deadlock"
 )
 
 type Binance struct {
@@ -14,6 +15,14 @@ type Binance struct {
 	streamLimits     map[string]int                // marketType: limit
 	wsRequestId      map[string]int                // url: count
 	LeverageBrackets map[string]*SymbolLvgBrackets // symbol: Leverage Brackets
+	wsApiLogons      map[string]bool               // clientKey#connID: ws-api session logon done
+	wsUserSubs       map[string]int                // clientKey: connID subscribed to user data stream by ws-api
+	wsApiLock        deadlock.Mutex                // for wsApiLogons, wsUserSubs
+	wsReqIdLock      deadlock.Mutex                // for wsRequestId
+	oiStreamRefs     map[string]map[string]bool    // client prefix + option openInterest stream: watched symbols
+	oiRefLock        deadlock.Mutex                // for oiStreamRefs
+	bookTickerIds    map[string]int64              // client prefix + symbol: last bookTicker updateId
+	bookTickerLock   deadlock.Mutex                // for bookTickerIds
 }
 
 /*
@@ -662,6 +671,128 @@ type IBnbTicker interface {
 	ToStdTicker(e *Binance, marketType string, info map[string]interface{}) *banexg.Ticker
 }
 
+/*
+*****************************   Trades   ***********************************
+ */
+
+/*
+AggTrade
+归集交易，现货/U本位/币本位通用
+*/
+type AggTrade struct {
+	ID           int64  `json:"a"`
+	Price        string `json:"p"`
+	Quantity     string `json:"q"`
+	FirstId      int64  `json:"f"`
+	LastId       int64  `json:"l"`
+	Time         int64  `json:"T"`
+	IsBuyerMaker bool   `json:"m"`
+}
+
+/*
+PubTrade
+近期成交/历史成交，现货/U本位/币本位通用
+*/
+type PubTrade struct {
+	ID           int64  `json:"id"`
+	Price        string `json:"price"`
+	Qty          string `json:"qty"`
+	QuoteQty     string `json:"quoteQty"`
+	Time         int64  `json:"time"`
+	IsBuyerMaker bool   `json:"isBuyerMaker"`
+}
+
+type OptionPubTrade struct {
+	ID       string `json:"id"`
+	TradeId  string `json:"tradeId"`
+	Symbol   string `json:"symbol"`
+	Price    string `json:"price"`
+	Qty      string `json:"qty"`
+	QuoteQty string `json:"quoteQty"`
+	Side     int    `json:"side"` // 1买 -1卖
+	Time     int64  `json:"time"`
+}
+
+type IBnbTrade interface {
+	ToStdTrade(symbol string, info map[string]interface{}) *banexg.Trade
+}
+
+/*
+SpotMyTrade
+现货/杠杆账户成交记录
+*/
+type SpotMyTrade struct {
+	Symbol          string `json:"symbol"`
+	ID              int64  `json:"id"`
+	OrderId         int64  `json:"orderId"`
+	OrderListId     int64  `json:"orderListId"`
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	QuoteQty        string `json:"quoteQty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	Time            int64  `json:"time"`
+	IsBuyer         bool   `json:"isBuyer"`
+	IsMaker         bool   `json:"isMaker"`
+	IsIsolated      bool   `json:"isIsolated"`
+}
+
+/*
+FutureMyTrade
+U本位合约成交记录
+*/
+type FutureMyTrade struct {
+	Symbol          string `json:"symbol"`
+	ID              int64  `json:"id"`
+	OrderId         int64  `json:"orderId"`
+	Side            string `json:"side"`
+	PositionSide    string `json:"positionSide"`
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	QuoteQty        string `json:"quoteQty"`
+	RealizedPnl     string `json:"realizedPnl"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	Time            int64  `json:"time"`
+	Buyer           bool   `json:"buyer"`
+	Maker           bool   `json:"maker"`
+}
+
+/*
+InverseMyTrade
+币本位合约成交记录
+*/
+type InverseMyTrade struct {
+	FutureMyTrade
+	Pair        string `json:"pair"`
+	MarginAsset string `json:"marginAsset"`
+	BaseQty     string `json:"baseQty"`
+}
+
+/*
+OptionMyTrade
+期权成交记录
+*/
+type OptionMyTrade struct {
+	ID             int64  `json:"id"`
+	TradeId        int64  `json:"tradeId"`
+	OrderId        int64  `json:"orderId"`
+	Symbol         string `json:"symbol"`
+	Price          string `json:"price"`
+	Quantity       string `json:"quantity"`
+	Fee            string `json:"fee"`
+	RealizedProfit string `json:"realizedProfit"`
+	Side           string `json:"side"`
+	Type           string `json:"type"`
+	Liquidity      string `json:"liquidity"` // TAKER / MAKER
+	QuoteAsset     string `json:"quoteAsset"`
+	Time           int64  `json:"time"`
+}
+
+type IBnbMyTrade interface {
+	ToStdMyTrade(market *banexg.Market, info map[string]interface{}) *banexg.MyTrade
+}
+
 /*
 *****************************   OrderBook   ***********************************
  */
@@ -786,3 +917,250 @@ type LastPrice struct {
 	Time   int64  `json:"time,omitempty"` // linear/inverse
 	PS     string `json:"ps,omitempty"`   //inverse
 }
+
+/*
+*****************************   Wallet   ***********************************
+ */
+
+type TransferRow struct {
+	Asset     string `json:"asset"`
+	Amount    string `json:"amount"`
+	Type      string `json:"type"`
+	Status    string `json:"status"`
+	TranId    int64  `json:"tranId"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type BnbDepositAddress struct {
+	Address string `json:"address"`
+	Coin    string `json:"coin"`
+	Tag     string `json:"tag"`
+	Url     string `json:"url"`
+}
+
+type DepositRow struct {
+	ID            string `json:"id"`
+	Amount        string `json:"amount"`
+	Coin          string `json:"coin"`
+	Network       string `json:"network"`
+	Status        int    `json:"status"`
+	Address       string `json:"address"`
+	AddressTag    string `json:"addressTag"`
+	TxId          string `json:"txId"`
+	InsertTime    int64  `json:"insertTime"`
+	TransferType  int    `json:"transferType"`
+	ConfirmTimes  string `json:"confirmTimes"`
+	UnlockConfirm int    `json:"unlockConfirm"`
+	WalletType    int    `json:"walletType"`
+}
+
+type WithdrawRow struct {
+	ID              string `json:"id"`
+	Amount          string `json:"amount"`
+	TransactionFee  string `json:"transactionFee"`
+	Coin            string `json:"coin"`
+	Status          int    `json:"status"`
+	Address         string `json:"address"`
+	AddressTag      string `json:"addressTag"`
+	TxId            string `json:"txId"`
+	ApplyTime       string `json:"applyTime"` // UTC: 2019-10-12 11:12:02
+	Network         string `json:"network"`
+	TransferType    int    `json:"transferType"`
+	WithdrawOrderId string `json:"withdrawOrderId"`
+	Info            string `json:"info"`
+	ConfirmNo       int    `json:"confirmNo"`
+	WalletType      int    `json:"walletType"`
+	CompleteTime    string `json:"completeTime"`
+}
+
+type IBnbTransaction interface {
+	ToStdTransaction(e *Binance, info map[string]interface{}) *banexg.Transaction
+}
+
+/*
+*****************************   OpenInterest   ***********************************
+ */
+
+type OpenInterestCur struct {
+	Symbol       string `json:"symbol"`
+	Pair         string `json:"pair"` // inverse
+	OpenInterest string `json:"openInterest"`
+	ContractType string `json:"contractType"` // inverse
+	Time         int64  `json:"time"`
+}
+
+type OpenInterestHis struct {
+	Symbol               string `json:"symbol"`
+	Pair                 string `json:"pair"`         // inverse
+	ContractType         string `json:"contractType"` // inverse
+	SumOpenInterest      string `json:"sumOpenInterest"`
+	SumOpenInterestValue string `json:"sumOpenInterestValue"`
+	Timestamp            int64  `json:"timestamp"`
+}
+
+type OptionOpenInterest struct {
+	Symbol             string `json:"symbol"`
+	SumOpenInterest    string `json:"sumOpenInterest"`
+	SumOpenInterestUsd string `json:"sumOpenInterestUsd"`
+	Timestamp          string `json:"timestamp"`
+}
+
+/*
+*****************************   AccountConfig   ***********************************
+ */
+
+type SymbolConfig struct {
+	Symbol           string `json:"symbol"`
+	MarginType       string `json:"marginType"` // CROSSED/ISOLATED
+	IsAutoAddMargin  string `json:"isAutoAddMargin"`
+	Leverage         int    `json:"leverage"`
+	MaxNotionalValue string `json:"maxNotionalValue"`
+}
+
+/*
+*****************************   PositionMargin   ***********************************
+ */
+
+type PositionMarginRsp struct {
+	Amount float64 `json:"amount"`
+	Code   int     `json:"code"`
+	Msg    string  `json:"msg"`
+	Type   int     `json:"type"` // 1: add, 2: reduce
+}
+
+type PositionMarginHis struct {
+	Symbol       string `json:"symbol"`
+	Type         int    `json:"type"` // 1: add, 2: reduce
+	DeltaType    string `json:"deltaType"`
+	Amount       string `json:"amount"`
+	Asset        string `json:"asset"`
+	Time         int64  `json:"time"`
+	PositionSide string `json:"positionSide"`
+}
+
+/*
+*****************************   MarginLoan   ***********************************
+ */
+
+type MarginTranRsp struct {
+	TranId int64 `json:"tranId"`
+}
+
+type NextHourlyRate struct {
+	Asset                  string `json:"asset"`
+	NextHourlyInterestRate string `json:"nextHourlyInterestRate"`
+}
+
+type CrossMarginData struct {
+	VipLevel       int      `json:"vipLevel"`
+	Coin           string   `json:"coin"`
+	TransferIn     bool     `json:"transferIn"`
+	Borrowable     bool     `json:"borrowable"`
+	DailyInterest  string   `json:"dailyInterest"`
+	YearlyInterest string   `json:"yearlyInterest"`
+	BorrowLimit    string   `json:"borrowLimit"`
+	MarginablePair []string `json:"marginablePairs"`
+}
+
+type MarginInterest struct {
+	TxId                int64  `json:"txId"`
+	InterestAccuredTime int64  `json:"interestAccuredTime"`
+	Asset               string `json:"asset"`
+	RawAsset            string `json:"rawAsset"`
+	Principal           string `json:"principal"`
+	Interest            string `json:"interest"`
+	InterestRate        string `json:"interestRate"`
+	Type                string `json:"type"`
+	IsolatedSymbol      string `json:"isolatedSymbol"`
+}
+
+/*
+*****************************   PortfolioMargin   ***********************************
+ */
+
+type PapiBalance struct {
+	Asset               string `json:"asset"`
+	TotalWalletBalance  string `json:"totalWalletBalance"`  // 钱包余额 = 全仓杠杆未锁定 + 全仓杠杆锁定 + u本位合约钱包余额 + 币本位合约钱包余额
+	CrossMarginAsset    string `json:"crossMarginAsset"`    // 全仓资产 = 全仓杠杆未锁定 + 全仓杠杆锁定
+	CrossMarginBorrowed string `json:"crossMarginBorrowed"` // 全仓杠杆借贷
+	CrossMarginFree     string `json:"crossMarginFree"`     // 全仓杠杆未锁定
+	CrossMarginInterest string `json:"crossMarginInterest"` // 全仓杠杆利息
+	CrossMarginLocked   string `json:"crossMarginLocked"`   // 全仓杠杆锁定
+	UmWalletBalance     string `json:"umWalletBalance"`     // u本位合约钱包余额
+	UmUnrealizedPNL     string `json:"umUnrealizedPNL"`     // u本位未实现盈亏
+	CmWalletBalance     string `json:"cmWalletBalance"`     // 币本位合约钱包余额
+	CmUnrealizedPNL     string `json:"cmUnrealizedPNL"`     // 币本位未实现盈亏
+	UpdateTime          int64  `json:"updateTime"`
+	NegativeBalance     string `json:"negativeBalance"`
+}
+
+type PapiAccount struct {
+	UniMMR                   string `json:"uniMMR"`                   // 统一账户维持保证金率
+	AccountEquity            string `json:"accountEquity"`            // 以USD计价的统一账户权益
+	ActualEquity             string `json:"actualEquity"`             // 不考虑质押率的以USD计价的统一账户权益
+	AccountInitialMargin     string `json:"accountInitialMargin"`     // 统一账户初始保证金
+	AccountMaintMargin       string `json:"accountMaintMargin"`       // 统一账户维持保证金
+	AccountStatus            string `json:"accountStatus"`            // NORMAL/MARGIN_CALL/SUPPLY_MARGIN/REDUCE_ONLY/ACTIVE_LIQUIDATION/FORCE_LIQUIDATION/BANKRUPTED
+	VirtualMaxWithdrawAmount string `json:"virtualMaxWithdrawAmount"` // 以USD计价的最大可转出
+	TotalAvailableBalance    string `json:"totalAvailableBalance"`
+	TotalMarginOpenLoss      string `json:"totalMarginOpenLoss"`
+	UpdateTime               int64  `json:"updateTime"`
+}
+
+/*
+PapiStrategyOrder 统一账户条件单
+*/
+type PapiStrategyOrder struct {
+	NewClientStrategyId string `json:"newClientStrategyId"`
+	StrategyId          int    `json:"strategyId"`
+	StrategyStatus      string `json:"strategyStatus"`
+	StrategyType        string `json:"strategyType"`
+	OrigQty             string `json:"origQty"`
+	Price               string `json:"price"`
+	ReduceOnly          bool   `json:"reduceOnly"`
+	Side                string `json:"side"`
+	PositionSide        string `json:"positionSide"`
+	StopPrice           string `json:"stopPrice"`
+	Symbol              string `json:"symbol"`
+	TimeInForce         string `json:"timeInForce"`
+	ActivatePrice       string `json:"activatePrice"`
+	PriceRate           string `json:"priceRate"`
+	BookTime            int64  `json:"bookTime"`
+	UpdateTime          int64  `json:"updateTime"`
+	WorkingType         string `json:"workingType"`
+	PriceProtect        bool   `json:"priceProtect"`
+}
+
+/*
+*****************************   OrderList   ***********************************
+ */
+
+type OrderListItem struct {
+	Symbol        string `json:"symbol"`
+	OrderId       int    `json:"orderId"`
+	ClientOrderId string `json:"clientOrderId"`
+}
+
+/*
+OrderListRsp 现货关联订单组(OCO/OTO/OTOCO)，查询接口不返回orderReports
+*/
+type OrderListRsp struct {
+	OrderListId       int              `json:"orderListId"`
+	ContingencyType   string           `json:"contingencyType"` // OCO/OTO
+	ListStatusType    string           `json:"listStatusType"`  // RESPONSE/EXEC_STARTED/ALL_DONE
+	ListOrderStatus   string           `json:"listOrderStatus"` // EXECUTING/ALL_DONE/REJECT
+	ListClientOrderId string           `json:"listClientOrderId"`
+	TransactionTime   int64            `json:"transactionTime"`
+	Symbol            string           `json:"symbol"`
+	Orders            []*OrderListItem `json:"orders"`
+	OrderReports      []*SpotOrder     `json:"orderReports"`
+}
+
+/*
+WsSubLog 记录订阅的标的和参数，用于回放时按相同参数重新订阅
+*/
+type WsSubLog struct {
+	Symbols []string `json:"symbols"`
+	Market  string   `json:"market,omitempty"`
+	Name    string   `json:"name,omitempty"`
+}
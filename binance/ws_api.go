@@ -0,0 +1,356 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/log"
+	"github.com/banbox/banexg/utils"
+	"go.uber.org/zap"
+)
+
+const wsApiTimeout = 10 * time.Second
+
+// wsApiMethods rest method: ws-api method
+var wsApiMethods = map[string]string{
+	MethodPrivatePostOrder:       "order.place",
+	MethodFapiPrivatePostOrder:   "order.place",
+	MethodDapiPrivatePostOrder:   "order.place",
+	MethodPrivateDeleteOrder:     "order.cancel",
+	MethodFapiPrivateDeleteOrder: "order.cancel",
+	MethodDapiPrivateDeleteOrder: "order.cancel",
+	MethodFapiPrivatePutOrder:    "order.modify",
+	MethodDapiPrivatePutOrder:    "order.modify",
+	MethodPrivateGetOrder:        "order.status",
+	MethodFapiPrivateGetOrder:    "order.status",
+	MethodDapiPrivateGetOrder:    "order.status",
+}
+
+// wsApiHosts rest host: [ws-api host, marketType]
+var wsApiHosts = map[string][2]string{
+	HostPrivate:     {WssApi, banexg.MarketSpot},
+	HostFApiPrivate: {WssFApi, banexg.MarketLinear},
+	HostDApiPrivate: {WssDApi, banexg.MarketInverse},
+}
+
+/*
+requestOrderApi
+发送订单相关请求，设置OptOrderTransport=ws时优先通过ws-api发送，连接不可用时回退到rest
+*/
+func (e *Binance) requestOrderApi(ctx context.Context, method string, args map[string]interface{}, tryNum int) *banexg.HttpRes {
+	transport := utils.GetMapVal(e.Options, banexg.OptOrderTransport, banexg.OrderTransportRest)
+	if wsMethod, ok := wsApiMethods[method]; ok && transport == banexg.OrderTransportWs {
+		rsp, err := e.requestWsApi(ctx, method, wsMethod, args)
+		if err == nil {
+			return rsp
+		}
+		log.Warn("ws-api not available, fallback to rest", zap.String("method", wsMethod),
+			zap.String("err", err.Short()))
+	}
+	return e.RequestApiRetry(ctx, method, args, tryNum)
+}
+
+/*
+requestWsApi
+通过ws-api发送请求，返回的err不为空表示请求未发出，可安全回退到rest；
+请求发出后的交易所错误或超时记录在HttpRes.Error中
+*/
+func (e *Binance) requestWsApi(ctx context.Context, method, wsMethod string, params map[string]interface{}) (*banexg.HttpRes, *errs.Error) {
+	if e.WsDecoder != nil {
+		return nil, errs.NewMsg(errs.CodeNotSupport, "ws-api is disabled in replay mode")
+	}
+	api, ok := e.Apis[method]
+	if !ok {
+		return nil, errs.NewMsg(errs.CodeApiNotSupport, "invalid api: %s", method)
+	}
+	hostInfo, ok := wsApiHosts[api.Host]
+	if !ok {
+		return nil, errs.NewMsg(errs.CodeNotSupport, "ws-api not support for host: %s", api.Host)
+	}
+	args := utils.SafeParams(params)
+	ctx = utils.PopMapVal(args, banexg.ParamContext, ctx)
+	delete(args, banexg.ParamDebug)
+	accName, creds, err := e.GetAccountCreds(e.PopAccName(args))
+	if err != nil {
+		return nil, err
+	}
+	client, conn, err := e.getWsApiConn(accName, hostInfo[0], hostInfo[1])
+	if err != nil {
+		return nil, err
+	}
+	signMethod, _ := getSignMethod(creds.Secret)
+	if signMethod == "eddsa" {
+		err = e.wsApiLogon(ctx, client, conn, creds)
+		if err != nil {
+			return nil, err
+		}
+	}
+	reqArgs, err := e.makeWsApiArgs(args, creds, signMethod != "eddsa")
+	if err != nil {
+		return nil, err
+	}
+	res := &banexg.HttpRes{AccName: accName, Url: client.URL + "#" + wsMethod}
+	msg, err := e.writeWsApi(ctx, client, conn, wsMethod, reqArgs)
+	if err != nil {
+		if err.Code == errs.CodeConnectFail {
+			return nil, err
+		}
+		// 请求已发出，不可回退到rest，避免重复下单
+		res.Error = err
+		return res, nil
+	}
+	res.Status, _ = strconv.Atoi(msg["status"])
+	res.Content = msg["result"]
+	if res.Status != 200 {
+		errText := msg["error"]
+		res.Error = errs.NewMsg(res.Status, "%s: %s  %s", accName, wsMethod, errText)
+		var errRsp = ErrRsp{}
+		if err_ := utils.UnmarshalString(errText, &errRsp, utils.JsonNumDefault); err_ == nil {
+			res.Error.BizCode = errRsp.Code
+		}
+	}
+	return res, nil
+}
+
+/*
+getWsApiConn
+获取账户的ws-api连接，连接断开时返回错误
+*/
+func (e *Binance) getWsApiConn(accName, hostKey, marketType string) (*banexg.WsClient, *banexg.AsyncConn, *errs.Error) {
+	host := e.GetHost(hostKey)
+	if host == "" {
+		return nil, nil, errs.NewMsg(errs.CodeParamInvalid, "unsupport ws-api host for %s: %s", e.Name, hostKey)
+	}
+	client, err := e.GetClient(host, marketType, accName)
+	if err != nil {
+		return nil, nil, errs.NewMsg(errs.CodeConnectFail, "connect ws-api fail: %s", err.Short())
+	}
+	conns, lock := client.LockConns()
+	connList := utils.ValsOfMap(conns)
+	lock.Unlock()
+	// IsOK requires lock of conn, check after LockConns released
+	for _, conn := range connList {
+		if conn.IsOK() {
+			return client, conn, nil
+		}
+	}
+	return nil, nil, errs.NewMsg(errs.CodeConnectFail, "no available ws-api conn: %s", host)
+}
+
+/*
+makeWsApiArgs
+ws-api请求参数，值全部转为字符串；未登录会话时需要对每个请求签名
+*/
+func (e *Binance) makeWsApiArgs(args map[string]interface{}, creds *banexg.Credential, sign bool) (map[string]string, *errs.Error) {
+	var result = make(map[string]string, len(args)+3)
+	for k, v := range args {
+		result[k] = fmt.Sprintf("%v", v)
+	}
+	result["timestamp"] = strconv.FormatInt(e.Nonce(), 10)
+	if e.RecvWindow > 0 {
+		result["recvWindow"] = strconv.Itoa(e.RecvWindow)
+	}
+	if sign {
+		result["apiKey"] = creds.ApiKey
+		signature, err := signWsApiArgs(result, creds.Secret)
+		if err != nil {
+			return nil, err
+		}
+		result["signature"] = signature
+	}
+	return result, nil
+}
+
+// signWsApiArgs 参数按key排序后拼接为query字符串签名
+func signWsApiArgs(args map[string]string, secret string) (string, *errs.Error) {
+	keys := utils.KeysOfMap(args)
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+args[k])
+	}
+	method, hash := getSignMethod(secret)
+	return utils.Signature(strings.Join(parts, "&"), secret, method, hash, "hex")
+}
+
+/*
+wsApiLogon
+使用Ed25519密钥登录ws-api会话，之后的请求无需签名；每个连接只需登录一次，重连后需重新登录
+*/
+func (e *Binance) wsApiLogon(ctx context.Context, client *banexg.WsClient, conn *banexg.AsyncConn, creds *banexg.Credential) *errs.Error {
+	logonKey := fmt.Sprintf("%s#%d", client.Key, conn.GetID())
+	e.wsApiLock.Lock()
+	done := e.wsApiLogons[logonKey]
+	e.wsApiLock.Unlock()
+	if done {
+		return nil
+	}
+	args := map[string]string{
+		"apiKey":    creds.ApiKey,
+		"timestamp": strconv.FormatInt(e.Nonce(), 10),
+	}
+	signature, err := signWsApiArgs(args, creds.Secret)
+	if err != nil {
+		return err
+	}
+	args["signature"] = signature
+	msg, err := e.writeWsApi(ctx, client, conn, "session.logon", args)
+	if err != nil {
+		return err
+	}
+	if msg["status"] != "200" {
+		return errs.NewMsg(errs.CodeSignFail, "ws-api session.logon fail: %s", msg["error"])
+	}
+	e.wsApiLock.Lock()
+	e.wsApiLogons[logonKey] = true
+	e.wsApiLock.Unlock()
+	return nil
+}
+
+// resetWsApiLogon ws-api连接重连后会话失效，需重新登录
+func (e *Binance) resetWsApiLogon(client *banexg.WsClient, connID int) {
+	e.wsApiLock.Lock()
+	delete(e.wsApiLogons, fmt.Sprintf("%s#%d", client.Key, connID))
+	e.wsApiLock.Unlock()
+}
+
+// useWsApiUserStream 设置OptUserStream=wsApi时，现货用户数据流通过ws-api订阅，无需listenKey
+func (e *Binance) useWsApiUserStream(marketType string) bool {
+	mode := utils.GetMapVal(e.Options, banexg.OptUserStream, banexg.UserStreamListenKey)
+	return mode == banexg.UserStreamWsApi && marketType == banexg.MarketSpot
+}
+
+/*
+getWsApiUserClient
+获取已订阅用户数据流的ws-api客户端，与ws-api下单共用连接；每个连接只需订阅一次
+*/
+func (e *Binance) getWsApiUserClient(acc *banexg.Account) (*banexg.WsClient, *errs.Error) {
+	hostInfo := wsApiHosts[HostPrivate]
+	client, conn, err := e.getWsApiConn(acc.Name, hostInfo[0], hostInfo[1])
+	if err != nil {
+		return nil, err
+	}
+	e.wsApiLock.Lock()
+	connID, ok := e.wsUserSubs[client.Key]
+	e.wsApiLock.Unlock()
+	if ok && connID == conn.GetID() {
+		return client, nil
+	}
+	err = e.subWsApiUserStream(context.Background(), client, conn)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+/*
+subWsApiUserStream
+在ws-api连接上订阅用户数据流：Ed25519密钥先登录会话再订阅，HMAC/RSA密钥使用签名订阅
+*/
+func (e *Binance) subWsApiUserStream(ctx context.Context, client *banexg.WsClient, conn *banexg.AsyncConn) *errs.Error {
+	_, creds, err := e.GetAccountCreds(client.AccName)
+	if err != nil {
+		return err
+	}
+	var msg map[string]string
+	signMethod, _ := getSignMethod(creds.Secret)
+	if signMethod == "eddsa" {
+		err = e.wsApiLogon(ctx, client, conn, creds)
+		if err != nil {
+			return err
+		}
+		msg, err = e.writeWsApi(ctx, client, conn, "userDataStream.subscribe", nil)
+	} else {
+		var args map[string]string
+		args, err = e.makeWsApiArgs(nil, creds, true)
+		if err != nil {
+			return err
+		}
+		msg, err = e.writeWsApi(ctx, client, conn, "userDataStream.subscribe.signature", args)
+	}
+	if err != nil {
+		return err
+	}
+	if msg["status"] != "200" {
+		return errs.NewMsg(errs.CodeSignFail, "ws-api userDataStream.subscribe fail: %s", msg["error"])
+	}
+	e.wsApiLock.Lock()
+	e.wsUserSubs[client.Key] = conn.GetID()
+	e.wsApiLock.Unlock()
+	log.Info("ws-api user data stream subscribed", zap.String("acc", client.AccName), zap.Int("conn", conn.GetID()))
+	return nil
+}
+
+/*
+reSubWsApiUserStream
+ws-api连接重连后会话和订阅失效，需重新登录并订阅用户数据流。
+重连回调执行时读取循环被阻塞，需异步等待结果
+*/
+func (e *Binance) reSubWsApiUserStream(client *banexg.WsClient, connID int) {
+	e.wsApiLock.Lock()
+	subID, ok := e.wsUserSubs[client.Key]
+	e.wsApiLock.Unlock()
+	if !ok || subID != connID {
+		return
+	}
+	conns, lock := client.LockConns()
+	conn, ok := conns[connID]
+	lock.Unlock()
+	if !ok {
+		return
+	}
+	go func() {
+		err := e.subWsApiUserStream(context.Background(), client, conn)
+		if err != nil {
+			log.Error("re-subscribe ws-api user data stream fail", zap.String("acc", client.AccName),
+				zap.Int("conn", connID), zap.Error(err))
+		}
+	}()
+}
+
+/*
+writeWsApi
+发送ws-api请求并等待结果，通过请求id关联WsJobInfo；未能写入时返回CodeConnectFail
+*/
+func (e *Binance) writeWsApi(ctx context.Context, client *banexg.WsClient, conn *banexg.AsyncConn, method string,
+	args map[string]string) (map[string]string, *errs.Error) {
+	id := strconv.Itoa(e.nextId(client))
+	out := make(chan map[string]string, 1)
+	info := &banexg.WsJobInfo{
+		ID:   id,
+		Name: method,
+		Method: func(client *banexg.WsClient, msg map[string]string, info *banexg.WsJobInfo) {
+			out <- msg
+		},
+	}
+	request := map[string]interface{}{
+		"id":     id,
+		"method": method,
+	}
+	if len(args) > 0 {
+		request["params"] = args
+	}
+	err := client.Write(conn, request, info)
+	if err != nil {
+		client.DelJobInfo(id)
+		return nil, errs.NewMsg(errs.CodeConnectFail, "write ws-api fail: %s", err.Short())
+	}
+	timer := time.NewTimer(wsApiTimeout)
+	defer timer.Stop()
+	select {
+	case msg := <-out:
+		return msg, nil
+	case <-timer.C:
+		client.DelJobInfo(id)
+		return nil, errs.NewMsg(errs.CodeNetFail, "ws-api %s timeout, id: %s", method, id)
+	case <-ctx.Done():
+		client.DelJobInfo(id)
+		return nil, errs.New(errs.CodeCanceled, ctx.Err())
+	}
+}
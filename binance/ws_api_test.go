@@ -0,0 +1,45 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/banbox/banexg"
+)
+
+func TestSignWsApiArgs(t *testing.T) {
+	// example from https://developers.binance.com/docs/binance-spot-api-docs/web-socket-api#signed-request-example-hmac
+	args := map[string]string{
+		"symbol":           "BTCUSDT",
+		"side":             "SELL",
+		"type":             "LIMIT",
+		"timeInForce":      "GTC",
+		"quantity":         "0.01000000",
+		"price":            "52000.00",
+		"newOrderRespType": "ACK",
+		"recvWindow":       "100",
+		"timestamp":        "1645423376532",
+		"apiKey":           "vmPUZE6mv9SD5VNHk4HlWFsOr6aKE2zvsw0MuIgwCIPy6utIco14y7Ju91duEh8A",
+	}
+	secret := "NhqPtmdSJYdKjVHjA7PZj4Mge3R5YNiP1e3UZjInClVN65XAbvqqM6A7H5fATj0j"
+	sign, err := signWsApiArgs(args, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := "cc15477742bd704c29492d96c7ead9414dfd8e0ec4a00f947bb5bb454ddbd08a"
+	if sign != expect {
+		t.Errorf("signature mismatch, expect %s, got %s", expect, sign)
+	}
+}
+
+func TestWsApiUserStream(t *testing.T) {
+	exg := getBinance(map[string]interface{}{
+		banexg.OptUserStream: banexg.UserStreamWsApi,
+	})
+	out, err := exg.WatchBalance(nil)
+	if err != nil {
+		panic(err)
+	}
+	for item := range out {
+		t.Logf("balance: %v", item.Assets)
+	}
+}
@@ -16,9 +16,18 @@ import (
 )
 
 func makeHandleWsMsg(e *Binance) banexg.FuncOnWsMsg {
-	return func(client *banexg.WsClient, item *banexg.WsMsg) {
+	var handle banexg.FuncOnWsMsg
+	handle = func(client *banexg.WsClient, item *banexg.WsMsg) {
 		if item.Event == "" {
-			if item.ID != "" {
+			if evtText, ok := item.Object["event"]; ok && item.Object["subscriptionId"] != "" {
+				// ws-api用户数据流推送，事件包装在event字段中
+				evt, err := banexg.NewWsMsg(evtText)
+				if err != nil {
+					log.Error("invalid ws-api user data event", zap.String("msg", item.Text), zap.Error(err))
+					return
+				}
+				handle(client, evt)
+			} else if item.ID != "" {
 				// 任务结果返回
 				err := banexg.CheckWsError(item.Object)
 				if err != nil {
@@ -26,6 +35,9 @@ func makeHandleWsMsg(e *Binance) banexg.FuncOnWsMsg {
 				} else {
 					log.Debug("ws job ok", zap.String("job", item.ID))
 				}
+			} else if _, ok := item.Object["u"]; ok && item.Object["b"] != "" {
+				// 现货bookTicker推送无事件字段
+				e.handleBookTicker(client, item.Object)
 			} else {
 				log.Warn("no event ws msg", zap.String("msg", item.Text))
 			}
@@ -57,15 +69,18 @@ func makeHandleWsMsg(e *Binance) banexg.FuncOnWsMsg {
 			e.handleMarkPrices(client, msgList, item.IsArray)
 		case "24hrTicker":
 			//spot/linear/inverse/option
-			e.handleTickers(client, msgList)
+			e.handleTickers(client, msgList, item.IsArray)
 		case "24hrMiniTicker":
 			//spot/linear/inverse
-			e.handleTickers(client, msgList)
+			e.handleTickers(client, msgList, item.IsArray)
 		case "bookTicker":
-			e.handleTickers(client, msgList)
+			e.handleBookTicker(client, msg)
 		case "openInterest":
 			// option 合约持仓量
-			break
+			e.handleOpenInterest(client, msgList)
+		case "forceOrder":
+			// linear/inverse 强平订单
+			e.handleLiquidation(client, msg)
 		case "outboundAccountPosition":
 			e.handleBalance(client, msg)
 		case "balanceUpdate":
@@ -78,15 +93,28 @@ func makeHandleWsMsg(e *Binance) banexg.FuncOnWsMsg {
 			e.handleOrderUpdate(client, msg)
 		case "ACCOUNT_CONFIG_UPDATE":
 			e.handleAccountConfigUpdate(client, msg)
-		case "TRADE_LITE":
+		case "riskLevelChange":
+			// 统一账户风险等级变化
+			log.Warn("portfolio margin risk level change", zap.String("uniMMR", msg["u"]),
+				zap.String("level", msg["s"]))
+		case "eventStreamTerminated":
+			// ws-api会话登出，用户数据流已停止
+			e.wsApiLock.Lock()
+			delete(e.wsUserSubs, client.Key)
+			e.wsApiLock.Unlock()
+			log.Warn("ws-api user data stream terminated", zap.String("acc", client.AccName))
+		case "TRADE_LITE", "liabilityChange", "openOrderLoss", "CONDITIONAL_ORDER_TRADE_UPDATE":
 		default:
 			log.Warn("unhandle ws msg", zap.String("msg", item.Text))
 		}
 	}
+	return handle
 }
 
 func makeHandleWsReCon(e *Binance) banexg.FuncOnWsReCon {
 	return func(client *banexg.WsClient, connID int) *errs.Error {
+		e.resetWsApiLogon(client, connID)
+		e.reSubWsApiUserStream(client, connID)
 		subParams := client.GetSubKeys(connID)
 		if len(subParams) == 0 {
 			return nil
@@ -111,8 +139,9 @@ func (e *Binance) postListenKey(acc *banexg.Account, params map[string]interface
 	zeroVal := int64(0)
 	args := utils.SafeParams(params)
 	marketType, _ := e.GetArgsMarketType(args, "")
-	lastTimeKey := marketType + "lastAuthTime"
-	authField := marketType + banexg.MidListenKey
+	keyPrefix := e.userStreamPrefix(acc, marketType)
+	lastTimeKey := keyPrefix + "lastAuthTime"
+	authField := keyPrefix + banexg.MidListenKey
 	lastAuthTime := utils.GetMapVal(acc.Data, lastTimeKey, zeroVal)
 	authRefreshSecs := utils.GetMapVal(e.Options, banexg.OptAuthRefreshSecs, 1200)
 	refreshDuration := int64(authRefreshSecs * 1000)
@@ -122,7 +151,10 @@ func (e *Binance) postListenKey(acc *banexg.Account, params map[string]interface
 	}
 	marginMode := utils.PopMapVal(args, banexg.ParamMarginMode, "")
 	method := MethodPublicPostUserDataStream
-	if marketType == banexg.MarketLinear {
+	if keyPrefix == pmStreamPrefix {
+		// 统一账户的U本位、币本位、全仓杠杆共用papi用户数据流
+		method = MethodPapiPostListenKey
+	} else if marketType == banexg.MarketLinear {
 		method = MethodFapiPrivatePostListenKey
 	} else if marketType == banexg.MarketInverse {
 		method = MethodDapiPrivatePostListenKey
@@ -159,8 +191,9 @@ func (e *Binance) postListenKey(acc *banexg.Account, params map[string]interface
 func (e *Binance) keepAliveListenKey(acc *banexg.Account, params map[string]interface{}) {
 	args := utils.SafeParams(params)
 	marketType, _ := e.GetArgsMarketType(args, "")
-	lastTimeKey := marketType + "lastAuthTime"
-	authField := marketType + banexg.MidListenKey
+	keyPrefix := e.userStreamPrefix(acc, marketType)
+	lastTimeKey := keyPrefix + "lastAuthTime"
+	authField := keyPrefix + banexg.MidListenKey
 	acc.LockData.Lock()
 	listenKey := utils.GetMapVal(acc.Data, authField, "")
 	acc.LockData.Unlock()
@@ -176,7 +209,7 @@ func (e *Binance) keepAliveListenKey(acc *banexg.Account, params map[string]inte
 		delete(acc.Data, authField)
 		delete(acc.Data, lastTimeKey)
 		acc.LockData.Unlock()
-		clientKey := acc.Name + "@" + e.GetHost(marketType) + "/" + listenKey
+		clientKey := acc.Name + "@" + e.userWsHost(keyPrefix) + "/" + listenKey
 		if client, ok := e.WSClients[clientKey]; ok {
 			conns, lock := client.LockConns()
 			connList := utils.ValsOfMap(conns)
@@ -188,7 +221,9 @@ func (e *Binance) keepAliveListenKey(acc *banexg.Account, params map[string]inte
 		}
 	}()
 	method := MethodPublicPutUserDataStream
-	if marketType == banexg.MarketLinear {
+	if keyPrefix == pmStreamPrefix {
+		method = MethodPapiPutListenKey
+	} else if marketType == banexg.MarketLinear {
 		method = MethodFapiPrivatePutListenKey
 	} else if marketType == banexg.MarketInverse {
 		method = MethodDapiPrivatePutListenKey
@@ -240,16 +275,22 @@ func (e *Binance) getAuthClient(params map[string]interface{}) (string, *banexg.
 	if err != nil {
 		return "", nil, err
 	}
+	args := utils.SafeParams(params)
+	marketType, _ := e.GetArgsMarketType(args, "")
+	if e.useWsApiUserStream(marketType) {
+		// 通过ws-api会话订阅，无需listenKey
+		client, err := e.getWsApiUserClient(acc)
+		return "", client, err
+	}
 	err = e.AuthWS(acc, params)
 	if err != nil {
 		return "", nil, err
 	}
-	args := utils.SafeParams(params)
-	marketType, _ := e.GetArgsMarketType(args, "")
+	keyPrefix := e.userStreamPrefix(acc, marketType)
 	acc.LockData.Lock()
-	listenKey := utils.GetMapVal(acc.Data, marketType+banexg.MidListenKey, "")
+	listenKey := utils.GetMapVal(acc.Data, keyPrefix+banexg.MidListenKey, "")
 	acc.LockData.Unlock()
-	wsUrl := e.GetHost(marketType) + "/" + listenKey
+	wsUrl := e.userWsHost(keyPrefix) + "/" + listenKey
 	client, err := e.GetClient(wsUrl, marketType, acc.Name)
 	return listenKey, client, err
 }
@@ -334,58 +375,114 @@ func (e *Binance) UnWatchOHLCVs(jobs [][2]string, params map[string]interface{})
 }
 
 func (e *Binance) WatchMarkPrices(symbols []string, params map[string]interface{}) (chan map[string]float64, *errs.Error) {
-	chanKey, args, err := e.prepareMarkPrices(true, symbols, params)
+	chanKey, refKeys, args, err := e.prepareMarkPrices(true, symbols, params, "markPrice")
 	if err != nil {
 		return nil, err
 	}
 	create := func(cap int) chan map[string]float64 { return make(chan map[string]float64, cap) }
 	out := banexg.GetWsOutChan(e.Exchange, chanKey, create, args)
-	e.AddWsChanRefs(chanKey, "markPrice")
+	e.AddWsChanRefs(chanKey, refKeys...)
 	e.DumpWS("WatchMarkPrices", symbols)
 	return out, nil
 }
 
 func (e *Binance) UnWatchMarkPrices(symbols []string, params map[string]interface{}) *errs.Error {
-	chanKey, _, err := e.prepareMarkPrices(false, symbols, params)
+	chanKey, refKeys, _, err := e.prepareMarkPrices(false, symbols, params, "markPrice")
+	if err != nil {
+		return err
+	}
+	e.DelWsChanRefs(chanKey, refKeys...)
+	return nil
+}
+
+/*
+WatchFundingRates
+订阅资金费率推送，复用标记价格(markPrice)的订阅，不会新建ws订阅。symbols为空时订阅全市场
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#mark-price-stream
+	:see: https://binance-docs.github.io/apidocs/delivery/en/#mark-price-stream
+	:param []string symbols: unified linear/inverse symbols
+	:param dict [params]: extra parameters
+	:param str [params.interval]: 1s or empty(3s)
+*/
+func (e *Binance) WatchFundingRates(symbols []string, params map[string]interface{}) (chan []*banexg.FundingRateCur, *errs.Error) {
+	chanKey, refKeys, args, err := e.prepareMarkPrices(true, symbols, params, "fundingRate")
+	if err != nil {
+		return nil, err
+	}
+	create := func(cap int) chan []*banexg.FundingRateCur { return make(chan []*banexg.FundingRateCur, cap) }
+	out := banexg.GetWsOutChan(e.Exchange, chanKey, create, args)
+	e.AddWsChanRefs(chanKey, refKeys...)
+	e.DumpWS("WatchFundingRates", symbols)
+	return out, nil
+}
+
+func (e *Binance) UnWatchFundingRates(symbols []string, params map[string]interface{}) *errs.Error {
+	chanKey, refKeys, _, err := e.prepareMarkPrices(false, symbols, params, "fundingRate")
 	if err != nil {
 		return err
 	}
-	e.DelWsChanRefs(chanKey, "markPrice")
+	e.DelWsChanRefs(chanKey, refKeys...)
 	return nil
 }
 
-func (e *Binance) prepareMarkPrices(isSub bool, symbols []string, params map[string]interface{}) (string, map[string]interface{}, *errs.Error) {
+/*
+prepareMarkPrices
+标记价格和资金费率共用markPrice订阅，name为markPrice或fundingRate，用于区分输出chan。
+返回的refKeys为symbols，symbols为空时为全市场stream。
+取消订阅时，跳过另一方仍在引用的symbol，只对其余symbol发送取消请求
+*/
+func (e *Binance) prepareMarkPrices(isSub bool, symbols []string, params map[string]interface{}, name string) (string, []string, map[string]interface{}, *errs.Error) {
 	args := utils.SafeParams(params)
 	marketType, _, err := e.LoadArgsMarketType(args, symbols...)
 	if err != nil {
-		return "", nil, err
+		return "", nil, nil, err
 	}
-	if !e.IsContract(marketType) && marketType != banexg.MarketOption {
-		return "", nil, errs.NewMsg(errs.CodeUnsupportMarket, "WatchMarkPrices support linear/inverse/option, current: %s", marketType)
+	other := "fundingRate"
+	if name == "fundingRate" {
+		other = "markPrice"
+		if !e.IsContract(marketType) {
+			return "", nil, nil, errs.NewMsg(errs.CodeUnsupportMarket, "WatchFundingRates support linear/inverse, current: %s", marketType)
+		}
+	} else if !e.IsContract(marketType) && marketType != banexg.MarketOption {
+		return "", nil, nil, errs.NewMsg(errs.CodeUnsupportMarket, "WatchMarkPrices support linear/inverse/option, current: %s", marketType)
 	}
 	msgHash := marketType + "@markPrice"
 	client, err := e.GetWsClient(marketType, msgHash)
 	if err != nil {
-		return "", nil, err
+		return "", nil, nil, err
 	}
+	chanKey := client.Prefix(marketType + "@" + name)
 	intv := utils.PopMapVal(args, banexg.ParamInterval, "")
 	if intv != "" {
 		if intv != "1s" {
-			return "", nil, errs.NewMsg(errs.CodeParamInvalid, "ParamInterval must be 1s or empty")
+			return "", nil, nil, errs.NewMsg(errs.CodeParamInvalid, "ParamInterval must be 1s or empty")
 		}
 		intv = "@" + intv
 	}
-	if len(symbols) == 0 {
-		symbols = []string{"!markPrice@arr" + intv}
+	refKeys := symbols
+	if len(refKeys) == 0 {
+		refKeys = []string{"!markPrice@arr" + intv}
 	}
-	err = e.WriteWSMsg(client, 0, isSub, symbols, func(m *banexg.Market, _ int) string {
-		return m.LowercaseID + "@markPrice" + intv
-	}, nil)
-	if err != nil {
-		return "", nil, err
+	subKeys := refKeys
+	if !isSub {
+		otherKey := client.Prefix(marketType + "@" + other)
+		subKeys = make([]string, 0, len(refKeys))
+		for _, key := range refKeys {
+			if !e.HasWsChanRef(otherKey, key) {
+				subKeys = append(subKeys, key)
+			}
+		}
 	}
-	chanKey := client.Prefix(msgHash)
-	return chanKey, args, nil
+	if len(subKeys) > 0 {
+		err = e.WriteWSMsg(client, 0, isSub, subKeys, func(m *banexg.Market, _ int) string {
+			return m.LowercaseID + "@markPrice" + intv
+		}, nil)
+		if err != nil {
+			return "", nil, nil, err
+		}
+	}
+	return chanKey, refKeys, args, nil
 }
 
 func (e *Binance) handleMarkPrices(client *banexg.WsClient, msgList []map[string]string, isArray bool) {
@@ -398,6 +495,7 @@ func (e *Binance) handleMarkPrices(client *banexg.WsClient, msgList []map[string
 		e.MarkPrices[client.MarketType] = data
 	}
 	var res = map[string]float64{}
+	var rates = make([]*banexg.FundingRateCur, 0, len(msgList))
 	var marketId string
 	for _, msg := range msgList {
 		marketId, _ = utils.SafeMapVal(msg, "s", "")
@@ -407,6 +505,10 @@ func (e *Binance) handleMarkPrices(client *banexg.WsClient, msgList []map[string
 			continue
 		}
 		res[symbol] = markPrice
+		if _, ok := msg["r"]; ok {
+			// linear/inverse 推送包含资金费率
+			rates = append(rates, parseWsFundingRate(symbol, markPrice, msg))
+		}
 	}
 	maps.Copy(data, res)
 	e.MarkPriceLock.Unlock()
@@ -431,6 +533,28 @@ func (e *Binance) handleMarkPrices(client *banexg.WsClient, msgList []map[string
 	client.SetSubsKeyStamp(subsKey, bntp.UTCStamp())
 	chanKey := client.Prefix(client.MarketType + "@markPrice")
 	banexg.WriteOutChan(e.Exchange, chanKey, res, true)
+	if len(rates) > 0 {
+		chanKey = client.Prefix(client.MarketType + "@fundingRate")
+		banexg.WriteOutChan(e.Exchange, chanKey, rates, true)
+	}
+}
+
+func parseWsFundingRate(symbol string, markPrice float64, msg map[string]string) *banexg.FundingRateCur {
+	evtTime, _ := utils.SafeMapVal(msg, "E", int64(0))
+	rate, _ := utils.SafeMapVal(msg, "r", float64(0))
+	indexPrice, _ := utils.SafeMapVal(msg, "i", float64(0))
+	settlePrice, _ := utils.SafeMapVal(msg, "P", float64(0))
+	nextTime, _ := utils.SafeMapVal(msg, "T", int64(0))
+	return &banexg.FundingRateCur{
+		Symbol:               symbol,
+		FundingRate:          rate,
+		Timestamp:            evtTime,
+		MarkPrice:            markPrice,
+		IndexPrice:           indexPrice,
+		EstimatedSettlePrice: settlePrice,
+		NextFundingTimestamp: nextTime,
+		Info:                 utils.ToStdMap(msg),
+	}
 }
 
 func (e *Binance) handleTrade(client *banexg.WsClient, msg map[string]string) {
@@ -574,8 +698,528 @@ func (e *Binance) prepareOHLCVSub(isSub bool, jobs [][2]string, params map[strin
 	return chanKey, symbols, args, nil
 }
 
-func (e *Binance) handleTickers(client *banexg.WsClient, msgList []map[string]string) {
+/*
+WatchTickers
+订阅24小时滚动窗口行情统计。symbols为空时订阅全市场（期权不支持）
+
+:param []string symbols: unified symbols
+:param dict [params]: extra parameters
+:param str [params.name]: ticker(默认) or miniTicker
+*/
+func (e *Binance) WatchTickers(symbols []string, params map[string]interface{}) (chan []*banexg.Ticker, *errs.Error) {
+	chanKey, refKeys, args, err := e.prepareWatchTickers(true, symbols, params)
+	if err != nil {
+		return nil, err
+	}
+	create := func(cap int) chan []*banexg.Ticker { return make(chan []*banexg.Ticker, cap) }
+	out := banexg.GetWsOutChan(e.Exchange, chanKey, create, args)
+	e.AddWsChanRefs(chanKey, refKeys...)
+	e.dumpWsSub("WatchTickers", symbols, params)
+	return out, nil
+}
+
+func (e *Binance) UnWatchTickers(symbols []string, params map[string]interface{}) *errs.Error {
+	chanKey, refKeys, _, err := e.prepareWatchTickers(false, symbols, params)
+	if err != nil {
+		return err
+	}
+	e.DelWsChanRefs(chanKey, refKeys...)
+	return nil
+}
+
+func (e *Binance) prepareWatchTickers(isSub bool, symbols []string, params map[string]interface{}) (string, []string, map[string]interface{}, *errs.Error) {
+	args := utils.SafeParams(params)
+	marketType, _, err := e.LoadArgsMarketType(args, symbols...)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	name := utils.PopMapVal(args, banexg.ParamName, "ticker")
+	if name != "ticker" && name != "miniTicker" {
+		return "", nil, nil, errs.NewMsg(errs.CodeParamInvalid, "ParamName must be ticker or miniTicker")
+	}
+	if marketType == banexg.MarketOption {
+		if name != "ticker" {
+			return "", nil, nil, errs.NewMsg(errs.CodeParamInvalid, "option only support ticker stream")
+		}
+		if len(symbols) == 0 {
+			return "", nil, nil, errs.NewMsg(errs.CodeParamRequired, "symbols is required for option")
+		}
+	}
+	msgHash := marketType + "@" + name
+	client, err := e.GetWsClient(marketType, msgHash)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if len(symbols) == 0 {
+		// 全市场行情推送
+		symbols = []string{"!" + name + "@arr"}
+	}
+	err = e.WriteWSMsg(client, 0, isSub, symbols, func(m *banexg.Market, _ int) string {
+		return m.LowercaseID + "@" + name
+	}, nil)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	chanKey := client.Prefix(msgHash)
+	return chanKey, symbols, args, nil
+}
+
+/*
+WatchOpenInterest
+订阅期权持仓量推送，按标的和到期日推送该到期日全部期权合约的持仓量，每60秒一次。
+U本位/币本位合约无持仓量推送，请使用FetchOpenInterest轮询
+
+	:see: https://binance-docs.github.io/apidocs/voptions/en/#open-interest
+	:param []string symbols: unified option symbols, required
+	:param dict [params]: extra parameters
+*/
+func (e *Binance) WatchOpenInterest(symbols []string, params map[string]interface{}) (chan []*banexg.OpenInterest, *errs.Error) {
+	chanKey, args, err := e.prepareWatchOpenInterest(true, symbols, params)
+	if err != nil {
+		return nil, err
+	}
+	create := func(cap int) chan []*banexg.OpenInterest { return make(chan []*banexg.OpenInterest, cap) }
+	out := banexg.GetWsOutChan(e.Exchange, chanKey, create, args)
+	e.AddWsChanRefs(chanKey, symbols...)
+	e.DumpWS("WatchOpenInterest", symbols)
+	return out, nil
+}
+
+func (e *Binance) UnWatchOpenInterest(symbols []string, params map[string]interface{}) *errs.Error {
+	chanKey, _, err := e.prepareWatchOpenInterest(false, symbols, params)
+	if err != nil {
+		return err
+	}
+	e.DelWsChanRefs(chanKey, symbols...)
+	return nil
+}
+
+func (e *Binance) prepareWatchOpenInterest(isSub bool, symbols []string, params map[string]interface{}) (string, map[string]interface{}, *errs.Error) {
+	if len(symbols) == 0 {
+		return "", nil, errs.NewMsg(errs.CodeParamRequired, "symbols required for WatchOpenInterest")
+	}
+	args := utils.SafeParams(params)
+	marketType, _, err := e.LoadArgsMarketType(args, symbols...)
+	if err != nil {
+		return "", nil, err
+	}
+	if marketType != banexg.MarketOption {
+		return "", nil, errs.NewMsg(errs.CodeUnsupportMarket, "WatchOpenInterest support option only, current: %s", marketType)
+	}
+	// <underlyingAsset>@openInterest@<expirationDate>, 同一到期日的期权共用一个stream
+	var symStreams = make(map[string]string, len(symbols))
+	for _, symbol := range symbols {
+		market, err := e.GetMarket(symbol)
+		if err != nil {
+			return "", nil, err
+		}
+		key := getOptionOIStream(market.ID)
+		if key == "" {
+			return "", nil, errs.NewMsg(errs.CodeParamInvalid, "invalid option symbol: %s", market.ID)
+		}
+		symStreams[symbol] = key
+	}
+	msgHash := marketType + "@openInterest"
+	client, err := e.GetWsClient(marketType, msgHash)
+	if err != nil {
+		return "", nil, err
+	}
+	streams := e.updateOIStreamRefs(client.Prefix(""), isSub, symStreams)
+	if len(streams) > 0 {
+		err = e.WriteWSMsg(client, 0, isSub, streams, nil, nil)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	chanKey := client.Prefix(msgHash)
+	return chanKey, args, nil
+}
+
+/*
+updateOIStreamRefs
+记录每个持仓量stream被哪些symbol引用，返回需要订阅（首次引用）或取消订阅（无symbol引用）的stream
+*/
+func (e *Binance) updateOIStreamRefs(prefix string, isSub bool, symStreams map[string]string) []string {
+	var streams = make([]string, 0, len(symStreams))
+	e.oiRefLock.Lock()
+	defer e.oiRefLock.Unlock()
+	for symbol, key := range symStreams {
+		refKey := prefix + key
+		refs, ok := e.oiStreamRefs[refKey]
+		if isSub {
+			if !ok {
+				refs = make(map[string]bool)
+				e.oiStreamRefs[refKey] = refs
+				streams = append(streams, key)
+			}
+			refs[symbol] = true
+		} else if ok {
+			delete(refs, symbol)
+			if len(refs) == 0 {
+				delete(e.oiStreamRefs, refKey)
+				streams = append(streams, key)
+			}
+		}
+	}
+	return streams
+}
+
+/*
+getOptionOIStream
+ETH-221125-2700-C -> ETH@openInterest@221125
+*/
+func getOptionOIStream(marketId string) string {
+	parts := strings.Split(marketId, "-")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0] + "@openInterest@" + parts[1]
+}
+
+func (e *Binance) handleOpenInterest(client *banexg.WsClient, msgList []map[string]string) {
+	var res = make([]*banexg.OpenInterest, 0, len(msgList))
+	var streams = make(map[string]bool)
+	stamp := bntp.UTCStamp()
+	for _, msg := range msgList {
+		marketId, _ := utils.SafeMapVal(msg, "s", "")
+		symbol := e.SafeSymbol(marketId, "", client.MarketType)
+		if symbol == "" {
+			continue
+		}
+		streams[getOptionOIStream(marketId)] = true
+		evtTime, _ := utils.SafeMapVal(msg, "E", stamp)
+		amount, _ := utils.SafeMapVal(msg, "o", float64(0))
+		value, _ := utils.SafeMapVal(msg, "h", float64(0))
+		res = append(res, &banexg.OpenInterest{
+			Symbol:    symbol,
+			Amount:    amount,
+			Value:     value,
+			Timestamp: evtTime,
+			Info:      utils.ToStdMap(msg),
+		})
+	}
+	for key := range streams {
+		client.SetSubsKeyStamp(key, stamp)
+	}
+	if len(res) == 0 {
+		return
+	}
+	chanKey := client.Prefix(client.MarketType + "@openInterest")
+	banexg.WriteOutChan(e.Exchange, chanKey, res, true)
+}
+
+/*
+WatchLiquidations
+订阅强平订单推送，symbols为空时订阅全市场（!forceOrder@arr）。每个交易对每秒最多推送一条最新强平
+
+	:see: https://binance-docs.github.io/apidocs/futures/en/#liquidation-order-streams
+	:see: https://binance-docs.github.io/apidocs/futures/en/#all-market-liquidation-order-streams
+	:param []string symbols: unified linear/inverse symbols
+	:param dict [params]: extra parameters
+*/
+func (e *Binance) WatchLiquidations(symbols []string, params map[string]interface{}) (chan *banexg.Liquidation, *errs.Error) {
+	chanKey, refKeys, args, err := e.prepareWatchLiquidations(true, symbols, params)
+	if err != nil {
+		return nil, err
+	}
+	create := func(cap int) chan *banexg.Liquidation { return make(chan *banexg.Liquidation, cap) }
+	out := banexg.GetWsOutChan(e.Exchange, chanKey, create, args)
+	e.AddWsChanRefs(chanKey, refKeys...)
+	e.dumpWsSub("WatchLiquidations", symbols, params)
+	return out, nil
+}
+
+func (e *Binance) UnWatchLiquidations(symbols []string, params map[string]interface{}) *errs.Error {
+	chanKey, refKeys, _, err := e.prepareWatchLiquidations(false, symbols, params)
+	if err != nil {
+		return err
+	}
+	e.DelWsChanRefs(chanKey, refKeys...)
+	return nil
+}
+
+func (e *Binance) prepareWatchLiquidations(isSub bool, symbols []string, params map[string]interface{}) (string, []string, map[string]interface{}, *errs.Error) {
+	args := utils.SafeParams(params)
+	marketType, _, err := e.LoadArgsMarketType(args, symbols...)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if !e.IsContract(marketType) {
+		return "", nil, nil, errs.NewMsg(errs.CodeUnsupportMarket, "WatchLiquidations support linear/inverse, current: %s", marketType)
+	}
+	msgHash := marketType + "@forceOrder"
+	client, err := e.GetWsClient(marketType, msgHash)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if len(symbols) == 0 {
+		symbols = []string{"!forceOrder@arr"}
+	}
+	err = e.WriteWSMsg(client, 0, isSub, symbols, func(m *banexg.Market, _ int) string {
+		return m.LowercaseID + "@forceOrder"
+	}, nil)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	chanKey := client.Prefix(msgHash)
+	return chanKey, symbols, args, nil
+}
+
+func (e *Binance) handleLiquidation(client *banexg.WsClient, msg map[string]string) {
+	objText, _ := utils.SafeMapVal(msg, "o", "")
+	var obj = map[string]interface{}{}
+	err := utils.UnmarshalString(objText, &obj, utils.JsonNumStr)
+	if err != nil {
+		log.Error("unmarshal forceOrder fail", zap.String("o", objText), zap.Error(err))
+		return
+	}
+	od := utils.MapValStr(obj)
+	marketId, _ := utils.SafeMapVal(od, "s", "")
+	// 全市场和单个交易对推送格式相同，按当前订阅区分
+	subsKey := strings.ToLower(marketId) + "@forceOrder"
+	if !client.HasSubsKey(subsKey) {
+		subsKey = "!forceOrder@arr"
+	}
+	client.SetSubsKeyStamp(subsKey, bntp.UTCStamp())
+	symbol := e.SafeSymbol(marketId, "", client.MarketType)
+	if symbol == "" {
+		return
+	}
+	side, _ := utils.SafeMapVal(od, "S", "")
+	status, _ := utils.SafeMapVal(od, "X", "")
+	price, _ := utils.SafeMapVal(od, "p", float64(0))
+	average, _ := utils.SafeMapVal(od, "ap", float64(0))
+	amount, _ := utils.SafeMapVal(od, "q", float64(0))
+	filled, _ := utils.SafeMapVal(od, "z", float64(0))
+	stamp, _ := utils.SafeMapVal(od, "T", int64(0))
+	res := &banexg.Liquidation{
+		Symbol:    symbol,
+		Side:      strings.ToLower(side),
+		Price:     price,
+		Average:   average,
+		Amount:    amount,
+		Filled:    filled,
+		Status:    mapOrderStatus(status),
+		Timestamp: stamp,
+		Info:      obj,
+	}
+	chanKey := client.Prefix(client.MarketType + "@forceOrder")
+	banexg.WriteOutChan(e.Exchange, chanKey, res, true)
+}
+
+/*
+WatchBookTickers
+订阅最优挂单（买一卖一）推送。symbols为空时订阅全市场
+
+:param []string symbols: unified symbols
+:param dict [params]: extra parameters
+*/
+func (e *Binance) WatchBookTickers(symbols []string, params map[string]interface{}) (chan *banexg.BookTicker, *errs.Error) {
+	chanKey, refKeys, args, err := e.prepareWatchBookTickers(true, symbols, params)
+	if err != nil {
+		return nil, err
+	}
+	create := func(cap int) chan *banexg.BookTicker { return make(chan *banexg.BookTicker, cap) }
+	out := banexg.GetWsOutChan(e.Exchange, chanKey, create, args)
+	e.AddWsChanRefs(chanKey, refKeys...)
+	e.dumpWsSub("WatchBookTickers", symbols, params)
+	return out, nil
+}
+
+func (e *Binance) UnWatchBookTickers(symbols []string, params map[string]interface{}) *errs.Error {
+	chanKey, refKeys, _, err := e.prepareWatchBookTickers(false, symbols, params)
+	if err != nil {
+		return err
+	}
+	e.DelWsChanRefs(chanKey, refKeys...)
+	return nil
+}
+
+func (e *Binance) prepareWatchBookTickers(isSub bool, symbols []string, params map[string]interface{}) (string, []string, map[string]interface{}, *errs.Error) {
+	args := utils.SafeParams(params)
+	marketType, _, err := e.LoadArgsMarketType(args, symbols...)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if marketType == banexg.MarketOption {
+		return "", nil, nil, errs.NewMsg(errs.CodeUnsupportMarket, "WatchBookTickers support spot/linear/inverse, current: %s", marketType)
+	}
+	msgHash := marketType + "@bookTicker"
+	client, err := e.GetWsClient(marketType, msgHash)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if len(symbols) == 0 {
+		symbols = []string{"!bookTicker"}
+	}
+	err = e.WriteWSMsg(client, 0, isSub, symbols, func(m *banexg.Market, _ int) string {
+		return m.LowercaseID + "@bookTicker"
+	}, nil)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	chanKey := client.Prefix(msgHash)
+	return chanKey, symbols, args, nil
+}
+
+func (e *Binance) handleBookTicker(client *banexg.WsClient, msg map[string]string) {
+	marketId, _ := utils.SafeMapVal(msg, "s", "")
+	symbol := e.SafeSymbol(marketId, "", client.MarketType)
+	if symbol == "" {
+		return
+	}
+	stamp := bntp.UTCStamp()
+	// 全市场推送也是逐条发送，全市场和单个symbol可能同时订阅，已订阅的key都需更新
+	allKey := "!bookTicker"
+	if client.HasSubsKey(allKey) {
+		client.SetSubsKeyStamp(allKey, stamp)
+	}
+	symKey := strings.ToLower(marketId) + "@bookTicker"
+	if client.HasSubsKey(symKey) {
+		client.SetSubsKeyStamp(symKey, stamp)
+	}
+	updateId, _ := utils.SafeMapVal(msg, "u", int64(0))
+	if !e.checkBookTickerId(client.Prefix(symbol), updateId) {
+		// 同时订阅时同一更新会收到两次，跳过重复的
+		return
+	}
+	evtTime, _ := utils.SafeMapVal(msg, "E", stamp)
+	bid, _ := utils.SafeMapVal(msg, "b", float64(0))
+	bidQty, _ := utils.SafeMapVal(msg, "B", float64(0))
+	ask, _ := utils.SafeMapVal(msg, "a", float64(0))
+	askQty, _ := utils.SafeMapVal(msg, "A", float64(0))
+	res := &banexg.BookTicker{
+		Symbol:    symbol,
+		Bid:       bid,
+		BidQty:    bidQty,
+		Ask:       ask,
+		AskQty:    askQty,
+		UpdateID:  updateId,
+		EventTime: evtTime,
+	}
+	chanKey := client.Prefix(client.MarketType + "@bookTicker")
+	banexg.WriteOutChan(e.Exchange, chanKey, res, true)
+}
+
+/*
+checkBookTickerId
+记录每个symbol最新的bookTicker updateId，重复或过期的推送返回false
+*/
+func (e *Binance) checkBookTickerId(key string, updateId int64) bool {
+	if updateId <= 0 {
+		return true
+	}
+	e.bookTickerLock.Lock()
+	defer e.bookTickerLock.Unlock()
+	if updateId <= e.bookTickerIds[key] {
+		return false
+	}
+	e.bookTickerIds[key] = updateId
+	return true
+}
+
+/*
+handleTickers
+处理24hrTicker/24hrMiniTicker消息，更新Tickers缓存并推送到输出通道
+*/
+func (e *Binance) handleTickers(client *banexg.WsClient, msgList []map[string]string, isArray bool) {
+	if len(msgList) == 0 {
+		return
+	}
+	event, _ := utils.SafeMapVal(msgList[0], "e", "24hrTicker")
+	name := "ticker"
+	if event == "24hrMiniTicker" {
+		name = "miniTicker"
+	}
+	stamp := bntp.UTCStamp()
+	var res = make([]*banexg.Ticker, 0, len(msgList))
+	e.TickerLock.Lock()
+	data, ok := e.Tickers[client.MarketType]
+	if !ok {
+		data = map[string]*banexg.Ticker{}
+		e.Tickers[client.MarketType] = data
+	}
+	for _, msg := range msgList {
+		marketId, _ := utils.SafeMapVal(msg, "s", "")
+		symbol := e.SafeSymbol(marketId, "", client.MarketType)
+		if symbol == "" {
+			continue
+		}
+		ticker := parseWsTicker(msg)
+		ticker.Symbol = symbol
+		data[symbol] = ticker
+		res = append(res, ticker)
+		if !isArray {
+			client.SetSubsKeyStamp(strings.ToLower(marketId)+"@"+name, stamp)
+		}
+	}
+	e.TickerLock.Unlock()
+	if isArray {
+		client.SetSubsKeyStamp("!"+name+"@arr", stamp)
+	}
+	if len(res) == 0 {
+		return
+	}
+	chanKey := client.Prefix(client.MarketType + "@" + name)
+	banexg.WriteOutChan(e.Exchange, chanKey, res, true)
+}
 
+/*
+parseWsTicker
+解析ws推送的ticker，兼容现货/U本位/币本位/期权，以及miniTicker
+*/
+func parseWsTicker(msg map[string]string) *banexg.Ticker {
+	evtTime, _ := utils.SafeMapVal(msg, "E", int64(0))
+	last, _ := utils.SafeMapVal(msg, "c", float64(0))
+	open, _ := utils.SafeMapVal(msg, "o", float64(0))
+	high, _ := utils.SafeMapVal(msg, "h", float64(0))
+	low, _ := utils.SafeMapVal(msg, "l", float64(0))
+	change, _ := utils.SafeMapVal(msg, "p", float64(0))
+	percent, _ := utils.SafeMapVal(msg, "P", float64(0))
+	vwap, _ := utils.SafeMapVal(msg, "w", float64(0))
+	baseVol, _ := utils.SafeMapVal(msg, "v", float64(0))
+	quoteVol, _ := utils.SafeMapVal(msg, "q", float64(0))
+	bid, _ := utils.SafeMapVal(msg, "b", float64(0))
+	bidVol, _ := utils.SafeMapVal(msg, "B", float64(0))
+	ask, _ := utils.SafeMapVal(msg, "a", float64(0))
+	askVol, _ := utils.SafeMapVal(msg, "A", float64(0))
+	prevClose, _ := utils.SafeMapVal(msg, "x", float64(0))
+	var markPrice float64
+	if _, ok := msg["mp"]; ok {
+		// 期权：成交量为V，成交额为A，买卖一价为bo/ao
+		baseVol, _ = utils.SafeMapVal(msg, "V", float64(0))
+		quoteVol, _ = utils.SafeMapVal(msg, "A", float64(0))
+		bid, _ = utils.SafeMapVal(msg, "bo", float64(0))
+		bidVol, _ = utils.SafeMapVal(msg, "bq", float64(0))
+		ask, _ = utils.SafeMapVal(msg, "ao", float64(0))
+		askVol, _ = utils.SafeMapVal(msg, "aq", float64(0))
+		markPrice, _ = utils.SafeMapVal(msg, "mp", float64(0))
+	}
+	if change == 0 && last != 0 && open != 0 {
+		// miniTicker无涨跌字段，自行计算
+		change = last - open
+		percent = change / open * 100
+	}
+	return &banexg.Ticker{
+		TimeStamp:     evtTime,
+		Bid:           bid,
+		BidVolume:     bidVol,
+		Ask:           ask,
+		AskVolume:     askVol,
+		High:          high,
+		Low:           low,
+		Open:          open,
+		Close:         last,
+		Last:          last,
+		Change:        change,
+		Percentage:    percent,
+		Vwap:          vwap,
+		BaseVolume:    baseVol,
+		QuoteVolume:   quoteVol,
+		PreviousClose: prevClose,
+		MarkPrice:     markPrice,
+		Info:          utils.ToStdMap(msg),
+	}
 }
 
 /*
@@ -682,11 +1326,13 @@ func (e *Binance) handleAccountUpdate(client *banexg.WsClient, msg map[string]st
 		acc.MarBalances[client.MarketType] = balances
 	}
 	acc.LockBalance.Unlock()
+	// 统一账户的余额共用，持仓按fs区分市场
+	marketType := e.wsMarketType(client, msg)
 	acc.LockPos.Lock()
-	positions, ok := acc.MarPositions[client.MarketType]
+	positions, ok := acc.MarPositions[marketType]
 	if !ok {
 		positions = make([]*banexg.Position, 0)
-		acc.MarPositions[client.MarketType] = positions
+		acc.MarPositions[marketType] = positions
 	}
 	acc.LockPos.Unlock()
 	posMap := make(map[string]*banexg.Position)
@@ -695,7 +1341,7 @@ func (e *Binance) handleAccountUpdate(client *banexg.WsClient, msg map[string]st
 	}
 	evtTime, _ := utils.SafeMapVal(msg, "E", int64(0))
 	balances.TimeStamp = evtTime
-	if client.MarketType != banexg.MarketOption {
+	if marketType != banexg.MarketOption {
 		// linear/inverse
 		text, _ := msg["a"]
 		var Data = struct {
@@ -723,7 +1369,7 @@ func (e *Binance) handleAccountUpdate(client *banexg.WsClient, msg map[string]st
 		var posList []map[string]interface{}
 		posList = utils.GetMapVal(raw, "P", posList)
 		for i, pos := range Data.Positions {
-			symbol := e.SafeSymbol(pos.Symbol, "", client.MarketType)
+			symbol := e.SafeSymbol(pos.Symbol, "", marketType)
 			if symbol == "" {
 				continue
 			}
@@ -753,7 +1399,7 @@ func (e *Binance) handleAccountUpdate(client *banexg.WsClient, msg map[string]st
 			positions = append(positions, p)
 		}
 		acc.LockPos.Lock()
-		acc.MarPositions[client.MarketType] = positions
+		acc.MarPositions[marketType] = positions
 		acc.LockPos.Unlock()
 		updBalance = len(Data.Balances) > 0
 		updPosition = len(Data.Positions) > 0
@@ -766,13 +1412,14 @@ func (e *Binance) handleAccountUpdate(client *banexg.WsClient, msg map[string]st
 	}
 	if updPosition {
 		acc.LockPos.Lock()
-		positions = acc.MarPositions[client.MarketType]
+		positions = acc.MarPositions[marketType]
 		acc.LockPos.Unlock()
 		banexg.WriteOutChan(e.Exchange, client.Prefix("positions"), positions, true)
 	}
 }
 func (e *Binance) handleOrderUpdate(client *banexg.WsClient, msg map[string]string) {
 	event, _ := utils.SafeMapVal(msg, "e", "")
+	marketType := e.wsMarketType(client, msg)
 	if event == "ORDER_TRADE_UPDATE" {
 		objText, _ := utils.SafeMapVal(msg, "o", "")
 		var obj = map[string]interface{}{}
@@ -784,7 +1431,7 @@ func (e *Binance) handleOrderUpdate(client *banexg.WsClient, msg map[string]stri
 		msg = utils.MapValStr(obj)
 	}
 	trade := parseMyTrade(msg)
-	market := e.GetMarketById(trade.Symbol, client.MarketType)
+	market := e.GetMarketById(trade.Symbol, marketType)
 	if market == nil {
 		log.Error("no market found for my trade", zap.String("symbol", trade.Symbol))
 		return
@@ -800,6 +1447,17 @@ func (e *Binance) handleOrderUpdate(client *banexg.WsClient, msg map[string]stri
 func (e *Binance) handleAccountConfigUpdate(client *banexg.WsClient, msg map[string]string) {
 	acText, ok := msg["ac"]
 	if !ok || acText == "" {
+		if aiText, ok := msg["ai"]; ok && aiText != "" {
+			// 联合保证金模式变化
+			var data = make(map[string]interface{})
+			err_ := utils.UnmarshalString(aiText, &data, utils.JsonNumAuto)
+			if err_ != nil {
+				log.Error("unmarshal AccountConfigUpdate fail", zap.String("ai", aiText), zap.Error(err_))
+				return
+			}
+			item := &banexg.AccountConfig{MultiAssets: utils.GetMapVal(data, "j", false)}
+			banexg.WriteOutChan(e.Exchange, client.Prefix("accConfig"), item, false)
+		}
 		return
 	}
 	var data = make(map[string]interface{})
@@ -810,7 +1468,7 @@ func (e *Binance) handleAccountConfigUpdate(client *banexg.WsClient, msg map[str
 	}
 	marketId := utils.GetMapVal(data, "s", "")
 	leverage := int(utils.GetMapVal(data, "l", int64(0)))
-	market := e.GetMarketById(marketId, client.MarketType)
+	market := e.GetMarketById(marketId, e.wsMarketType(client, msg))
 	if market == nil {
 		log.Error("no market found for AccountConfigUpdate", zap.String("symbol", marketId))
 		return
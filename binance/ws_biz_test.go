@@ -8,6 +8,7 @@ import (
 	"github.com/banbox/banexg/bntp"
 	"github.com/banbox/banexg/errs"
 	"github.com/banbox/banexg/log"
+	"github.com/banbox/banexg/utils"
 	"github.com/h2non/gock"
 	"go.uber.org/zap"
 	"os"
@@ -162,6 +163,57 @@ mainFor:
 	}
 }
 
+func TestWatchTickers(t *testing.T) {
+	exg := getBinance(nil)
+	exg.MarketType = banexg.MarketLinear
+	symbols := []string{"BTC/USDT:USDT", "ETH/USDT:USDT"}
+	out, err := exg.WatchTickers(symbols, nil)
+	// 监听所有币种:
+	// out, err := exg.WatchTickers(nil, nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("start watching tickers")
+mainFor:
+	for {
+		select {
+		case data, ok := <-out:
+			if !ok {
+				log.Info("read out chan fail, break")
+				break mainFor
+			}
+			timeStr := bntp.Now().Format("2006-01-02 15:04:05")
+			builder := strings.Builder{}
+			builder.WriteString("============== " + timeStr + " ===============\n")
+			for _, tk := range data {
+				builder.WriteString(fmt.Sprintf("%s: %v %v\n", tk.Symbol, tk.Last, tk.QuoteVolume))
+			}
+			fmt.Print(builder.String())
+		}
+	}
+}
+
+func TestWatchBookTickers(t *testing.T) {
+	exg := getBinance(nil)
+	symbols := []string{"BTC/USDT", "ETH/USDT"}
+	out, err := exg.WatchBookTickers(symbols, nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("start watching book tickers")
+	count := 0
+	for data := range out {
+		fmt.Printf("%s bid: %v(%v) ask: %v(%v)\n", data.Symbol, data.Bid, data.BidQty, data.Ask, data.AskQty)
+		count += 1
+		if count == 20 {
+			err = exg.UnWatchBookTickers(symbols, nil)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
 func TestWsDump(t *testing.T) {
 	exg := getBinance(map[string]interface{}{
 		banexg.OptDumpPath: getWsDumpPath(),
@@ -265,3 +317,97 @@ func TestDumpCompress(t *testing.T) {
 		}
 	}
 }
+
+func TestWatchLiquidations(t *testing.T) {
+	exg := getBinance(nil)
+	exg.MarketType = banexg.MarketLinear
+	// 全市场强平推送
+	out, err := exg.WatchLiquidations(nil, nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("start watching liquidations")
+	for data := range out {
+		data.Info = nil
+		fmt.Println(utils.MarshalString(data))
+	}
+}
+
+func TestWatchFundingRates(t *testing.T) {
+	exg := getBinance(nil)
+	exg.MarketType = banexg.MarketLinear
+	symbols := []string{"BTC/USDT:USDT", "ETH/USDT:USDT"}
+	out, err := exg.WatchFundingRates(symbols, nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("start watching funding rates")
+	for data := range out {
+		for _, it := range data {
+			it.Info = nil
+		}
+		fmt.Println(utils.MarshalString(data))
+	}
+}
+
+func TestUpdateOIStreamRefs(t *testing.T) {
+	exg := &Binance{oiStreamRefs: map[string]map[string]bool{}}
+	call := "ETH/USDT:USDT-221125-2700-C"
+	put := "ETH/USDT:USDT-221125-2700-P"
+	stream := "ETH@openInterest@221125"
+	res := exg.updateOIStreamRefs("p#", true, map[string]string{call: stream, put: stream})
+	if len(res) != 1 || res[0] != stream {
+		t.Fatalf("first watch should subscribe %s once, got %v", stream, res)
+	}
+	if res = exg.updateOIStreamRefs("p#", true, map[string]string{call: stream}); len(res) != 0 {
+		t.Errorf("stream already subscribed, got %v", res)
+	}
+	if res = exg.updateOIStreamRefs("p#", false, map[string]string{call: stream}); len(res) != 0 {
+		t.Errorf("%s still watched, should not unsubscribe, got %v", put, res)
+	}
+	if res = exg.updateOIStreamRefs("p#", false, map[string]string{put: stream}); len(res) != 1 || res[0] != stream {
+		t.Errorf("no symbol maps to %s, should unsubscribe, got %v", stream, res)
+	}
+	if res = exg.updateOIStreamRefs("p#", false, map[string]string{put: stream}); len(res) != 0 {
+		t.Errorf("unsubscribe twice, got %v", res)
+	}
+}
+
+func TestCheckBookTickerId(t *testing.T) {
+	exg := &Binance{bookTickerIds: map[string]int64{}}
+	key := "p#BTC/USDT"
+	if !exg.checkBookTickerId(key, 100) {
+		t.Fatalf("first update should pass")
+	}
+	if exg.checkBookTickerId(key, 100) {
+		t.Errorf("duplicate update from another stream should be dropped")
+	}
+	if exg.checkBookTickerId(key, 99) {
+		t.Errorf("stale update should be dropped")
+	}
+	if !exg.checkBookTickerId("p#ETH/USDT", 50) || !exg.checkBookTickerId(key, 101) {
+		t.Errorf("new update should pass")
+	}
+	if !exg.checkBookTickerId(key, 0) {
+		t.Errorf("update without id should pass")
+	}
+}
+
+func TestParseWsSubLog(t *testing.T) {
+	item := &banexg.WsLog{Name: "WatchLiquidations", Content: `{"symbols":[],"market":"inverse"}`}
+	symbols, params, err := parseWsSubLog(item)
+	if err != nil || len(symbols) != 0 || params[banexg.ParamMarket] != banexg.MarketInverse {
+		t.Errorf("parse sub log fail: %v %v %v", symbols, params, err)
+	}
+	item.Content = `{"symbols":["BTC/USDT"],"market":"spot","name":"miniTicker"}`
+	symbols, params, err = parseWsSubLog(item)
+	if err != nil || len(symbols) != 1 || params[banexg.ParamName] != "miniTicker" {
+		t.Errorf("parse sub log with name fail: %v %v %v", symbols, params, err)
+	}
+	// 兼容只记录标的的旧格式
+	item.Content = `["BTC/USDT:USDT"]`
+	symbols, params, err = parseWsSubLog(item)
+	if err != nil || len(symbols) != 1 || len(params) != 0 {
+		t.Errorf("parse old sub log fail: %v %v %v", symbols, params, err)
+	}
+}
@@ -119,6 +119,7 @@ func (e *Exchange) Init() *errs.Error {
 	e.WsChanRefs = map[string]map[string]struct{}{}
 	e.OrderBooks = map[string]*OrderBook{}
 	e.MarkPrices = map[string]map[string]float64{}
+	e.Tickers = map[string]map[string]*Ticker{}
 	e.KeyTimeStamps = map[string]int64{}
 	e.ExgInfo.Min1mHole = 1
 	return nil
@@ -188,6 +189,84 @@ func (e *Exchange) SafeCurrencyCode(currId string) string {
 	return e.SafeCurrency(currId).Code
 }
 
+/*
+GetCurrencyID
+get currency id of exchange by unified code, return code if not found
+根据统一币种代码获取交易所的币种ID，找不到时返回code
+*/
+func (e *Exchange) GetCurrencyID(code string) string {
+	if curr, ok := e.CurrenciesByCode[code]; ok && curr.ID != "" {
+		return curr.ID
+	}
+	return code
+}
+
+/*
+GetChainNetwork
+get currency and the chain network matched by id or network name (case-insensitive).
+network is nil if network is empty.
+根据币种代码和网络ID或名称（忽略大小写）获取币种和链网络，network为空时返回的ChainNetwork为nil
+*/
+func (e *Exchange) GetChainNetwork(code, network string) (*Currency, *ChainNetwork, *errs.Error) {
+	curr, ok := e.CurrenciesByCode[code]
+	if !ok || curr == nil {
+		return nil, nil, errs.NewMsg(errs.CodeParamInvalid, "unknown currency: %s", code)
+	}
+	if network == "" {
+		return curr, nil, nil
+	}
+	var names = make([]string, 0, len(curr.Networks))
+	for _, n := range curr.Networks {
+		if strings.EqualFold(n.ID, network) || strings.EqualFold(n.Network, network) {
+			return curr, n, nil
+		}
+		names = append(names, n.Network)
+	}
+	return curr, nil, errs.NewMsg(errs.CodeParamInvalid, "network %s not found for %s, valid: %v", network, code, names)
+}
+
+/*
+CheckWithdraw
+check Withdraw flag and Limits.Withdraw of currency/network before sending withdraw request.
+the default network is checked when network is empty
+发送提现请求前，检查币种/网络的Withdraw标记和Limits.Withdraw限制；network为空时检查默认网络
+*/
+func (e *Exchange) CheckWithdraw(code, network string, amount float64) (*ChainNetwork, *errs.Error) {
+	if amount <= 0 {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "withdraw amount must > 0")
+	}
+	curr, net, err := e.GetChainNetwork(code, network)
+	if err != nil {
+		return nil, err
+	}
+	if net == nil {
+		net = curr.DefaultNetwork()
+	}
+	var limits *CodeLimits
+	if net != nil {
+		network = net.Network
+		if !net.Withdraw {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "withdraw disabled for %s on %s", code, net.Network)
+		}
+		limits = net.Limits
+	} else {
+		if !curr.Withdraw {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "withdraw disabled for %s", code)
+		}
+		limits = curr.Limits
+	}
+	if limits != nil && limits.Withdraw != nil {
+		rg := limits.Withdraw
+		if rg.Min > 0 && amount < rg.Min {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "withdraw amount %v < min %v for %s %s", amount, rg.Min, code, network)
+		}
+		if rg.Max > 0 && amount > rg.Max {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "withdraw amount %v > max %v for %s %s", amount, rg.Max, code, network)
+		}
+	}
+	return net, nil
+}
+
 func doLoadMarkets(e *Exchange, params map[string]interface{}) {
 	var markets MarketMap
 	var currencies CurrencyMap
@@ -619,6 +698,10 @@ func (e *Exchange) FetchOHLCV(symbol, timeframe string, since int64, limit int,
 	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
 }
 
+func (e *Exchange) FetchTrades(symbol string, since int64, limit int, params map[string]interface{}) ([]*Trade, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
 func (e *Exchange) FetchBalance(params map[string]interface{}) (*Balances, *errs.Error) {
 	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
 }
@@ -655,6 +738,10 @@ func (e *Exchange) FetchOpenOrders(symbol string, since int64, limit int, params
 	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
 }
 
+func (e *Exchange) FetchMyTrades(symbol string, since int64, limit int, params map[string]interface{}) ([]*MyTrade, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
 func (e *Exchange) FetchIncomeHistory(inType string, symbol string, since int64, limit int, params map[string]interface{}) ([]*Income, *errs.Error) {
 	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
 }
@@ -671,6 +758,26 @@ func (e *Exchange) FetchFundingRateHistory(symbol string, since int64, limit int
 	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
 }
 
+func (e *Exchange) FetchOpenInterest(symbol string, params map[string]interface{}) (*OpenInterest, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) FetchOpenInterestHistory(symbol, period string, since int64, limit int, params map[string]interface{}) ([]*OpenInterest, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) FetchLongShortRatioHistory(symbol, period string, since int64, limit int, params map[string]interface{}) ([]*LongShortRatio, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) FetchTakerVolumeHistory(symbol, period string, since int64, limit int, params map[string]interface{}) ([]*TakerVolume, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) FetchLiquidations(symbol string, since int64, limit int, params map[string]interface{}) ([]*Liquidation, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
 func (e *Exchange) FetchLastPrices(symbols []string, params map[string]interface{}) ([]*LastPrice, *errs.Error) {
 	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
 }
@@ -691,10 +798,106 @@ func (e *Exchange) CancelOrder(id string, symbol string, params map[string]inter
 	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
 }
 
+func (e *Exchange) CreateOrderBy(args *OrderArgs) (*Order, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) CreateOrders(args []*OrderArgs, params map[string]interface{}) ([]*OrderRes, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) CancelOrders(symbol string, ids []string, params map[string]interface{}) ([]*OrderRes, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) CancelAllOrders(symbol string, params map[string]interface{}) ([]*Order, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) CreateOrderList(kind string, legs []*OrderArgs, params map[string]interface{}) (*OrderList, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) CancelOrderList(id string, symbol string, params map[string]interface{}) (*OrderList, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) FetchOrderList(id string, symbol string, params map[string]interface{}) (*OrderList, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) Transfer(code string, amount float64, fromAccount, toAccount string, params map[string]interface{}) (*TransferResult, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) FetchTransfers(code string, since int64, limit int, params map[string]interface{}) ([]*TransferResult, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) FetchDepositAddress(code, network string, params map[string]interface{}) (*DepositAddress, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) FetchDeposits(code string, since int64, limit int, params map[string]interface{}) ([]*Transaction, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) FetchWithdrawals(code string, since int64, limit int, params map[string]interface{}) ([]*Transaction, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) Withdraw(code string, amount float64, address, tag, network string, params map[string]interface{}) (*Transaction, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
 func (e *Exchange) SetLeverage(leverage float64, symbol string, params map[string]interface{}) (map[string]interface{}, *errs.Error) {
 	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
 }
 
+func (e *Exchange) SetMarginMode(mode, symbol string, params map[string]interface{}) (map[string]interface{}, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) SetPositionMode(hedged bool, params map[string]interface{}) (map[string]interface{}, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) FetchAccountConfig(symbols []string, params map[string]interface{}) ([]*AccountConfig, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) FetchAccountStatus(params map[string]interface{}) (*AccountStatus, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) AddMargin(symbol string, amount float64, params map[string]interface{}) (*MarginAdjustment, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) ReduceMargin(symbol string, amount float64, params map[string]interface{}) (*MarginAdjustment, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) FetchMarginAdjustmentHistory(symbol string, since int64, limit int, params map[string]interface{}) ([]*MarginAdjustment, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) BorrowMargin(code string, amount float64, symbol string, params map[string]interface{}) (*MarginLoan, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) RepayMargin(code string, amount float64, symbol string, params map[string]interface{}) (*MarginLoan, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) FetchBorrowRates(codes []string, params map[string]interface{}) ([]*BorrowRate, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) FetchBorrowInterest(code, symbol string, since int64, limit int, params map[string]interface{}) ([]*BorrowInterest, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
 func (e *Exchange) LoadLeverageBrackets(reload bool, params map[string]interface{}) *errs.Error {
 	return errs.NewMsg(errs.CodeNotImplement, "method not implement")
 }
@@ -748,6 +951,46 @@ func (e *Exchange) UnWatchMarkPrices(symbols []string, params map[string]interfa
 	return errs.NewMsg(errs.CodeNotImplement, "method not implement")
 }
 
+func (e *Exchange) WatchFundingRates(symbols []string, params map[string]interface{}) (chan []*FundingRateCur, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) UnWatchFundingRates(symbols []string, params map[string]interface{}) *errs.Error {
+	return errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) WatchTickers(symbols []string, params map[string]interface{}) (chan []*Ticker, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) UnWatchTickers(symbols []string, params map[string]interface{}) *errs.Error {
+	return errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) WatchBookTickers(symbols []string, params map[string]interface{}) (chan *BookTicker, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) UnWatchBookTickers(symbols []string, params map[string]interface{}) *errs.Error {
+	return errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) WatchOpenInterest(symbols []string, params map[string]interface{}) (chan []*OpenInterest, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) UnWatchOpenInterest(symbols []string, params map[string]interface{}) *errs.Error {
+	return errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) WatchLiquidations(symbols []string, params map[string]interface{}) (chan *Liquidation, *errs.Error) {
+	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
+func (e *Exchange) UnWatchLiquidations(symbols []string, params map[string]interface{}) *errs.Error {
+	return errs.NewMsg(errs.CodeNotImplement, "method not implement")
+}
+
 func (e *Exchange) WatchTrades(symbols []string, params map[string]interface{}) (chan *Trade, *errs.Error) {
 	return nil, errs.NewMsg(errs.CodeNotImplement, "method not implement")
 }
@@ -1080,18 +1323,20 @@ func (e *Exchange) RequestApi(ctx context.Context, cacheKey string, api *Entry,
 		err := errs.NewMsg(errs.CodeNetDisable, fmt.Sprintf("net disabled for %v, fail: %v", e.Name, api.Url))
 		return &HttpRes{Error: err}
 	}
-	// Traffic control, block if concurrency is full
-	// 流量控制，如果并发已满则阻塞
-	sem := GetHostFlowChan(api.RawHost)
-	sem <- struct{}{}
-	defer func() {
-		<-sem
-	}()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// Traffic control, block if concurrency is full or ctx done
+	// 流量控制，如果并发已满则阻塞，ctx结束时退出
+	release, err2 := AcquireHostFlow(ctx, api.RawHost)
+	if err2 != nil {
+		return &HttpRes{Error: err2}
+	}
+	defer release()
 	// Check if 429 or 418 appears and wait
 	// 检查是否出现429或418需要等待
-	waitMS := GetHostRetryWait(api.RawHost, true)
-	if waitMS > 0 {
-		time.Sleep(time.Millisecond * time.Duration(waitMS))
+	if err2 = WaitHostRetry(ctx, api.RawHost); err2 != nil {
+		return &HttpRes{Error: err2}
 	}
 	if e.EnableRateLimit == BoolTrue {
 		e.rateM.Lock()
@@ -1099,10 +1344,13 @@ func (e *Exchange) RequestApi(ctx context.Context, cacheKey string, api *Entry,
 		cost := e.CalcRateLimiterCost(api, params)
 		sleepMS := int64(math.Round(float64(e.RateLimit) * cost))
 		if elapsed < sleepMS {
-			time.Sleep(time.Duration(sleepMS-elapsed) * time.Millisecond)
+			err2 = SleepCtx(ctx, time.Duration(sleepMS-elapsed)*time.Millisecond)
 		}
 		e.lastRequestMS = e.MilliSeconds()
 		e.rateM.Unlock()
+		if err2 != nil {
+			return &HttpRes{Error: err2}
+		}
 	}
 	sign := e.Sign(api, params)
 	if sign.Error != nil {
@@ -1130,6 +1378,9 @@ func (e *Exchange) RequestApi(ctx context.Context, cacheKey string, api *Entry,
 	}
 	rsp, err := e.HttpClient.Do(req)
 	if err != nil {
+		if err2 = CtxError(ctx); err2 != nil {
+			return &HttpRes{Url: sign.Url, AccName: sign.AccName, Error: err2}
+		}
 		return &HttpRes{Url: sign.Url, AccName: sign.AccName, Error: errs.New(errs.CodeNetFail, err)}
 	}
 	defer rsp.Body.Close()
@@ -1211,6 +1462,12 @@ func (e *Exchange) RequestApiRetryAdv(ctx context.Context, endpoint string, para
 		log.Panic("invalid api", zap.String("endpoint", endpoint))
 		return &HttpRes{Error: errs.NewMsg(errs.CodeApiNotSupport, "api not support")}
 	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// ctx in params is preferred, it should never be sent to exchange
+	// 优先使用params中的ctx，不可发送到交易所
+	ctx, params = popParamCtx(ctx, params)
 	debug := utils.PopMapVal(params, ParamDebug, false)
 	// 检查是否有缓存
 	var cacheKey string
@@ -1252,10 +1509,16 @@ func (e *Exchange) RequestApiRetryAdv(ctx context.Context, endpoint string, para
 	var sleep = 0
 	for i := 0; i < tryNum; i++ {
 		if sleep > 0 {
-			time.Sleep(time.Second * time.Duration(sleep))
+			if err := SleepCtx(ctx, time.Second*time.Duration(sleep)); err != nil {
+				rsp = &HttpRes{Url: rsp.Url, AccName: rsp.AccName, Error: err}
+				break
+			}
 			sleep = 0
 		}
 		rsp = e.RequestApi(ctx, cacheKey, api, params, writeCache, debug)
+		if rsp.Error != nil && rsp.Error.Code == errs.CodeCanceled {
+			break
+		}
 		if rsp.Error != nil {
 			if rsp.Error.Code == errs.CodeNetFail {
 				// 网络错误等待3s重试
@@ -69,3 +69,38 @@ func TestCalcFee(t *testing.T) {
 		t.Errorf("maker fee: %v", fee)
 	}
 }
+
+func TestCheckWithdraw(t *testing.T) {
+	e := Exchange{ExgInfo: &ExgInfo{
+		CurrenciesByCode: CurrencyMap{
+			"USDT": {ID: "USDT", Code: "USDT", Withdraw: true, Networks: []*ChainNetwork{
+				{ID: "TRX", Network: "TRX", Withdraw: true, IsDefault: true, Limits: &CodeLimits{Withdraw: &LimitRange{Min: 10, Max: 1000}}},
+				{ID: "ETH", Network: "ETH", Withdraw: false},
+			}},
+		},
+	}}
+	cases := []struct {
+		code    string
+		network string
+		amount  float64
+		ok      bool
+	}{
+		{"USDT", "TRX", 100, true},
+		{"USDT", "trx", 100, true},
+		{"USDT", "", 100, true},
+		{"USDT", "", 5, false},
+		{"USDT", "", 5000, false},
+		{"USDT", "TRX", 5, false},
+		{"USDT", "TRX", 5000, false},
+		{"USDT", "ETH", 100, false},
+		{"USDT", "SOL", 100, false},
+		{"BTC", "", 1, false},
+		{"USDT", "TRX", 0, false},
+	}
+	for _, c := range cases {
+		_, err := e.CheckWithdraw(c.code, c.network, c.amount)
+		if (err == nil) != c.ok {
+			t.Errorf("CheckWithdraw %s %s %v, expect ok: %v, got: %v", c.code, c.network, c.amount, c.ok, err)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package bybit
 
 import (
+	"github.com/banbox/banexg"
 	"github.com/banbox/banexg/log"
 	"github.com/banbox/banexg/utils"
 )
@@ -22,3 +23,35 @@ func getBybit(param map[string]interface{}) *Bybit {
 	}
 	return exg
 }
+
+var (
+	btcLinear = &banexg.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Type: banexg.MarketLinear, Base: "BTC",
+		Quote: "USDT", Settle: "USDT", Contract: true, Linear: true, Swap: true, ContractSize: 1}
+	btcInverse = &banexg.Market{ID: "BTCUSD", Symbol: "BTC/USD:BTC", Type: banexg.MarketInverse, Base: "BTC",
+		Quote: "USD", Settle: "BTC", Contract: true, Inverse: true, Swap: true, ContractSize: 1}
+	btcSpot = &banexg.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Type: banexg.MarketSpot, Base: "BTC",
+		Quote: "USDT", Spot: true}
+)
+
+/*
+getOfflineBybit
+不访问网络的交易所对象，用于解析和推送处理的单元测试
+*/
+func getOfflineBybit(markets ...*banexg.Market) *Bybit {
+	log.Setup("info", "")
+	exg, err := New(map[string]interface{}{})
+	if err != nil {
+		panic(err)
+	}
+	exg.Markets = banexg.MarketMap{}
+	exg.MarketsById = banexg.MarketArrMap{}
+	for _, m := range markets {
+		exg.Markets[m.Symbol] = m
+		exg.MarketsById[m.ID] = append(exg.MarketsById[m.ID], m)
+	}
+	return exg
+}
+
+func getOfflineClient(exg *Bybit, marketType string) *banexg.WsClient {
+	return &banexg.WsClient{Exg: exg.Exchange, URL: "wss://stream.bybit.com/v5/private", MarketType: marketType}
+}
@@ -23,8 +23,14 @@ func (e *Bybit) Init() *errs.Error {
 	if e.CareMarkets == nil || len(e.CareMarkets) == 0 {
 		e.CareMarkets = DefCareMarkets
 	}
+	e.wsPingClients = map[*banexg.WsClient]bool{}
+	e.wsAuthConns = map[string]bool{}
+	e.wsAuthJobs = map[string]*wsAuthJob{}
+	e.bookSeqs = map[string]int64{}
+	e.lvgLoaded = map[string]bool{}
 	e.ExgInfo.NoHoliday = true
 	e.ExgInfo.FullDay = true
+	e.regReplayHandles()
 	return nil
 }
 
@@ -134,6 +140,7 @@ func requestRetry[T any](e *Bybit, api string, params map[string]interface{}, tr
 	}
 	if rsp.RetCode != 0 {
 		res.Error = errs.NewMsg(errs.CodeRunTime, "[%v] %s", rsp.RetCode, rsp.RetMsg)
+		res.Error.BizCode = rsp.RetCode
 	} else {
 		res.Result = rsp.Result
 		e.CacheApiRes(api, res_)
@@ -141,6 +148,23 @@ func requestRetry[T any](e *Bybit, api string, params map[string]interface{}, tr
 	return res
 }
 
+/*
+getMarketCategory
+返回v5接口的category参数：spot/linear/inverse/option
+*/
+func getMarketCategory(marketType string) string {
+	switch marketType {
+	case banexg.MarketOption:
+		return "option"
+	case banexg.MarketLinear:
+		return "linear"
+	case banexg.MarketInverse:
+		return "inverse"
+	default:
+		return "spot"
+	}
+}
+
 func makeFetchCurr(e *Bybit) banexg.FuncFetchCurr {
 	return func(params map[string]interface{}) (banexg.CurrencyMap, *errs.Error) {
 		tryNum := e.GetRetryNum("FetchCurr", 1)
@@ -718,3 +742,133 @@ func (e *Bybit) getFundRateHis(marketType string, until int64, args map[string]i
 	hasMore := until > 0 && len(rsp.Result.List) == maxFundRateBatch && lastMS+interval < until
 	return list, hasMore, nil
 }
+
+/*
+FetchFundingRate
+fetch the current funding rate. rate, mark price and next funding time come from tickers,
+the last settled rate comes from funding rate history
+
+	:see: https://bybit-exchange.github.io/docs/v5/market/tickers
+	:see: https://bybit-exchange.github.io/docs/v5/market/history-fund-rate
+	:param str symbol: unified market symbol
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+*/
+func (e *Bybit) FetchFundingRate(symbol string, params map[string]interface{}) (*banexg.FundingRateCur, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	if market.Spot || market.Option {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "only linear/inverse market support")
+	}
+	args["symbol"] = market.ID
+	items, err := e.fetchFundingRates(market.Type, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, errs.NewMsg(errs.CodeNoMarketForPair, "no funding rate for %s", symbol)
+	}
+	res := items[0]
+	// 保留account、ctx等通用参数，移除仅用于tickers接口的参数
+	hisArgs := utils.SafeParams(params)
+	delete(hisArgs, "baseCoin")
+	delete(hisArgs, "expDate")
+	his, err := e.FetchFundingRateHistory(symbol, 0, 1, hisArgs)
+	if err != nil {
+		return nil, err
+	}
+	if len(his) > 0 {
+		// 历史按时间倒序返回，第一个为最近一次结算
+		res.PrevFundingRate = his[0].FundingRate
+		res.PrevFundingTimestamp = his[0].Timestamp
+		if res.NextFundingTimestamp > res.PrevFundingTimestamp {
+			intvSecs := int((res.NextFundingTimestamp - res.PrevFundingTimestamp) / 1000)
+			res.Interval = utils.SecsToTF(intvSecs)
+		}
+	}
+	return res, nil
+}
+
+/*
+FetchFundingRates
+fetch the current funding rates of linear/inverse markets from tickers
+
+	:see: https://bybit-exchange.github.io/docs/v5/market/tickers
+	:param []string [symbols]: unified market symbols, return all markets if empty
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+*/
+func (e *Bybit) FetchFundingRates(symbols []string, params map[string]interface{}) ([]*banexg.FundingRateCur, *errs.Error) {
+	args := utils.SafeParams(params)
+	marketType, _, err := e.LoadArgsMarketType(args, symbols...)
+	if err != nil {
+		return nil, err
+	}
+	if marketType != banexg.MarketLinear && marketType != banexg.MarketInverse {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "unsupport market: %v", marketType)
+	}
+	if len(symbols) == 1 {
+		market, err := e.GetMarket(symbols[0])
+		if err != nil {
+			return nil, err
+		}
+		args["symbol"] = market.ID
+	}
+	items, err := e.fetchFundingRates(marketType, args)
+	if err != nil || len(symbols) <= 1 {
+		return items, err
+	}
+	var list = make([]*banexg.FundingRateCur, 0, len(symbols))
+	for _, it := range items {
+		if utils.ArrContains(symbols, it.Symbol) {
+			list = append(list, it)
+		}
+	}
+	return list, nil
+}
+
+func (e *Bybit) fetchFundingRates(marketType string, args map[string]interface{}) ([]*banexg.FundingRateCur, *errs.Error) {
+	args["category"] = getMarketCategory(marketType)
+	tryNum := e.GetRetryNum("FetchFundingRates", 1)
+	rsp := requestRetry[struct {
+		Category string                   `json:"category"`
+		List     []map[string]interface{} `json:"list"`
+	}](e, MethodPublicGetV5MarketTickers, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	items := rsp.Result.List
+	var arr = make([]*FutureTicker, 0, len(items))
+	err := utils.DecodeStructMap(items, &arr, "json")
+	if err != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err)
+	}
+	stamp := e.MilliSeconds()
+	var list = make([]*banexg.FundingRateCur, 0, len(arr))
+	for i, it := range arr {
+		item := it.ToStdFundRate(e, marketType, items[i])
+		if item.Symbol == "" {
+			continue
+		}
+		item.Timestamp = stamp
+		list = append(list, item)
+	}
+	return list, nil
+}
+
+func (t *FutureTicker) ToStdFundRate(e *Bybit, marketType string, info map[string]interface{}) *banexg.FundingRateCur {
+	code := e.SafeSymbol(t.Symbol, "", marketType)
+	rate, _ := strconv.ParseFloat(t.FundingRate, 64)
+	markPrice, _ := strconv.ParseFloat(t.MarkPrice, 64)
+	indexPrice, _ := strconv.ParseFloat(t.IndexPrice, 64)
+	nextTime, _ := strconv.ParseInt(t.NextFundingTime, 10, 64)
+	return &banexg.FundingRateCur{
+		Symbol:               code,
+		FundingRate:          rate,
+		MarkPrice:            markPrice,
+		IndexPrice:           indexPrice,
+		FundingTimestamp:     nextTime,
+		NextFundingTimestamp: nextTime,
+		Info:                 info,
+	}
+}
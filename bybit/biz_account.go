@@ -0,0 +1,282 @@
+package bybit
+
+import (
+	"maps"
+	"strconv"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+const (
+	codeMarginNotModified  = 110026 // Cross/isolated margin mode is not modified
+	codePosModeNotModified = 110025 // Position mode is not modified
+	codeLvgNotModified     = 110043 // Set leverage has not been modified
+)
+
+var accMarginModes = map[string]string{
+	banexg.MarginCross:    "REGULAR_MARGIN",
+	banexg.MarginIsolated: "ISOLATED_MARGIN",
+	"portfolio":           "PORTFOLIO_MARGIN",
+}
+
+/*
+SetMarginMode
+set margin mode to 'cross' or 'isolated'.
+switch for single symbol is only supported by classic account, set account level margin mode if symbol is empty
+
+	:see: https://bybit-exchange.github.io/docs/v5/position/cross-isolate
+	:see: https://bybit-exchange.github.io/docs/v5/account/set-margin-mode
+	:param str mode: MarginCross or MarginIsolated, or portfolio for account level
+	:param str [symbol]: unified market symbol
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param float [params.leverage]: leverage for switch symbol margin mode, use current leverage if empty
+	:returns dict: response from the exchange
+*/
+func (e *Bybit) SetMarginMode(mode, symbol string, params map[string]interface{}) (map[string]interface{}, *errs.Error) {
+	if symbol == "" {
+		accMode, ok := accMarginModes[mode]
+		if !ok {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "mode should be cross/isolated/portfolio, current: %s", mode)
+		}
+		args := utils.SafeParams(params)
+		args["setMarginMode"] = accMode
+		tryNum := e.GetRetryNum("SetMarginMode", 1)
+		rsp := requestRetry[map[string]interface{}](e, MethodPrivatePostV5AccountSetMarginMode, args, tryNum)
+		return parseModeRsp(rsp, codeMarginNotModified)
+	}
+	var tradeMode int
+	if mode == banexg.MarginIsolated {
+		tradeMode = 1
+	} else if mode != banexg.MarginCross {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "mode should be cross or isolated, current: %s", mode)
+	}
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	if !market.Linear && !market.Inverse {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "%v SetMarginMode supports linear and inverse contracts only", e.Name)
+	}
+	leverage := utils.PopMapVal(args, "leverage", float64(0))
+	if leverage <= 0 {
+		items, err := e.FetchAccountConfig([]string{symbol}, maps.Clone(args))
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			return nil, errs.NewMsg(errs.CodeParamRequired, "leverage is required for %v.SetMarginMode", e.Name)
+		}
+		leverage = float64(items[0].Leverage)
+	}
+	levText := strconv.FormatFloat(leverage, 'f', -1, 64)
+	args["category"] = getMarketCategory(market.Type)
+	args["symbol"] = market.ID
+	args["tradeMode"] = tradeMode
+	args["buyLeverage"] = levText
+	args["sellLeverage"] = levText
+	tryNum := e.GetRetryNum("SetMarginMode", 1)
+	rsp := requestRetry[map[string]interface{}](e, MethodPrivatePostV5PositionSwitchIsolated, args, tryNum)
+	return parseModeRsp(rsp, codeMarginNotModified)
+}
+
+/*
+SetPositionMode
+set hedged to true or false for linear/inverse contracts
+
+	:see: https://bybit-exchange.github.io/docs/v5/position/position-mode
+	:param bool hedged: set to true to use both side position mode
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.symbol]: unified market symbol to switch
+	:param str [params.coin]: settle coin to switch all symbols, default USDT for linear
+	:returns dict: response from the exchange
+*/
+func (e *Bybit) SetPositionMode(hedged bool, params map[string]interface{}) (map[string]interface{}, *errs.Error) {
+	args := utils.SafeParams(params)
+	symbol := utils.PopMapVal(args, banexg.ParamSymbol, "")
+	var marketType string
+	if symbol != "" {
+		var market *banexg.Market
+		var err *errs.Error
+		args, market, err = e.LoadArgsMarket(symbol, args)
+		if err != nil {
+			return nil, err
+		}
+		args["symbol"] = market.ID
+		marketType = market.Type
+	} else {
+		var err *errs.Error
+		marketType, _, err = e.LoadArgsMarketType(args)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := args["coin"]; !ok {
+			if marketType != banexg.MarketLinear {
+				return nil, errs.NewMsg(errs.CodeParamRequired, "symbol or coin is required for %v.SetPositionMode", e.Name)
+			}
+			args["coin"] = "USDT"
+		}
+	}
+	if marketType != banexg.MarketLinear && marketType != banexg.MarketInverse {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "%v SetPositionMode supports linear and inverse contracts only", e.Name)
+	}
+	args["category"] = getMarketCategory(marketType)
+	if hedged {
+		args["mode"] = 3
+	} else {
+		args["mode"] = 0
+	}
+	tryNum := e.GetRetryNum("SetPositionMode", 1)
+	rsp := requestRetry[map[string]interface{}](e, MethodPrivatePostV5PositionSwitchMode, args, tryNum)
+	return parseModeRsp(rsp, codePosModeNotModified)
+}
+
+/*
+parseModeRsp
+解析切换模式的返回结果，当前已是目标模式时视为成功
+*/
+func parseModeRsp(rsp *banexg.ApiRes[map[string]interface{}], noChangeCode int) (map[string]interface{}, *errs.Error) {
+	if rsp.Error != nil {
+		if rsp.Error.BizCode == noChangeCode {
+			return map[string]interface{}{"retCode": noChangeCode, "retMsg": rsp.Error.Message()}, nil
+		}
+		return nil, rsp.Error
+	}
+	if rsp.Result == nil {
+		return map[string]interface{}{}, nil
+	}
+	return rsp.Result, nil
+}
+
+/*
+FetchAccountConfig
+fetch leverage, margin mode and position mode of contract symbols.
+only symbols with open positions are returned if symbols is empty
+
+	:see: https://bybit-exchange.github.io/docs/v5/position
+	:see: https://bybit-exchange.github.io/docs/v5/account/account-info
+	:param str[] [symbols]: unified market symbols
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.settleCoin]: settle coin when symbols is empty, default USDT for linear
+	:returns AccountConfig[]: a list of account config structures
+*/
+func (e *Bybit) FetchAccountConfig(symbols []string, params map[string]interface{}) ([]*banexg.AccountConfig, *errs.Error) {
+	args := utils.SafeParams(params)
+	marketType, _, err := e.LoadArgsMarketType(args, symbols...)
+	if err != nil {
+		return nil, err
+	}
+	if marketType != banexg.MarketLinear && marketType != banexg.MarketInverse {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "FetchAccountConfig support linear/inverse contracts only")
+	}
+	tryNum := e.GetRetryNum("FetchAccountConfig", 1)
+	accRsp := requestRetry[AccountInfo](e, MethodPrivateGetV5AccountInfo, maps.Clone(args), tryNum)
+	if accRsp.Error != nil {
+		return nil, accRsp.Error
+	}
+	accInfo := accRsp.Result
+	positions, _, err := e.fetchSymbolsPositions(marketType, symbols, args)
+	if err != nil {
+		return nil, err
+	}
+	// 统一账户的逐仓模式为账户级别；非逐仓的统一账户为多资产保证金
+	accIsolated := accInfo.MarginMode == "ISOLATED_MARGIN"
+	multiAssets := accInfo.UnifiedMarginStatus >= 3 && !accIsolated
+	var res = make([]*banexg.AccountConfig, 0, len(positions))
+	var cfgMap = make(map[string]*banexg.AccountConfig)
+	for _, p := range positions {
+		symbol := e.SafeSymbol(p.Symbol, "", marketType)
+		if symbol == "" {
+			continue
+		}
+		cfg, ok := cfgMap[symbol]
+		if !ok {
+			leverage, _ := strconv.ParseFloat(p.Leverage, 64)
+			marginMode := banexg.MarginCross
+			if accIsolated || p.TradeMode == 1 {
+				marginMode = banexg.MarginIsolated
+			}
+			cfg = &banexg.AccountConfig{
+				Symbol:      symbol,
+				Leverage:    int(leverage),
+				MarginMode:  marginMode,
+				MultiAssets: multiAssets,
+			}
+			cfgMap[symbol] = cfg
+			res = append(res, cfg)
+		}
+		if p.PositionIdx != 0 {
+			cfg.Hedged = true
+		}
+	}
+	return res, nil
+}
+
+/*
+fetchSymbolsPositions
+request positions of the given symbols, or all positions of settleCoin when symbols is empty
+*/
+func (e *Bybit) fetchSymbolsPositions(marketType string, symbols []string, args map[string]interface{}) ([]*Position, []map[string]interface{}, *errs.Error) {
+	args["category"] = getMarketCategory(marketType)
+	if len(symbols) == 0 {
+		if _, ok := args["settleCoin"]; !ok {
+			if marketType != banexg.MarketLinear {
+				return nil, nil, errs.NewMsg(errs.CodeParamRequired, "symbols or settleCoin is required for inverse")
+			}
+			args["settleCoin"] = "USDT"
+		}
+		return e.fetchPositionList(args)
+	}
+	var positions []*Position
+	var infos []map[string]interface{}
+	for _, symbol := range symbols {
+		market, err := e.GetMarket(symbol)
+		if err != nil {
+			return nil, nil, err
+		}
+		curArgs := maps.Clone(args)
+		curArgs["symbol"] = market.ID
+		items, itemInfos, err := e.fetchPositionList(curArgs)
+		if err != nil {
+			return nil, nil, err
+		}
+		positions = append(positions, items...)
+		infos = append(infos, itemInfos...)
+	}
+	return positions, infos, nil
+}
+
+/*
+fetchPositionList
+request v5/position/list with all pages, args should contain category and symbol/settleCoin
+*/
+func (e *Bybit) fetchPositionList(args map[string]interface{}) ([]*Position, []map[string]interface{}, *errs.Error) {
+	args["limit"] = 200
+	tryNum := e.GetRetryNum("FetchPositions", 1)
+	var items = make([]*Position, 0)
+	var infos = make([]map[string]interface{}, 0)
+	for {
+		rsp := requestRetry[struct {
+			Category       string                   `json:"category"`
+			List           []map[string]interface{} `json:"list"`
+			NextPageCursor string                   `json:"nextPageCursor"`
+		}](e, MethodPrivateGetV5PositionList, maps.Clone(args), tryNum)
+		if rsp.Error != nil {
+			return nil, nil, rsp.Error
+		}
+		var arr = rsp.Result.List
+		var page = make([]*Position, 0, len(arr))
+		err_ := utils.DecodeStructMap(arr, &page, "json")
+		if err_ != nil {
+			return nil, nil, errs.New(errs.CodeUnmarshalFail, err_)
+		}
+		items = append(items, page...)
+		infos = append(infos, arr...)
+		if rsp.Result.NextPageCursor == "" || len(arr) == 0 {
+			break
+		}
+		args["cursor"] = rsp.Result.NextPageCursor
+	}
+	return items, infos, nil
+}
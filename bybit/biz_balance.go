@@ -0,0 +1,149 @@
+package bybit
+
+import (
+	"strconv"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+/*
+FetchBalance
+query for balance and get the amount of funds available for trading or funds locked in orders
+
+	:see: https://bybit-exchange.github.io/docs/v5/account/wallet-balance
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.accountType]: UNIFIED(default), CONTRACT or SPOT for classic account
+	:param str [params.coin]: coin names split by comma, return all coins if empty
+	:returns dict: a `balance structure <https://docs.ccxt.com/#/?id=balance-structure>`
+*/
+func (e *Bybit) FetchBalance(params map[string]interface{}) (*banexg.Balances, *errs.Error) {
+	args := utils.SafeParams(params)
+	_, _, err := e.LoadArgsMarketType(args)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := args["accountType"]; !ok {
+		args["accountType"] = "UNIFIED"
+	}
+	tryNum := e.GetRetryNum("FetchBalance", 1)
+	rsp := requestRetry[struct {
+		List []map[string]interface{} `json:"list"`
+	}](e, MethodPrivateGetV5AccountWalletBalance, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	return parseWalletBalance(e, rsp.Result.List)
+}
+
+/*
+parseWalletBalance
+解析钱包余额；冻结部分包含现货挂单、委托单和仓位占用的初始保证金，负债为借款加应计利息。
+可作为保证金的币种记录在Info["collateral"]中
+*/
+func parseWalletBalance(e *Bybit, list []map[string]interface{}) (*banexg.Balances, *errs.Error) {
+	var data = make([]*WalletBalance, 0, len(list))
+	err_ := utils.DecodeStructMap(list, &data, "json")
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var collateral = make(map[string]bool)
+	var result = banexg.Balances{
+		Info:   map[string]interface{}{"list": list, "collateral": collateral},
+		Assets: map[string]*banexg.Asset{},
+	}
+	for _, acc := range data {
+		for _, c := range acc.Coin {
+			total, _ := strconv.ParseFloat(c.WalletBalance, 64)
+			locked, _ := strconv.ParseFloat(c.Locked, 64)
+			orderIM, _ := strconv.ParseFloat(c.TotalOrderIM, 64)
+			posIM, _ := strconv.ParseFloat(c.TotalPositionIM, 64)
+			borrowed, _ := strconv.ParseFloat(c.BorrowAmount, 64)
+			interest, _ := strconv.ParseFloat(c.AccruedInterest, 64)
+			unp, _ := strconv.ParseFloat(c.UnrealisedPnl, 64)
+			used := locked + orderIM + posIM
+			free := total - used
+			if free < 0 {
+				free = 0
+			}
+			code := e.SafeCurrencyCode(c.Coin)
+			asset, ok := result.Assets[code]
+			if !ok {
+				asset = &banexg.Asset{Code: code}
+			}
+			asset.Free += free
+			asset.Used += used
+			asset.Total += total
+			asset.Debt += borrowed + interest
+			asset.UPol += unp
+			if asset.IsEmpty() {
+				continue
+			}
+			result.Assets[code] = asset
+			if c.MarginCollateral && c.CollateralSwitch {
+				collateral[code] = true
+			}
+		}
+	}
+	return result.Init(), nil
+}
+
+/*
+FetchPositions
+fetch all open positions
+
+	:see: https://bybit-exchange.github.io/docs/v5/position
+	:param str[] [symbols]: list of unified market symbols
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.settleCoin]: settle coin when symbols is empty, default USDT for linear
+	:returns Position[]: a list of position structures
+*/
+func (e *Bybit) FetchPositions(symbols []string, params map[string]interface{}) ([]*banexg.Position, *errs.Error) {
+	args := utils.SafeParams(params)
+	marketType, _, err := e.LoadArgsMarketType(args, symbols...)
+	if err != nil {
+		return nil, err
+	}
+	if marketType != banexg.MarketLinear && marketType != banexg.MarketInverse {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "FetchPositions support linear/inverse contracts only")
+	}
+	accName := e.GetAccName(args)
+	items, infos, err := e.fetchSymbolsPositions(marketType, symbols, args)
+	if err != nil {
+		return nil, err
+	}
+	var leverages = make(map[string]int)
+	var result = make([]*banexg.Position, 0, len(items))
+	for i, p := range items {
+		market := e.GetMarketById(p.Symbol, marketType)
+		if market == nil {
+			continue
+		}
+		leverage, _ := strconv.ParseFloat(p.Leverage, 64)
+		if leverage > 0 {
+			leverages[market.Symbol] = int(leverage)
+		}
+		size, _ := strconv.ParseFloat(p.Size, 64)
+		if size == 0 {
+			// 无持仓时也会返回空记录，用于查询杠杆
+			continue
+		}
+		result = append(result, p.ToStdPos(market, infos[i]))
+	}
+	e.setAccLeverages(accName, leverages)
+	return result, nil
+}
+
+/*
+FetchAccountPositions
+bybit has no separate account endpoint for positions, same as FetchPositions
+
+	:see: https://bybit-exchange.github.io/docs/v5/position
+	:param str[] [symbols]: list of unified market symbols
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:returns Position[]: a list of position structures
+*/
+func (e *Bybit) FetchAccountPositions(symbols []string, params map[string]interface{}) ([]*banexg.Position, *errs.Error) {
+	return e.FetchPositions(symbols, params)
+}
@@ -0,0 +1,42 @@
+package bybit
+
+import (
+	"testing"
+
+	"github.com/banbox/banexg/utils"
+)
+
+func TestParseWalletBalance(t *testing.T) {
+	exg := getOfflineBybit()
+	text := `[{"accountType":"UNIFIED","totalEquity":"1000","coin":[
+{"coin":"USDT","walletBalance":"1000","locked":"50","totalOrderIM":"20","totalPositionIM":"30","borrowAmount":"0",
+"accruedInterest":"0","unrealisedPnl":"12.5","marginCollateral":true,"collateralSwitch":true},
+{"coin":"BTC","walletBalance":"-0.1","locked":"0","totalOrderIM":"0","totalPositionIM":"0","borrowAmount":"0.1",
+"accruedInterest":"0.001","unrealisedPnl":"0","marginCollateral":true,"collateralSwitch":false},
+{"coin":"ETH","walletBalance":"0","locked":"0","borrowAmount":"0","accruedInterest":"0","unrealisedPnl":"0",
+"marginCollateral":true,"collateralSwitch":true}
+]}]`
+	var list = make([]map[string]interface{}, 0)
+	if err := utils.UnmarshalString(text, &list, utils.JsonNumDefault); err != nil {
+		t.Fatalf("unmarshal wallet fail: %v", err)
+	}
+	res, err := parseWalletBalance(exg, list)
+	if err != nil {
+		t.Fatalf("parse wallet fail: %v", err)
+	}
+	usdt, ok := res.Assets["USDT"]
+	if !ok || usdt.Total != 1000 || usdt.Used != 100 || usdt.Free != 900 || usdt.UPol != 12.5 || usdt.Debt != 0 {
+		t.Errorf("bad USDT asset: %+v", usdt)
+	}
+	btc, ok := res.Assets["BTC"]
+	if !ok || btc.Debt != 0.101 || btc.Free != 0 || btc.Total != -0.1 {
+		t.Errorf("bad BTC asset with debt: %+v", btc)
+	}
+	if _, ok = res.Assets["ETH"]; ok {
+		t.Errorf("empty ETH asset should be skipped")
+	}
+	collateral, _ := res.Info["collateral"].(map[string]bool)
+	if !collateral["USDT"] || collateral["BTC"] || collateral["ETH"] {
+		t.Errorf("collateral should only contain enabled coins with balance, got %v", collateral)
+	}
+}
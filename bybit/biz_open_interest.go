@@ -0,0 +1,111 @@
+package bybit
+
+import (
+	"strconv"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+var oiPeriodMap = map[string]string{
+	"5m":  "5min",
+	"15m": "15min",
+	"30m": "30min",
+	"1h":  "1h",
+	"4h":  "4h",
+	"1d":  "1d",
+}
+
+/*
+FetchOpenInterest
+retrieves the latest open interest of a contract trading pair
+
+	:see: https://bybit-exchange.github.io/docs/v5/market/open-interest
+	:param str symbol: unified CCXT market symbol
+	:param dict [params]: exchange specific parameters
+	:returns OpenInterest: an open interest structure
+*/
+func (e *Bybit) FetchOpenInterest(symbol string, params map[string]interface{}) (*banexg.OpenInterest, *errs.Error) {
+	items, err := e.FetchOpenInterestHistory(symbol, "5m", 0, 1, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, errs.NewMsg(errs.CodeInvalidResponse, "no open interest for %s", symbol)
+	}
+	return items[len(items)-1], nil
+}
+
+/*
+FetchOpenInterestHistory
+retrieves the open interest history of a linear/inverse contract
+
+	:see: https://bybit-exchange.github.io/docs/v5/market/open-interest
+	:param str symbol: unified CCXT market symbol
+	:param str period: "5m","15m","30m","1h","4h", or "1d"
+	:param int [since]: the time(ms) of the earliest record to retrieve as a unix timestamp
+	:param int [limit]: default 50, max 200
+	:param dict [params]: exchange specific parameters
+	:param int [params.until]: the time(ms) of the latest record to retrieve as a unix timestamp
+	:returns OpenInterest[]: an array of open interest structure, sorted by time asc
+*/
+func (e *Bybit) FetchOpenInterestHistory(symbol, period string, since int64, limit int, params map[string]interface{}) ([]*banexg.OpenInterest, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	if !market.Linear && !market.Inverse {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "FetchOpenInterestHistory support linear/inverse only")
+	}
+	if period == "" {
+		period = "5m"
+	}
+	intv, ok := oiPeriodMap[period]
+	if !ok {
+		return nil, errs.NewMsg(errs.CodeInvalidTimeFrame, "unsupported period for open interest: %s", period)
+	}
+	args["category"] = getMarketCategory(market.Type)
+	args["symbol"] = market.ID
+	args["intervalTime"] = intv
+	if since > 0 {
+		args["startTime"] = since
+	}
+	until := utils.PopMapVal(args, banexg.ParamUntil, int64(0))
+	if until > 0 {
+		args["endTime"] = until
+	}
+	if limit > 0 {
+		args["limit"] = min(limit, 200)
+	}
+	tryNum := e.GetRetryNum("FetchOpenInterestHistory", 1)
+	rsp := requestRetry[struct {
+		Symbol         string                   `json:"symbol"`
+		Category       string                   `json:"category"`
+		List           []map[string]interface{} `json:"list"`
+		NextPageCursor string                   `json:"nextPageCursor"`
+	}](e, MethodPublicGetV5MarketOpenInterest, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var arr = rsp.Result.List
+	var items = make([]*OpenInterest, 0, len(arr))
+	err_ := utils.DecodeStructMap(arr, &items, "json")
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var res = make([]*banexg.OpenInterest, 0, len(items))
+	// 接口返回按时间倒序，这里转为正序
+	for i := len(items) - 1; i >= 0; i-- {
+		it := items[i]
+		amount, _ := strconv.ParseFloat(it.OpenInterest, 64)
+		stamp, _ := strconv.ParseInt(it.Timestamp, 10, 64)
+		res = append(res, &banexg.OpenInterest{
+			Symbol:    market.Symbol,
+			Amount:    amount,
+			Timestamp: stamp,
+			Info:      arr[i],
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,610 @@
+package bybit
+
+import (
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+var orderStatusMap = map[string]string{
+	"New":                     banexg.OdStatusOpen,
+	"Untriggered":             banexg.OdStatusOpen,
+	"Triggered":               banexg.OdStatusOpen,
+	"PartiallyFilled":         banexg.OdStatusPartFilled,
+	"Filled":                  banexg.OdStatusFilled,
+	"Cancelled":               banexg.OdStatusCanceled,
+	"PartiallyFilledCanceled": banexg.OdStatusCanceled,
+	"Deactivated":             banexg.OdStatusCanceled,
+	"Rejected":                banexg.OdStatusRejected,
+}
+
+func mapOrderStatus(status string) string {
+	if val, ok := orderStatusMap[status]; ok {
+		return val
+	}
+	return strings.ToLower(status)
+}
+
+/*
+CreateOrderBy
+使用类型化参数下单，提交前检查参数有效性及互斥选项
+*/
+func (e *Bybit) CreateOrderBy(args *banexg.OrderArgs) (*banexg.Order, *errs.Error) {
+	return banexg.CreateOrderBy(e, args)
+}
+
+/*
+CreateOrder
+create a trade order
+
+	:see: https://bybit-exchange.github.io/docs/v5/order/create-order
+	:param str symbol: unified symbol of the market to create an order in
+	:param str odType: market/limit/limit_maker, or stop/take profit types with params.triggerPrice
+	:param str side: buy or sell
+	:param float amount: how much of currency you want to trade in units of base currency
+	:param float [price]: the price at which the order is to be fullfilled, ignored in market orders
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param float [params.stopLossPrice]: trigger price of stop loss conditional order
+	:param float [params.takeProfitPrice]: trigger price of take profit conditional order
+	:param float [params.triggerPrice]: trigger price of conditional order, params.triggerDirection(1: rise, 2: fall) is required for contracts
+	:param float [params.cost]: *spot market buy only* the quote quantity to spend
+	:param str [params.positionSide]: long/short in hedge mode
+	:param str [params.marginMode]: set to trade spot with margin
+	:returns Order: the order structure, only ids are returned by exchange
+*/
+func (e *Bybit) CreateOrder(symbol, odType, side string, amount, price float64, params map[string]interface{}) (*banexg.Order, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	if side != banexg.OdSideBuy && side != banexg.OdSideSell {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "side must be buy or sell, current: %s", side)
+	}
+	marginMode := utils.PopMapVal(args, banexg.ParamMarginMode, "")
+	clientOrderId := utils.PopMapVal(args, banexg.ParamClientOrderId, "")
+	postOnly := utils.PopMapVal(args, banexg.ParamPostOnly, false)
+	timeInForce := utils.PopMapVal(args, banexg.ParamTimeInForce, "")
+	reduceOnly := utils.PopMapVal(args, banexg.ParamReduceOnly, false)
+	closePosition := utils.PopMapVal(args, banexg.ParamClosePosition, false)
+	posSide := strings.ToLower(utils.PopMapVal(args, banexg.ParamPositionSide, ""))
+	triggerPrice := utils.PopMapVal(args, banexg.ParamTriggerPrice, float64(0))
+	stopLossPrice := utils.PopMapVal(args, banexg.ParamStopLossPrice, float64(0))
+	takeProfitPrice := utils.PopMapVal(args, banexg.ParamTakeProfitPrice, float64(0))
+	cost := utils.PopMapVal(args, banexg.ParamCost, float64(0))
+	if _, ok := args[banexg.ParamCallbackRate]; ok {
+		return nil, errs.NewMsg(errs.CodeNotSupport, "trailing stop order is not supported for %s", e.Name)
+	}
+	if _, ok := args[banexg.ParamTrailingDelta]; ok {
+		return nil, errs.NewMsg(errs.CodeNotSupport, "trailing stop order is not supported for %s", e.Name)
+	}
+	isMarket := false
+	switch odType {
+	case banexg.OdTypeMarket, banexg.OdTypeStopMarket, banexg.OdTypeTakeProfitMarket:
+		isMarket = true
+	case banexg.OdTypeStopLoss, banexg.OdTypeTakeProfit:
+		// 和币安保持一致：现货为市价，合约为限价
+		isMarket = market.Spot
+	case banexg.OdTypeLimit, banexg.OdTypeStop, banexg.OdTypeStopLossLimit, banexg.OdTypeTakeProfitLimit:
+	case banexg.OdTypeLimitMaker:
+		postOnly = true
+	default:
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "invalid order type %s for %s", odType, e.Name)
+	}
+	if strings.HasPrefix(odType, "stop") && stopLossPrice == 0 {
+		stopLossPrice = triggerPrice
+	} else if strings.HasPrefix(odType, "take_profit") && takeProfitPrice == 0 {
+		takeProfitPrice = triggerPrice
+	}
+	if timeInForce == banexg.TimeInForceGTX || timeInForce == banexg.TimeInForcePO {
+		postOnly = true
+	}
+	if postOnly {
+		if isMarket {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "market orders cannot be postOnly")
+		} else if timeInForce == banexg.TimeInForceIOC || timeInForce == banexg.TimeInForceFOK {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "postOnly orders cannot have timeInForce: %s", timeInForce)
+		}
+		timeInForce = "PostOnly"
+	} else if !isMarket && timeInForce == "" {
+		timeInForce = e.TimeInForce
+	}
+	args["category"] = getMarketCategory(market.Type)
+	args["symbol"] = market.ID
+	if side == banexg.OdSideBuy {
+		args["side"] = "Buy"
+	} else {
+		args["side"] = "Sell"
+	}
+	if isMarket {
+		args["orderType"] = "Market"
+	} else {
+		args["orderType"] = "Limit"
+		if price <= 0 {
+			return nil, errs.NewMsg(errs.CodeParamRequired, "price is required for %s order", odType)
+		}
+		precPrice, err := e.PrecPrice(market, price)
+		if err != nil {
+			return nil, err
+		}
+		args["price"] = strconv.FormatFloat(precPrice, 'f', -1, 64)
+	}
+	if timeInForce != "" {
+		args["timeInForce"] = timeInForce
+	}
+	if cost > 0 {
+		if !market.Spot || !isMarket || side != banexg.OdSideBuy {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "cost is only valid for spot market buy orders")
+		}
+		precCost, err := e.PrecCost(market, cost)
+		if err != nil {
+			return nil, err
+		}
+		args["marketUnit"] = "quoteCoin"
+		args["qty"] = strconv.FormatFloat(precCost, 'f', -1, 64)
+	} else if closePosition {
+		if !market.Contract {
+			return nil, errs.NewMsg(errs.CodeParamInvalid, "closePosition is only valid for contracts")
+		}
+		args["qty"] = "0"
+		args["closeOnTrigger"] = true
+		reduceOnly = true
+	} else {
+		precAmt, err := e.PrecAmount(market, amount)
+		if err != nil {
+			return nil, err
+		}
+		if market.Spot && isMarket {
+			// 现货市价买单默认按报价币计量，这里统一按基础币数量
+			args["marketUnit"] = "baseCoin"
+		}
+		args["qty"] = strconv.FormatFloat(precAmt, 'f', -1, 64)
+	}
+	var trigPrice float64
+	var direction int
+	if stopLossPrice > 0 {
+		trigPrice, direction = stopLossPrice, 2
+		if side == banexg.OdSideBuy {
+			direction = 1
+		}
+	} else if takeProfitPrice > 0 {
+		trigPrice, direction = takeProfitPrice, 1
+		if side == banexg.OdSideBuy {
+			direction = 2
+		}
+	} else if triggerPrice > 0 {
+		trigPrice = triggerPrice
+		direction = utils.PopMapVal(args, "triggerDirection", 0)
+		if direction == 0 && market.Contract {
+			return nil, errs.NewMsg(errs.CodeParamRequired, "triggerDirection(1: rise, 2: fall) is required for triggerPrice")
+		}
+	}
+	if trigPrice == 0 && odType != banexg.OdTypeMarket && odType != banexg.OdTypeLimit && odType != banexg.OdTypeLimitMaker {
+		return nil, errs.NewMsg(errs.CodeParamRequired, "triggerPrice is required for %s order", odType)
+	}
+	if trigPrice > 0 {
+		precTrig, err := e.PrecPrice(market, trigPrice)
+		if err != nil {
+			return nil, err
+		}
+		args["triggerPrice"] = strconv.FormatFloat(precTrig, 'f', -1, 64)
+		if market.Spot {
+			if _, ok := args["orderFilter"]; !ok {
+				args["orderFilter"] = "StopOrder"
+			}
+		} else {
+			args["triggerDirection"] = direction
+		}
+	}
+	if market.Contract {
+		if reduceOnly {
+			args["reduceOnly"] = true
+		}
+		if !market.Option {
+			args["positionIdx"] = getPositionIdx(posSide)
+		}
+	} else if market.Type == banexg.MarketMargin || marginMode != "" {
+		args["isLeverage"] = 1
+	}
+	if clientOrderId == "" {
+		brokerId := utils.GetMapVal(params, banexg.ParamBrokerId, "")
+		clientOrderId = brokerId + utils.UUID(22)
+	}
+	args["orderLinkId"] = clientOrderId
+	tryNum := e.GetRetryNum("CreateOrder", 1)
+	rsp := requestRetry[map[string]interface{}](e, MethodPrivatePostV5OrderCreate, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	res, err := parseOrderIdRes(rsp.Result)
+	if err != nil {
+		return nil, err
+	}
+	stamp := e.MilliSeconds()
+	return &banexg.Order{
+		Info:                rsp.Result,
+		ID:                  res.OrderId,
+		ClientOrderID:       res.OrderLinkId,
+		Datetime:            utils.ISO8601(stamp),
+		Timestamp:           stamp,
+		LastUpdateTimestamp: stamp,
+		Status:              banexg.OdStatusOpen,
+		Symbol:              market.Symbol,
+		Type:                odType,
+		TimeInForce:         timeInForce,
+		PositionSide:        posSide,
+		Side:                side,
+		Price:               price,
+		Amount:              amount,
+		Remaining:           amount,
+		TriggerPrice:        trigPrice,
+		StopLossPrice:       stopLossPrice,
+		TakeProfitPrice:     takeProfitPrice,
+		Cost:                cost,
+		PostOnly:            postOnly,
+		ReduceOnly:          reduceOnly,
+		Fee:                 &banexg.Fee{},
+		Trades:              make([]*banexg.Trade, 0),
+	}, nil
+}
+
+/*
+EditOrder
+amend an open order, only unfilled or partially filled orders can be amended
+
+	:see: https://bybit-exchange.github.io/docs/v5/order/amend-order
+	:param str symbol: unified symbol of the market the order was made in
+	:param str orderId: order id, can be empty when params.clientOrderId is set
+	:param str side: buy or sell, only used for the returned order
+	:param float [amount]: new order quantity, 0 for no change
+	:param float [price]: new order price, 0 for no change
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param float [params.triggerPrice]: new trigger price of conditional order
+	:returns Order: the order structure, only ids and the amended fields are filled
+*/
+func (e *Bybit) EditOrder(symbol, orderId, side string, amount, price float64, params map[string]interface{}) (*banexg.Order, *errs.Error) {
+	args, market, err := e.loadOrderIdArgs(symbol, orderId, params)
+	if err != nil {
+		return nil, err
+	}
+	triggerPrice := utils.PopMapVal(args, banexg.ParamTriggerPrice, float64(0))
+	if triggerPrice == 0 {
+		triggerPrice = utils.PopMapVal(args, banexg.ParamStopLossPrice, float64(0))
+	}
+	if triggerPrice == 0 {
+		triggerPrice = utils.PopMapVal(args, banexg.ParamTakeProfitPrice, float64(0))
+	}
+	if amount <= 0 && price <= 0 && triggerPrice <= 0 {
+		return nil, errs.NewMsg(errs.CodeParamRequired, "amount, price or triggerPrice is required for EditOrder")
+	}
+	// 返回的订单只包含实际修改的字段，未修改的保持为0
+	var precAmt, precPrice, precTrig float64
+	if amount > 0 {
+		precAmt, err = e.PrecAmount(market, amount)
+		if err != nil {
+			return nil, err
+		}
+		args["qty"] = strconv.FormatFloat(precAmt, 'f', -1, 64)
+	}
+	if price > 0 {
+		precPrice, err = e.PrecPrice(market, price)
+		if err != nil {
+			return nil, err
+		}
+		args["price"] = strconv.FormatFloat(precPrice, 'f', -1, 64)
+	}
+	if triggerPrice > 0 {
+		precTrig, err = e.PrecPrice(market, triggerPrice)
+		if err != nil {
+			return nil, err
+		}
+		args["triggerPrice"] = strconv.FormatFloat(precTrig, 'f', -1, 64)
+	}
+	tryNum := e.GetRetryNum("EditOrder", 1)
+	rsp := requestRetry[map[string]interface{}](e, MethodPrivatePostV5OrderAmend, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	res, err := parseOrderIdRes(rsp.Result)
+	if err != nil {
+		return nil, err
+	}
+	stamp := e.MilliSeconds()
+	return &banexg.Order{
+		Info:                rsp.Result,
+		ID:                  res.OrderId,
+		ClientOrderID:       res.OrderLinkId,
+		LastUpdateTimestamp: stamp,
+		Status:              banexg.OdStatusOpen,
+		Symbol:              market.Symbol,
+		Side:                side,
+		Price:               precPrice,
+		Amount:              precAmt,
+		TriggerPrice:        precTrig,
+		Fee:                 &banexg.Fee{},
+		Trades:              make([]*banexg.Trade, 0),
+	}, nil
+}
+
+/*
+CancelOrder
+cancels an open order
+
+	:see: https://bybit-exchange.github.io/docs/v5/order/cancel-order
+	:param str id: order id, can be empty when params.clientOrderId is set
+	:param str symbol: unified symbol of the market the order was made in
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.orderFilter]: *spot only* Order/tpslOrder/StopOrder
+	:returns Order: the order structure, only ids are returned by exchange
+*/
+func (e *Bybit) CancelOrder(id string, symbol string, params map[string]interface{}) (*banexg.Order, *errs.Error) {
+	args, market, err := e.loadOrderIdArgs(symbol, id, params)
+	if err != nil {
+		return nil, err
+	}
+	tryNum := e.GetRetryNum("CancelOrder", 1)
+	rsp := requestRetry[map[string]interface{}](e, MethodPrivatePostV5OrderCancel, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	res, err := parseOrderIdRes(rsp.Result)
+	if err != nil {
+		return nil, err
+	}
+	return &banexg.Order{
+		Info:                rsp.Result,
+		ID:                  res.OrderId,
+		ClientOrderID:       res.OrderLinkId,
+		LastUpdateTimestamp: e.MilliSeconds(),
+		Status:              banexg.OdStatusCanceled,
+		Symbol:              market.Symbol,
+		Fee:                 &banexg.Fee{},
+		Trades:              make([]*banexg.Trade, 0),
+	}, nil
+}
+
+/*
+FetchOrder
+fetches information on an order, query open orders first, then order history
+
+	:see: https://bybit-exchange.github.io/docs/v5/order/open-order
+	:see: https://bybit-exchange.github.io/docs/v5/order/order-list
+	:param str symbol: unified symbol of the market the order was made in
+	:param str orderId: order id, can be empty when params.clientOrderId is set
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:returns Order: an order structure
+*/
+func (e *Bybit) FetchOrder(symbol, orderId string, params map[string]interface{}) (*banexg.Order, *errs.Error) {
+	args, market, err := e.loadOrderIdArgs(symbol, orderId, params)
+	if err != nil {
+		return nil, err
+	}
+	for _, method := range []string{MethodPrivateGetV5OrderRealtime, MethodPrivateGetV5OrderHistory} {
+		items, infos, err := e.fetchOrderPages(method, maps.Clone(args), 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) > 0 {
+			return items[0].ToStdOrder(e, market.Type, infos[0]), nil
+		}
+	}
+	return nil, errs.NewMsg(errs.CodeParamInvalid, "order not found: %s %s", symbol, orderId)
+}
+
+/*
+FetchOpenOrders
+fetch all unfilled currently open orders
+
+	:see: https://bybit-exchange.github.io/docs/v5/order/open-order
+	:param str [symbol]: unified market symbol
+	:param int [since]: the earliest time in ms to fetch open orders for
+	:param int [limit]: the maximum number of open orders structures to retrieve
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.settleCoin]: settle coin when symbol is empty, default USDT for linear
+	:returns Order[]: a list of order structures, sorted by time ascending
+*/
+func (e *Bybit) FetchOpenOrders(symbol string, since int64, limit int, params map[string]interface{}) ([]*banexg.Order, *errs.Error) {
+	args := utils.SafeParams(params)
+	marketType, _, err := e.LoadArgsMarketType(args, symbol)
+	if err != nil {
+		return nil, err
+	}
+	args["category"] = getMarketCategory(marketType)
+	if symbol != "" {
+		market, err := e.GetMarket(symbol)
+		if err != nil {
+			return nil, err
+		}
+		args["symbol"] = market.ID
+		marketType = market.Type
+	} else if marketType == banexg.MarketLinear || marketType == banexg.MarketInverse {
+		_, hasSettle := args["settleCoin"]
+		_, hasBase := args["baseCoin"]
+		if !hasSettle && !hasBase {
+			if marketType != banexg.MarketLinear {
+				return nil, errs.NewMsg(errs.CodeParamRequired, "symbol or settleCoin is required for inverse")
+			}
+			args["settleCoin"] = "USDT"
+		}
+	}
+	args["openOnly"] = 0
+	items, infos, err := e.fetchOrderPages(MethodPrivateGetV5OrderRealtime, args, 0)
+	if err != nil {
+		return nil, err
+	}
+	var res = make([]*banexg.Order, 0, len(items))
+	// 返回结果按时间倒序，转为升序
+	for i := len(items) - 1; i >= 0; i-- {
+		od := items[i].ToStdOrder(e, marketType, infos[i])
+		if since > 0 && od.Timestamp < since {
+			continue
+		}
+		res = append(res, od)
+	}
+	if limit > 0 && len(res) > limit {
+		res = res[len(res)-limit:]
+	}
+	return res, nil
+}
+
+/*
+loadOrderIdArgs
+common args for order amend/cancel/query: category, symbol, orderId or orderLinkId
+*/
+func (e *Bybit) loadOrderIdArgs(symbol, orderId string, params map[string]interface{}) (map[string]interface{}, *banexg.Market, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	args["category"] = getMarketCategory(market.Type)
+	args["symbol"] = market.ID
+	clientOrderId := utils.PopMapVal(args, banexg.ParamClientOrderId, "")
+	if orderId != "" {
+		args["orderId"] = orderId
+	} else if clientOrderId != "" {
+		args["orderLinkId"] = clientOrderId
+	} else {
+		return nil, nil, errs.NewMsg(errs.CodeParamRequired, "orderId or clientOrderId is required")
+	}
+	return args, market, nil
+}
+
+/*
+fetchOrderPages
+request order list of v5/order/realtime or v5/order/history, load all pages when maxNum is 0
+*/
+func (e *Bybit) fetchOrderPages(method string, args map[string]interface{}, maxNum int) ([]*Order, []map[string]interface{}, *errs.Error) {
+	args["limit"] = 50
+	tryNum := e.GetRetryNum("FetchOrders", 1)
+	var items = make([]*Order, 0)
+	var infos = make([]map[string]interface{}, 0)
+	for {
+		rsp := requestRetry[struct {
+			Category       string                   `json:"category"`
+			List           []map[string]interface{} `json:"list"`
+			NextPageCursor string                   `json:"nextPageCursor"`
+		}](e, method, maps.Clone(args), tryNum)
+		if rsp.Error != nil {
+			return nil, nil, rsp.Error
+		}
+		var arr = rsp.Result.List
+		var page = make([]*Order, 0, len(arr))
+		err_ := utils.DecodeStructMap(arr, &page, "json")
+		if err_ != nil {
+			return nil, nil, errs.New(errs.CodeUnmarshalFail, err_)
+		}
+		items = append(items, page...)
+		infos = append(infos, arr...)
+		if rsp.Result.NextPageCursor == "" || len(arr) == 0 || (maxNum > 0 && len(items) >= maxNum) {
+			break
+		}
+		args["cursor"] = rsp.Result.NextPageCursor
+	}
+	return items, infos, nil
+}
+
+func parseOrderIdRes(result map[string]interface{}) (*OrderIdRes, *errs.Error) {
+	var res = OrderIdRes{}
+	err_ := utils.DecodeStructMap(result, &res, "json")
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	return &res, nil
+}
+
+func (o *Order) ToStdOrder(e *Bybit, marketType string, info map[string]interface{}) *banexg.Order {
+	price, _ := strconv.ParseFloat(o.Price, 64)
+	amount, _ := strconv.ParseFloat(o.Qty, 64)
+	average, _ := strconv.ParseFloat(o.AvgPrice, 64)
+	filled, _ := strconv.ParseFloat(o.CumExecQty, 64)
+	remaining, _ := strconv.ParseFloat(o.LeavesQty, 64)
+	cost, _ := strconv.ParseFloat(o.CumExecValue, 64)
+	feeCost, _ := strconv.ParseFloat(o.CumExecFee, 64)
+	triggerPrice, _ := strconv.ParseFloat(o.TriggerPrice, 64)
+	created, _ := strconv.ParseInt(o.CreatedTime, 10, 64)
+	updated, _ := strconv.ParseInt(o.UpdatedTime, 10, 64)
+	isMarket := o.OrderType == "Market"
+	odType := strings.ToLower(o.OrderType)
+	var stopLossPrice, takeProfitPrice float64
+	switch o.StopOrderType {
+	case "TakeProfit", "PartialTakeProfit":
+		takeProfitPrice = triggerPrice
+		odType = banexg.OdTypeTakeProfit
+		if isMarket {
+			odType = banexg.OdTypeTakeProfitMarket
+		}
+	case "StopLoss", "PartialStopLoss":
+		stopLossPrice = triggerPrice
+		odType = banexg.OdTypeStop
+		if isMarket {
+			odType = banexg.OdTypeStopMarket
+		}
+	default:
+		if triggerPrice > 0 {
+			odType = banexg.OdTypeStop
+			if isMarket {
+				odType = banexg.OdTypeStopMarket
+			}
+		}
+	}
+	timeInForce := o.TimeInForce
+	postOnly := timeInForce == "PostOnly"
+	if postOnly {
+		timeInForce = banexg.TimeInForcePO
+	}
+	posSide := ""
+	if marketType != banexg.MarketSpot && marketType != banexg.MarketMargin {
+		switch o.PositionIdx {
+		case 1:
+			posSide = banexg.PosSideLong
+		case 2:
+			posSide = banexg.PosSideShort
+		default:
+			posSide = banexg.PosSideBoth
+		}
+	}
+	side := strings.ToLower(o.Side)
+	symbol := o.Symbol
+	fee := &banexg.Fee{Cost: feeCost}
+	market := e.GetMarketById(o.Symbol, marketType)
+	if market != nil {
+		symbol = market.Symbol
+		if market.Contract {
+			fee.Currency = market.Settle
+		} else if side == banexg.OdSideBuy {
+			fee.Currency = market.Base
+		} else {
+			fee.Currency = market.Quote
+		}
+	}
+	return &banexg.Order{
+		Info:                info,
+		ID:                  o.OrderId,
+		ClientOrderID:       o.OrderLinkId,
+		Datetime:            utils.ISO8601(created),
+		Timestamp:           created,
+		LastUpdateTimestamp: updated,
+		Status:              mapOrderStatus(o.OrderStatus),
+		Symbol:              symbol,
+		Type:                odType,
+		TimeInForce:         timeInForce,
+		PositionSide:        posSide,
+		Side:                side,
+		Price:               price,
+		Average:             average,
+		Amount:              amount,
+		Filled:              filled,
+		Remaining:           remaining,
+		TriggerPrice:        triggerPrice,
+		StopLossPrice:       stopLossPrice,
+		TakeProfitPrice:     takeProfitPrice,
+		Cost:                cost,
+		PostOnly:            postOnly,
+		ReduceOnly:          o.ReduceOnly,
+		Fee:                 fee,
+		Trades:              make([]*banexg.Trade, 0),
+	}
+}
@@ -0,0 +1,75 @@
+package bybit
+
+import (
+	"strconv"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+)
+
+// 各市场单次获取订单簿的最大深度
+var maxBookLimits = map[string]int{
+	banexg.MarketSpot:    200,
+	banexg.MarketLinear:  500,
+	banexg.MarketInverse: 500,
+	banexg.MarketOption:  25,
+}
+
+/*
+FetchOrderBook
+fetches information on open orders with bid (buy) and ask (sell) prices, volumes and other data
+
+	:see: https://bybit-exchange.github.io/docs/v5/market/orderbook
+	:param str symbol: unified symbol of the market to fetch the order book for
+	:param int [limit]: the maximum amount of order book entries to return, spot: 1-200, linear/inverse: 1-500, option: 1-25
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:returns dict: A dictionary of `order book structures <https://docs.ccxt.com/#/?id=order-book-structure>` indexed by market symbols
+*/
+func (e *Bybit) FetchOrderBook(symbol string, limit int, params map[string]interface{}) (*banexg.OrderBook, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	args["symbol"] = market.ID
+	args["category"] = getMarketCategory(market.Type)
+	if limit > 0 {
+		if maxLimit, ok := maxBookLimits[market.Type]; ok {
+			limit = min(limit, maxLimit)
+		}
+		args["limit"] = limit
+	}
+	tryNum := e.GetRetryNum("FetchOrderBook", 1)
+	rsp := requestRetry[OrderBook](e, MethodPublicGetV5MarketOrderbook, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	book := rsp.Result.ToStdOrderBook(market)
+	if book.TimeStamp == 0 {
+		book.TimeStamp = e.MilliSeconds()
+	}
+	return book, nil
+}
+
+func parseBookSide(items [][2]string) [][2]float64 {
+	var res = make([][2]float64, len(items))
+	for i, it := range items {
+		item := [2]float64{}
+		item[0], _ = strconv.ParseFloat(it[0], 64)
+		item[1], _ = strconv.ParseFloat(it[1], 64)
+		res[i] = item
+	}
+	return res
+}
+
+func (o *OrderBook) ToStdOrderBook(market *banexg.Market) *banexg.OrderBook {
+	asks := parseBookSide(o.Asks)
+	bids := parseBookSide(o.Bids)
+	return &banexg.OrderBook{
+		Symbol:    market.Symbol,
+		TimeStamp: o.TimeStamp,
+		Asks:      banexg.NewOdBookSide(false, len(asks), asks),
+		Bids:      banexg.NewOdBookSide(true, len(bids), bids),
+		Nonce:     o.UpdateID,
+		Cache:     make([]map[string]string, 0),
+	}
+}
@@ -0,0 +1,109 @@
+package bybit
+
+import (
+	"testing"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/utils"
+	"github.com/h2non/gock"
+)
+
+func TestMapOrderStatus(t *testing.T) {
+	cases := map[string]string{
+		"New":                     banexg.OdStatusOpen,
+		"Untriggered":             banexg.OdStatusOpen,
+		"Triggered":               banexg.OdStatusOpen,
+		"PartiallyFilled":         banexg.OdStatusPartFilled,
+		"Filled":                  banexg.OdStatusFilled,
+		"Cancelled":               banexg.OdStatusCanceled,
+		"PartiallyFilledCanceled": banexg.OdStatusCanceled,
+		"Deactivated":             banexg.OdStatusCanceled,
+		"Rejected":                banexg.OdStatusRejected,
+		"Unknown":                 "unknown",
+	}
+	for status, expect := range cases {
+		if res := mapOrderStatus(status); res != expect {
+			t.Errorf("mapOrderStatus %s expect %s, got %s", status, expect, res)
+		}
+	}
+}
+
+func TestOrderToStdOrder(t *testing.T) {
+	exg := getOfflineBybit(btcLinear, btcSpot)
+	text := `[
+{"orderId":"1","orderLinkId":"c1","symbol":"BTCUSDT","price":"30000","qty":"0.02","side":"Buy","positionIdx":1,
+"orderStatus":"PartiallyFilled","avgPrice":"29990","leavesQty":"0.01","cumExecQty":"0.01","cumExecValue":"299.9",
+"cumExecFee":"0.18","timeInForce":"PostOnly","orderType":"Limit","createdTime":"1700000000000","updatedTime":"1700000001000"},
+{"orderId":"2","symbol":"BTCUSDT","price":"0","qty":"0.02","side":"Sell","positionIdx":2,"orderStatus":"Untriggered",
+"orderType":"Market","stopOrderType":"StopLoss","triggerPrice":"28000","reduceOnly":true,"timeInForce":"IOC",
+"createdTime":"1700000000000","updatedTime":"1700000000000"},
+{"orderId":"3","symbol":"BTCUSDT","price":"32000","qty":"0.02","side":"Sell","orderStatus":"New",
+"orderType":"Limit","stopOrderType":"TakeProfit","triggerPrice":"31000","timeInForce":"GTC",
+"createdTime":"1700000000000","updatedTime":"1700000000000"}
+]`
+	var items = make([]*Order, 0)
+	infos, err := utils.UnmarshalStringMapArr(text, &items)
+	if err != nil {
+		t.Fatalf("unmarshal orders fail: %v", err)
+	}
+	od := items[0].ToStdOrder(exg, banexg.MarketLinear, infos[0])
+	if od.Symbol != btcLinear.Symbol || od.Status != banexg.OdStatusPartFilled || od.Filled != 0.01 ||
+		od.Remaining != 0.01 || od.Average != 29990 || od.Cost != 299.9 || od.ClientOrderID != "c1" {
+		t.Errorf("bad linear order: %+v", od)
+	}
+	if !od.PostOnly || od.TimeInForce != banexg.TimeInForcePO || od.PositionSide != banexg.PosSideLong {
+		t.Errorf("bad post only or position side: %v %s %s", od.PostOnly, od.TimeInForce, od.PositionSide)
+	}
+	if od.Fee == nil || od.Fee.Currency != "USDT" || od.Fee.Cost != 0.18 || od.Timestamp != 1700000000000 ||
+		od.LastUpdateTimestamp != 1700000001000 {
+		t.Errorf("bad fee or time: %+v %+v", od.Fee, od)
+	}
+	od = items[1].ToStdOrder(exg, banexg.MarketLinear, infos[1])
+	if od.Type != banexg.OdTypeStopMarket || od.StopLossPrice != 28000 || od.TriggerPrice != 28000 ||
+		od.Status != banexg.OdStatusOpen || !od.ReduceOnly || od.PositionSide != banexg.PosSideShort {
+		t.Errorf("bad stop loss order: %+v", od)
+	}
+	od = items[2].ToStdOrder(exg, banexg.MarketLinear, infos[2])
+	if od.Type != banexg.OdTypeTakeProfit || od.TakeProfitPrice != 31000 || od.PositionSide != banexg.PosSideBoth {
+		t.Errorf("bad take profit order: %+v", od)
+	}
+	// 现货无持仓方向，买入手续费为基础币
+	od = items[0].ToStdOrder(exg, banexg.MarketSpot, infos[0])
+	if od.Symbol != btcSpot.Symbol || od.PositionSide != "" || od.Fee.Currency != "BTC" {
+		t.Errorf("bad spot order: %s %s %s", od.Symbol, od.PositionSide, od.Fee.Currency)
+	}
+}
+
+func TestEditOrderFields(t *testing.T) {
+	defer gock.Off()
+	gock.DisableNetworking()
+	exg, err := New(map[string]interface{}{
+		banexg.OptApiKey:    "key",
+		banexg.OptApiSecret: "secret",
+	})
+	if err != nil {
+		panic(err)
+	}
+	mar := *btcLinear
+	mar.Precision = &banexg.Precision{Amount: 0.001, Price: 0.1,
+		ModeAmount: banexg.PrecModeTickSize, ModePrice: banexg.PrecModeTickSize}
+	exg.Markets = banexg.MarketMap{mar.Symbol: &mar}
+	exg.MarketsById = banexg.MarketArrMap{mar.ID: {&mar}}
+	gock.InterceptClient(exg.HttpClient)
+	// 未指定任何修改字段时直接拒绝，不发送请求
+	if _, err = exg.EditOrder(btcLinear.Symbol, "123", banexg.OdSideBuy, 0, 0, nil); err == nil {
+		t.Errorf("EditOrder without changes should fail")
+	}
+	gock.New("https://api.bybit.com").Post("/v5/order/amend").Reply(200).
+		BodyString(`{"retCode":0,"retMsg":"OK","result":{"orderId":"123","orderLinkId":"abc"}}`)
+	od, err := exg.EditOrder(btcLinear.Symbol, "123", banexg.OdSideBuy, 0, 30000, nil)
+	if err != nil {
+		t.Fatalf("EditOrder fail: %v", err)
+	}
+	if od.ID != "123" || od.Price != 30000 || od.Amount != 0 || od.TriggerPrice != 0 {
+		t.Errorf("unexpected edited order: %+v", od)
+	}
+	if !gock.IsDone() {
+		t.Errorf("amend request not sent")
+	}
+}
@@ -0,0 +1,391 @@
+package bybit
+
+import (
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+/*
+AddMargin
+add margin to an isolated position
+
+	:see: https://bybit-exchange.github.io/docs/v5/position/manual-add-margin
+	:param str symbol: unified market symbol
+	:param float amount: amount of margin to add
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.positionSide]: long/short in hedge mode
+	:returns MarginAdjustment: a margin adjustment structure with the updated position
+*/
+func (e *Bybit) AddMargin(symbol string, amount float64, params map[string]interface{}) (*banexg.MarginAdjustment, *errs.Error) {
+	return e.modifyMarginHelper(symbol, amount, banexg.MarginAdjAdd, params)
+}
+
+/*
+ReduceMargin
+remove margin from an isolated position
+
+	:see: https://bybit-exchange.github.io/docs/v5/position/manual-add-margin
+	:param str symbol: unified market symbol
+	:param float amount: amount of margin to remove
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.positionSide]: long/short in hedge mode
+	:returns MarginAdjustment: a margin adjustment structure with the updated position
+*/
+func (e *Bybit) ReduceMargin(symbol string, amount float64, params map[string]interface{}) (*banexg.MarginAdjustment, *errs.Error) {
+	return e.modifyMarginHelper(symbol, amount, banexg.MarginAdjReduce, params)
+}
+
+func (e *Bybit) modifyMarginHelper(symbol string, amount float64, adjType string, params map[string]interface{}) (*banexg.MarginAdjustment, *errs.Error) {
+	if amount <= 0 {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "amount must > 0 for modify margin")
+	}
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	if !market.Linear && !market.Inverse {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "%v modify margin supports linear and inverse contracts only", e.Name)
+	}
+	posSide := strings.ToLower(utils.PopMapVal(args, banexg.ParamPositionSide, ""))
+	// 查询更新后的持仓需要的参数，需在发送请求前复制
+	posArgs := utils.SafeParams(args)
+	args["category"] = getMarketCategory(market.Type)
+	args["symbol"] = market.ID
+	margin := amount
+	if adjType == banexg.MarginAdjReduce {
+		margin = -amount
+	}
+	args["margin"] = strconv.FormatFloat(margin, 'f', -1, 64)
+	args["positionIdx"] = getPositionIdx(posSide)
+	tryNum := e.GetRetryNum("ModifyMargin", 1)
+	rsp := requestRetry[map[string]interface{}](e, MethodPrivatePostV5PositionAddMargin, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	// add-margin的返回缺少side/tradeMode/positionBalance，需重新查询持仓
+	pos, err := e.fetchUpdatedPosition(market, getPositionIdx(posSide), posArgs)
+	if err != nil {
+		return nil, err
+	}
+	return &banexg.MarginAdjustment{
+		Symbol:    market.Symbol,
+		Type:      adjType,
+		Amount:    amount,
+		Code:      market.Settle,
+		Status:    banexg.TransferStatusOk,
+		Timestamp: e.MilliSeconds(),
+		Position:  pos,
+		Info:      rsp.Result,
+	}, nil
+}
+
+/*
+fetchUpdatedPosition
+调整保证金后，查询symbol和positionIdx对应的最新持仓
+*/
+func (e *Bybit) fetchUpdatedPosition(market *banexg.Market, posIdx int, args map[string]interface{}) (*banexg.Position, *errs.Error) {
+	args["category"] = getMarketCategory(market.Type)
+	args["symbol"] = market.ID
+	items, infos, err := e.fetchPositionList(args)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range items {
+		if p.Symbol == market.ID && p.PositionIdx == posIdx {
+			return p.ToStdPos(market, infos[i]), nil
+		}
+	}
+	return nil, nil
+}
+
+/*
+getPositionIdx
+0: one-way mode, 1: hedge-mode buy side, 2: hedge-mode sell side
+*/
+func getPositionIdx(posSide string) int {
+	switch posSide {
+	case banexg.PosSideLong:
+		return 1
+	case banexg.PosSideShort:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func (p *Position) ToStdPos(market *banexg.Market, info map[string]interface{}) *banexg.Position {
+	contracts, _ := strconv.ParseFloat(p.Size, 64)
+	entryPrice, _ := strconv.ParseFloat(p.AvgPrice, 64)
+	markPrice, _ := strconv.ParseFloat(p.MarkPrice, 64)
+	notional, _ := strconv.ParseFloat(p.PositionValue, 64)
+	leverage, _ := strconv.ParseFloat(p.Leverage, 64)
+	collateral, _ := strconv.ParseFloat(p.PositionBal, 64)
+	initMargin, _ := strconv.ParseFloat(p.PositionIM, 64)
+	maintMargin, _ := strconv.ParseFloat(p.PositionMM, 64)
+	unp, _ := strconv.ParseFloat(p.UnrealisedPnl, 64)
+	liqPrice, _ := strconv.ParseFloat(p.LiqPrice, 64)
+	stamp, _ := strconv.ParseInt(p.UpdatedTime, 10, 64)
+	side := banexg.PosSideBoth
+	if p.Side == "Buy" {
+		side = banexg.PosSideLong
+	} else if p.Side == "Sell" {
+		side = banexg.PosSideShort
+	}
+	marginMode := banexg.MarginCross
+	if p.TradeMode == 1 {
+		marginMode = banexg.MarginIsolated
+	}
+	res := &banexg.Position{
+		Symbol:           market.Symbol,
+		TimeStamp:        stamp,
+		Isolated:         marginMode == banexg.MarginIsolated,
+		Hedged:           p.PositionIdx != 0,
+		Side:             side,
+		Contracts:        contracts,
+		ContractSize:     market.ContractSize,
+		EntryPrice:       entryPrice,
+		MarkPrice:        markPrice,
+		Notional:         notional,
+		Leverage:         int(leverage),
+		Collateral:       collateral,
+		InitialMargin:    initMargin,
+		MaintMargin:      maintMargin,
+		UnrealizedPnl:    unp,
+		LiquidationPrice: liqPrice,
+		MarginMode:       marginMode,
+		Info:             info,
+	}
+	if leverage > 0 {
+		res.InitialMarginPct = 1 / leverage
+	}
+	if notional > 0 {
+		res.MaintMarginPct, _ = utils.PrecFloat64(maintMargin/notional, 6, true, 0)
+	}
+	if collateral > 0 {
+		res.MarginRatio, _ = utils.PrecFloat64(maintMargin/collateral, 4, true, 0)
+	}
+	if initMargin > 0 {
+		res.Percentage, _ = utils.PrecFloat64(unp*100/initMargin, 2, true, 0)
+	}
+	return res
+}
+
+/*
+SetLeverage
+set the level of leverage for a market, buy and sell side use the same leverage
+
+	:see: https://bybit-exchange.github.io/docs/v5/position/leverage
+	:param float leverage: the rate of leverage
+	:param str symbol: unified market symbol
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:returns dict: response from the exchange
+*/
+func (e *Bybit) SetLeverage(leverage float64, symbol string, params map[string]interface{}) (map[string]interface{}, *errs.Error) {
+	if symbol == "" {
+		return nil, errs.NewMsg(errs.CodeParamRequired, "symbol is required for %v.SetLeverage", e.Name)
+	}
+	if leverage < 1 {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "%v leverage should not be less than 1", e.Name)
+	}
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	if !market.Linear && !market.Inverse {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "%v SetLeverage supports linear and inverse contracts only", e.Name)
+	}
+	accName := e.GetAccName(args)
+	levText := strconv.FormatFloat(leverage, 'f', -1, 64)
+	args["category"] = getMarketCategory(market.Type)
+	args["symbol"] = market.ID
+	args["buyLeverage"] = levText
+	args["sellLeverage"] = levText
+	tryNum := e.GetRetryNum("SetLeverage", 1)
+	rsp := requestRetry[map[string]interface{}](e, MethodPrivatePostV5PositionSetLeverage, args, tryNum)
+	res, err := parseModeRsp(rsp, codeLvgNotModified)
+	if err != nil {
+		return nil, err
+	}
+	e.setAccLeverages(accName, map[string]int{market.Symbol: int(leverage)})
+	return res, nil
+}
+
+/*
+setAccLeverages
+更新账户缓存的币种杠杆倍数，用于GetLeverage
+*/
+func (e *Bybit) setAccLeverages(accName string, leverages map[string]int) {
+	if len(leverages) == 0 {
+		return
+	}
+	acc, err := e.GetAccount(accName)
+	if err != nil {
+		return
+	}
+	acc.LockLeverage.Lock()
+	for symbol, lvg := range leverages {
+		acc.Leverages[symbol] = lvg
+	}
+	acc.LockLeverage.Unlock()
+}
+
+/*
+LoadLeverageBrackets
+load risk limit tiers of all symbols as leverage brackets.
+linear and inverse are loaded separately and merged by symbol, reload applies to the market type of params
+
+	:see: https://bybit-exchange.github.io/docs/derivatives/public/risk-limit
+	:param bool reload: reload even if already loaded
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+*/
+func (e *Bybit) LoadLeverageBrackets(reload bool, params map[string]interface{}) *errs.Error {
+	args := utils.SafeParams(params)
+	marketType, _, err := e.LoadArgsMarketType(args)
+	if err != nil {
+		return err
+	}
+	if marketType != banexg.MarketLinear && marketType != banexg.MarketInverse {
+		return errs.NewMsg(errs.CodeUnsupportMarket, "LoadLeverageBrackets support linear/inverse contracts only")
+	}
+	e.lvgLock.Lock()
+	loaded := e.lvgLoaded[marketType]
+	e.lvgLock.Unlock()
+	if loaded && !reload {
+		return nil
+	}
+	args["category"] = getMarketCategory(marketType)
+	tryNum := e.GetRetryNum("LoadLeverageBrackets", 1)
+	rsp := requestRetry[struct {
+		List []map[string]interface{} `json:"list"`
+	}](e, MethodPublicGetDerivativesV3PublicRiskLimitList, args, tryNum)
+	if rsp.Error != nil {
+		return rsp.Error
+	}
+	var items = make([]*RiskLimit, 0, len(rsp.Result.List))
+	err_ := utils.DecodeStructMap(rsp.Result.List, &items, "json")
+	if err_ != nil {
+		return errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	mapSymbol := func(id string) string {
+		return e.SafeSymbol(id, "", marketType)
+	}
+	brackets := parseLvgBrackets(mapSymbol, items)
+	// U本位和币本位分别加载，按symbol合并，不覆盖另一市场的档位
+	e.lvgLock.Lock()
+	if e.LeverageBrackets == nil {
+		e.LeverageBrackets = make(map[string]*SymbolLvgBrackets)
+	}
+	maps.Copy(e.LeverageBrackets, brackets)
+	e.lvgLoaded[marketType] = true
+	e.lvgLock.Unlock()
+	return nil
+}
+
+/*
+parseLvgBrackets
+将风险限额档位转为杠杆分层，按名义价值上限升序。
+速算数使各档位边界处维持保证金连续：cum_i = cum_{i-1} + floor_i * (mmr_i - mmr_{i-1})
+*/
+func parseLvgBrackets(mapSymbol func(string) string, items []*RiskLimit) map[string]*SymbolLvgBrackets {
+	var groups = make(map[string][]*RiskLimit)
+	for _, it := range items {
+		symbol := mapSymbol(it.Symbol)
+		if symbol == "" {
+			continue
+		}
+		groups[symbol] = append(groups[symbol], it)
+	}
+	var res = make(map[string]*SymbolLvgBrackets)
+	for symbol, tiers := range groups {
+		var brackets = make([]*LvgBracket, 0, len(tiers))
+		for _, it := range tiers {
+			limit, _ := strconv.ParseFloat(it.Limit, 64)
+			mmr, _ := strconv.ParseFloat(it.MaintainMargin, 64)
+			maxLvg, _ := strconv.ParseFloat(it.MaxLeverage, 64)
+			brackets = append(brackets, &LvgBracket{
+				MaxLeverage:      maxLvg,
+				MaintMarginRatio: mmr,
+				Capacity:         limit,
+			})
+		}
+		sort.Slice(brackets, func(i, j int) bool {
+			return brackets[i].Capacity < brackets[j].Capacity
+		})
+		var floor, cum, prevMmr float64
+		for i, b := range brackets {
+			cum += floor * (b.MaintMarginRatio - prevMmr)
+			b.Bracket = i + 1
+			b.Floor = floor
+			b.Cum = cum
+			floor = b.Capacity
+			prevMmr = b.MaintMarginRatio
+		}
+		res[symbol] = &SymbolLvgBrackets{
+			Symbol:   symbol,
+			Brackets: brackets,
+		}
+	}
+	return res
+}
+
+/*
+GetLeverage
+返回账户当前杠杆倍数，以及指定名义价值所在档位允许的最大杠杆
+*/
+func (e *Bybit) GetLeverage(symbol string, notional float64, account string) (float64, float64) {
+	e.lvgLock.Lock()
+	info, ok := e.LeverageBrackets[symbol]
+	e.lvgLock.Unlock()
+	maxVal := float64(0)
+	if ok && len(info.Brackets) > 0 {
+		for _, row := range info.Brackets {
+			maxVal = row.MaxLeverage
+			if notional <= row.Capacity {
+				break
+			}
+		}
+	}
+	if account == "" {
+		account = e.DefAccName
+	}
+	var leverage int
+	if acc, ok := e.Accounts[account]; ok {
+		acc.LockLeverage.Lock()
+		leverage = acc.Leverages[symbol]
+		acc.LockLeverage.Unlock()
+	}
+	return float64(leverage), maxVal
+}
+
+/*
+CalcMaintMargin
+根据风险限额档位计算指定名义价值的维持保证金
+*/
+func (e *Bybit) CalcMaintMargin(symbol string, cost float64) (float64, *errs.Error) {
+	e.lvgLock.Lock()
+	loadNum := len(e.LeverageBrackets)
+	info, ok := e.LeverageBrackets[symbol]
+	e.lvgLock.Unlock()
+	if loadNum == 0 {
+		return 0, errs.NewMsg(errs.CodeRunTime, "LeverageBrackets not load")
+	}
+	maintMargin := float64(-1)
+	if ok && len(info.Brackets) > 0 {
+		for _, row := range info.Brackets {
+			if cost < row.Floor {
+				break
+			}
+			maintMargin = row.MaintMarginRatio*cost - row.Cum
+		}
+	}
+	if maintMargin < 0 {
+		return 0, errs.NewMsg(errs.CodeParamInvalid, "cost invalid")
+	}
+	return maintMargin, nil
+}
@@ -0,0 +1,131 @@
+package bybit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/banbox/banexg"
+	"github.com/h2non/gock"
+)
+
+func TestLoadLeverageBracketsMerge(t *testing.T) {
+	defer gock.Off()
+	gock.DisableNetworking()
+	exg := getOfflineBybit(btcLinear, btcInverse)
+	gock.InterceptClient(exg.HttpClient)
+	riskPath := "/derivatives/v3/public/risk-limit/list"
+	gock.New("https://api.bybit.com").Get(riskPath).MatchParam("category", "linear").Reply(200).
+		BodyString(`{"retCode":0,"retMsg":"OK","result":{"list":[
+{"id":1,"symbol":"BTCUSDT","limit":"2000000","maintainMargin":"0.005","maxLeverage":"100"}]}}`)
+	gock.New("https://api.bybit.com").Get(riskPath).MatchParam("category", "inverse").Reply(200).
+		BodyString(`{"retCode":0,"retMsg":"OK","result":{"list":[
+{"id":1,"symbol":"BTCUSD","limit":"150","maintainMargin":"0.005","maxLeverage":"100"}]}}`)
+	err := exg.LoadLeverageBrackets(false, map[string]interface{}{banexg.ParamMarket: banexg.MarketLinear})
+	if err != nil {
+		t.Fatalf("load linear fail: %v", err)
+	}
+	err = exg.LoadLeverageBrackets(false, map[string]interface{}{banexg.ParamMarket: banexg.MarketInverse})
+	if err != nil {
+		t.Fatalf("load inverse fail: %v", err)
+	}
+	for _, symbol := range []string{btcLinear.Symbol, btcInverse.Symbol} {
+		if _, ok := exg.LeverageBrackets[symbol]; !ok {
+			t.Errorf("leverage brackets of %s missing", symbol)
+		}
+	}
+	// 已加载的市场不重复请求
+	err = exg.LoadLeverageBrackets(false, map[string]interface{}{banexg.ParamMarket: banexg.MarketLinear})
+	if err != nil {
+		t.Errorf("load linear again fail: %v", err)
+	}
+}
+
+func TestLvgBracketsCum(t *testing.T) {
+	exg := getOfflineBybit(btcLinear)
+	items := []*RiskLimit{
+		{Symbol: "BTCUSDT", Limit: "4000000", MaintainMargin: "0.01", MaxLeverage: "50"},
+		{Symbol: "BTCUSDT", Limit: "2000000", MaintainMargin: "0.005", MaxLeverage: "100"},
+		{Symbol: "BTCUSDT", Limit: "6000000", MaintainMargin: "0.015", MaxLeverage: "33.33"},
+		{Symbol: "UNKNOWN", Limit: "100", MaintainMargin: "0.1", MaxLeverage: "10"},
+	}
+	mapSymbol := func(id string) string {
+		return exg.SafeSymbol(id, "", banexg.MarketLinear)
+	}
+	exg.LeverageBrackets = parseLvgBrackets(mapSymbol, items)
+	info, ok := exg.LeverageBrackets[btcLinear.Symbol]
+	if !ok || len(info.Brackets) != 3 || len(exg.LeverageBrackets) != 1 {
+		t.Fatalf("bad brackets: %v", exg.LeverageBrackets)
+	}
+	// cum_i = cum_{i-1} + floor_i * (mmr_i - mmr_{i-1})
+	expects := [][3]float64{{0, 0, 2000000}, {2000000, 10000, 4000000}, {4000000, 30000, 6000000}}
+	for i, b := range info.Brackets {
+		exp := expects[i]
+		if b.Bracket != i+1 || b.Floor != exp[0] || math.Abs(b.Cum-exp[1]) > 1e-6 || b.Capacity != exp[2] {
+			t.Errorf("bracket %d expect floor/cum/cap %v, got %v %v %v", i+1, exp, b.Floor, b.Cum, b.Capacity)
+		}
+	}
+	// 档位边界处维持保证金连续
+	cases := map[float64]float64{1000000: 5000, 2000000: 10000, 3000000: 20000, 5000000: 45000}
+	for cost, expect := range cases {
+		res, err := exg.CalcMaintMargin(btcLinear.Symbol, cost)
+		if err != nil || math.Abs(res-expect) > 1e-6 {
+			t.Errorf("CalcMaintMargin %v expect %v, got %v %v", cost, expect, res, err)
+		}
+	}
+	if _, err := exg.CalcMaintMargin("ETH/USDT:USDT", 1000); err == nil {
+		t.Errorf("CalcMaintMargin should fail for symbol without brackets")
+	}
+	_, maxLvg := exg.GetLeverage(btcLinear.Symbol, 3000000, "")
+	if maxLvg != 50 {
+		t.Errorf("max leverage at 3000000 expect 50, got %v", maxLvg)
+	}
+}
+
+func TestModifyMarginRejectNonPositive(t *testing.T) {
+	defer gock.Off()
+	gock.DisableNetworking()
+	exg := getOfflineBybit(btcLinear)
+	gock.InterceptClient(exg.HttpClient)
+	for _, amount := range []float64{0, -5} {
+		if _, err := exg.AddMargin(btcLinear.Symbol, amount, nil); err == nil {
+			t.Errorf("AddMargin %v should fail", amount)
+		}
+		if _, err := exg.ReduceMargin(btcLinear.Symbol, amount, nil); err == nil {
+			t.Errorf("ReduceMargin %v should fail", amount)
+		}
+	}
+}
+
+func TestModifyMarginPosition(t *testing.T) {
+	defer gock.Off()
+	gock.DisableNetworking()
+	exg, err := New(map[string]interface{}{
+		banexg.OptApiKey:    "key",
+		banexg.OptApiSecret: "secret",
+	})
+	if err != nil {
+		panic(err)
+	}
+	exg.Markets = banexg.MarketMap{btcLinear.Symbol: btcLinear}
+	exg.MarketsById = banexg.MarketArrMap{btcLinear.ID: {btcLinear}}
+	gock.InterceptClient(exg.HttpClient)
+	gock.New("https://api.bybit.com").Post("/v5/position/add-margin").Reply(200).
+		BodyString(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","symbol":"BTCUSDT","positionIdx":1,
+"riskId":1,"riskLimitValue":"2000000","size":"0.1","avgPrice":"30000","liqPrice":"20000","positionValue":"3000",
+"leverage":"10","positionIM":"300","positionMM":"15","unrealisedPnl":"0","markPrice":"30000"}}`)
+	gock.New("https://api.bybit.com").Get("/v5/position/list").MatchParam("symbol", "BTCUSDT").Reply(200).
+		BodyString(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[
+{"positionIdx":2,"symbol":"BTCUSDT","side":"Sell","size":"0.2","avgPrice":"31000","positionValue":"6200","tradeMode":1,"leverage":"10","markPrice":"30000","positionIM":"620","positionMM":"31","positionBalance":"620"},
+{"positionIdx":1,"symbol":"BTCUSDT","side":"Buy","size":"0.1","avgPrice":"30000","positionValue":"3000","tradeMode":1,"leverage":"10","markPrice":"30000","positionIM":"300","positionMM":"15","positionBalance":"310"}]}}`)
+	res, err := exg.AddMargin(btcLinear.Symbol, 10, map[string]interface{}{banexg.ParamPositionSide: banexg.PosSideLong})
+	if err != nil {
+		t.Fatalf("add margin fail: %v", err)
+	}
+	pos := res.Position
+	if pos == nil {
+		t.Fatalf("updated position missing")
+	}
+	if pos.Side != banexg.PosSideLong || pos.MarginMode != banexg.MarginIsolated || pos.Collateral != 310 {
+		t.Errorf("unexpected position: side %s, mode %s, collateral %v", pos.Side, pos.MarginMode, pos.Collateral)
+	}
+}
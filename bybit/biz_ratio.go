@@ -0,0 +1,93 @@
+package bybit
+
+import (
+	"strconv"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+/*
+FetchLongShortRatioHistory
+retrieves the long/short account ratio history of a linear/inverse contract
+
+	:see: https://bybit-exchange.github.io/docs/v5/market/long-short-ratio
+	:param str symbol: unified CCXT market symbol
+	:param str period: "5m","15m","30m","1h","4h", or "1d"
+	:param int [since]: the time(ms) of the earliest record to retrieve as a unix timestamp
+	:param int [limit]: default 50, max 500
+	:param dict [params]: exchange specific parameters
+	:param str [params.ratioType]: only globalAccount is supported
+	:param int [params.until]: the time(ms) of the latest record to retrieve as a unix timestamp
+	:returns LongShortRatio[]: an array of long short ratio structure, sorted by time asc
+*/
+func (e *Bybit) FetchLongShortRatioHistory(symbol, period string, since int64, limit int, params map[string]interface{}) ([]*banexg.LongShortRatio, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	if !market.Linear && !market.Inverse {
+		return nil, errs.NewMsg(errs.CodeUnsupportMarket, "FetchLongShortRatioHistory support linear/inverse only")
+	}
+	ratioType := utils.PopMapVal(args, banexg.ParamRatioType, banexg.LSRatioGlobalAccount)
+	if ratioType != banexg.LSRatioGlobalAccount {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "bybit only support %s: %s", banexg.ParamRatioType,
+			banexg.LSRatioGlobalAccount)
+	}
+	if period == "" {
+		period = "5m"
+	}
+	intv, ok := oiPeriodMap[period]
+	if !ok {
+		return nil, errs.NewMsg(errs.CodeInvalidTimeFrame, "unsupported period for long short ratio: %s", period)
+	}
+	args["category"] = getMarketCategory(market.Type)
+	args["symbol"] = market.ID
+	args["period"] = intv
+	if since > 0 {
+		args["startTime"] = since
+	}
+	until := utils.PopMapVal(args, banexg.ParamUntil, int64(0))
+	if until > 0 {
+		args["endTime"] = until
+	}
+	if limit > 0 {
+		args["limit"] = min(limit, 500)
+	}
+	tryNum := e.GetRetryNum("FetchLongShortRatioHistory", 1)
+	rsp := requestRetry[struct {
+		List           []map[string]interface{} `json:"list"`
+		NextPageCursor string                   `json:"nextPageCursor"`
+	}](e, MethodPublicGetV5MarketAccountRatio, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var arr = rsp.Result.List
+	var items = make([]*AccountRatio, 0, len(arr))
+	err_ := utils.DecodeStructMap(arr, &items, "json")
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var res = make([]*banexg.LongShortRatio, 0, len(items))
+	// 接口返回按时间倒序，这里转为正序
+	for i := len(items) - 1; i >= 0; i-- {
+		it := items[i]
+		longRate, _ := strconv.ParseFloat(it.BuyRatio, 64)
+		shortRate, _ := strconv.ParseFloat(it.SellRatio, 64)
+		stamp, _ := strconv.ParseInt(it.Timestamp, 10, 64)
+		var ratio float64
+		if shortRate > 0 {
+			ratio = longRate / shortRate
+		}
+		res = append(res, &banexg.LongShortRatio{
+			Symbol:     market.Symbol,
+			LongRatio:  longRate,
+			ShortRatio: shortRate,
+			Ratio:      ratio,
+			Timestamp:  stamp,
+			Info:       arr[i],
+		})
+	}
+	return res, nil
+}
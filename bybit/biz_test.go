@@ -2,8 +2,10 @@ package bybit
 
 import (
 	"fmt"
-	"github.com/banbox/banexg/utils"
 	"testing"
+
+	"github.com/banbox/banexg/utils"
+	"github.com/h2non/gock"
 )
 
 func TestLoadMarkets(t *testing.T) {
@@ -19,3 +21,44 @@ func TestLoadMarkets(t *testing.T) {
 	}
 	fmt.Printf("dump markets at: %v", outPath)
 }
+
+func TestFetchFundingRateInterval(t *testing.T) {
+	cases := []struct {
+		prevTime string
+		expIntv  string
+	}{
+		{"1700006400000", "8h"},
+		{"1700020800000", "4h"},
+		{"", ""},
+	}
+	for _, c := range cases {
+		gock.Off()
+		gock.DisableNetworking()
+		exg := getOfflineBybit(btcLinear)
+		gock.InterceptClient(exg.HttpClient)
+		// 下次结算时间 2023-11-15 08:00 UTC
+		gock.New("https://api.bybit.com").Get("/v5/market/tickers").Reply(200).
+			BodyString(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[
+{"symbol":"BTCUSDT","fundingRate":"0.0001","markPrice":"30000","indexPrice":"30001","nextFundingTime":"1700035200000"}]}}`)
+		hisList := ""
+		if c.prevTime != "" {
+			hisList = fmt.Sprintf(`{"symbol":"BTCUSDT","fundingRate":"0.0002","fundingRateTimestamp":"%s"}`, c.prevTime)
+		}
+		gock.New("https://api.bybit.com").Get("/v5/market/funding/history").Reply(200).
+			BodyString(fmt.Sprintf(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[%s]}}`, hisList))
+		res, err := exg.FetchFundingRate(btcLinear.Symbol, nil)
+		if err != nil {
+			t.Fatalf("fetch funding rate fail: %v", err)
+		}
+		if res.Interval != c.expIntv {
+			t.Errorf("prev %s expect interval %q, got %q", c.prevTime, c.expIntv, res.Interval)
+		}
+		if res.FundingRate != 0.0001 || res.NextFundingTimestamp != 1700035200000 {
+			t.Errorf("unexpected funding rate: %v %v", res.FundingRate, res.NextFundingTimestamp)
+		}
+		if c.prevTime != "" && res.PrevFundingRate != 0.0002 {
+			t.Errorf("prev funding rate expect 0.0002, got %v", res.PrevFundingRate)
+		}
+	}
+	gock.Off()
+}
@@ -30,21 +30,46 @@ func (e *Bybit) FetchTicker(symbol string, params map[string]interface{}) (*bane
 	return nil, err
 }
 
+/*
+FetchTickerPrice
+获取最新价格，由tickers接口的lastPrice得到；symbol为空时返回市场所有品种
+
+	:see: https://bybit-exchange.github.io/docs/v5/market/tickers
+	:param str [symbol]: unified market symbol
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:returns map[string]float64: symbol: last price
+*/
 func (e *Bybit) FetchTickerPrice(symbol string, params map[string]interface{}) (map[string]float64, *errs.Error) {
-	return nil, errs.NewMsg(errs.CodeNotImplement, "not support FetchTickerPrice")
+	args := utils.SafeParams(params)
+	var marketType string
+	var err *errs.Error
+	if symbol != "" {
+		var market *banexg.Market
+		args, market, err = e.LoadArgsMarket(symbol, params)
+		if err != nil {
+			return nil, err
+		}
+		args["symbol"] = market.ID
+		marketType = market.Type
+	} else {
+		marketType, _, err = e.LoadArgsMarketType(args)
+		if err != nil {
+			return nil, err
+		}
+	}
+	items, err := e.fetchTickers(marketType, args)
+	if err != nil {
+		return nil, err
+	}
+	var result = make(map[string]float64, len(items))
+	for _, it := range items {
+		result[it.Symbol] = it.Last
+	}
+	return result, nil
 }
 
 func (e *Bybit) fetchTickers(marketType string, args map[string]interface{}) ([]*banexg.Ticker, *errs.Error) {
-	switch marketType {
-	case banexg.MarketOption:
-		args["category"] = "option"
-	case banexg.MarketLinear:
-		args["category"] = "linear"
-	case banexg.MarketInverse:
-		args["category"] = "inverse"
-	default:
-		args["category"] = "spot"
-	}
+	args["category"] = getMarketCategory(marketType)
 	method := MethodPublicGetV5MarketTickers
 	tryNum := e.GetRetryNum("FetchTicker", 1)
 	if marketType == banexg.MarketOption {
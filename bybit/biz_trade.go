@@ -0,0 +1,80 @@
+package bybit
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+/*
+FetchTrades
+get the list of most recent trades for a particular symbol
+
+	:see: https://bybit-exchange.github.io/docs/v5/market/recent-trade
+	:param str symbol: unified symbol of the market to fetch trades for
+	:param int [since]: timestamp in ms of the earliest trade to fetch, only used for filter
+	:param int [limit]: the maximum amount of trades to fetch, spot: [1,60], others: [1,1000]
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:returns Trade[]: a list of trade structures, sorted by time asc
+*/
+func (e *Bybit) FetchTrades(symbol string, since int64, limit int, params map[string]interface{}) ([]*banexg.Trade, *errs.Error) {
+	args, market, err := e.LoadArgsMarket(symbol, params)
+	if err != nil {
+		return nil, err
+	}
+	args["symbol"] = market.ID
+	args["category"] = getMarketCategory(market.Type)
+	if limit > 0 {
+		if market.Spot {
+			limit = min(limit, 60)
+		} else {
+			limit = min(limit, 1000)
+		}
+		args["limit"] = limit
+	}
+	tryNum := e.GetRetryNum("FetchTrades", 1)
+	rsp := requestRetry[struct {
+		Category string                   `json:"category"`
+		List     []map[string]interface{} `json:"list"`
+	}](e, MethodPublicGetV5MarketRecentTrade, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var arr = rsp.Result.List
+	var items = make([]*PubTrade, 0, len(arr))
+	err_ := utils.DecodeStructMap(arr, &items, "json")
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var res = make([]*banexg.Trade, 0, len(items))
+	// 接口返回按时间倒序，这里转为正序
+	for i := len(items) - 1; i >= 0; i-- {
+		trade := items[i].ToStdTrade(market.Symbol, arr[i])
+		if since > 0 && trade.Timestamp < since {
+			continue
+		}
+		res = append(res, trade)
+	}
+	return res, nil
+}
+
+func (t *PubTrade) ToStdTrade(symbol string, info map[string]interface{}) *banexg.Trade {
+	price, _ := strconv.ParseFloat(t.Price, 64)
+	amount, _ := strconv.ParseFloat(t.Size, 64)
+	stamp, _ := strconv.ParseInt(t.Time, 10, 64)
+	side := strings.ToLower(t.Side)
+	return &banexg.Trade{
+		ID:        t.ExecId,
+		Symbol:    symbol,
+		Side:      side,
+		Amount:    amount,
+		Price:     price,
+		Cost:      price * amount,
+		Timestamp: stamp,
+		Maker:     side == banexg.OdSideSell,
+		Info:      info,
+	}
+}
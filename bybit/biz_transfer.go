@@ -0,0 +1,214 @@
+package bybit
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/utils"
+)
+
+// unified account type to bybit accountType of unified trading account.
+// for classic account, pass bybit accountType directly, like SPOT or CONTRACT
+var transferAccMap = map[string]string{
+	banexg.MarketSpot:    "UNIFIED",
+	banexg.MarketMargin:  "UNIFIED",
+	banexg.MarketLinear:  "UNIFIED",
+	banexg.MarketInverse: "UNIFIED",
+	banexg.MarketOption:  "UNIFIED",
+	banexg.MarketFunding: "FUND",
+}
+
+var transferAccRevMap = map[string]string{
+	"SPOT":     banexg.MarketSpot,
+	"CONTRACT": banexg.MarketLinear,
+	"OPTION":   banexg.MarketOption,
+	"FUND":     banexg.MarketFunding,
+	"UNIFIED":  banexg.MarketSpot, // 统一账户的历史记录无法区分具体品类，按现货返回
+}
+
+func getTransferAcc(account string) (string, *errs.Error) {
+	if account == "" {
+		return "", errs.NewMsg(errs.CodeParamRequired, "transfer account is required")
+	}
+	if acc, ok := transferAccMap[account]; ok {
+		return acc, nil
+	}
+	acc := strings.ToUpper(account)
+	if _, ok := transferAccRevMap[acc]; ok {
+		return acc, nil
+	}
+	return "", errs.NewMsg(errs.CodeParamInvalid, "unsupported transfer account: %s", account)
+}
+
+func parseTransferAcc(acc string) string {
+	if res, ok := transferAccRevMap[acc]; ok {
+		return res
+	}
+	return strings.ToLower(acc)
+}
+
+func parseTransferStatus(status string) string {
+	switch status {
+	case "SUCCESS":
+		return banexg.TransferStatusOk
+	case "PENDING":
+		return banexg.TransferStatusPending
+	case "FAILED":
+		return banexg.TransferStatusFailed
+	default:
+		return strings.ToLower(status)
+	}
+}
+
+// newTransferID transferId of bybit must be UUID
+func newTransferID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+/*
+Transfer
+transfer currency internally between wallets on the same account
+
+	:see: https://bybit-exchange.github.io/docs/v5/asset/create-inter-transfer
+	:param str code: unified currency code
+	:param float amount: amount to transfer
+	:param str fromAccount: account to transfer from: spot/margin/linear/inverse/option/unified map to UNIFIED, funding to FUND; SPOT/CONTRACT for classic account
+	:param str toAccount: account to transfer to
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param str [params.transferId]: UUID of this transfer, generated if not provided
+	:returns TransferResult: a transfer structure with fromAccount/toAccount as passed in.
+		moves inside the unified wallet (e.g. spot -> linear) succeed without request and with empty ID
+*/
+func (e *Bybit) Transfer(code string, amount float64, fromAccount, toAccount string, params map[string]interface{}) (*banexg.TransferResult, *errs.Error) {
+	if code == "" {
+		return nil, errs.NewMsg(errs.CodeParamRequired, "code is required for Transfer")
+	}
+	if amount <= 0 {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "amount must > 0 for Transfer")
+	}
+	fromAcc, err := getTransferAcc(fromAccount)
+	if err != nil {
+		return nil, err
+	}
+	toAcc, err := getTransferAcc(toAccount)
+	if err != nil {
+		return nil, err
+	}
+	if fromAcc == toAcc {
+		if fromAcc == "UNIFIED" {
+			// 统一账户的现货、杠杆、合约、期权共用一个钱包，无需划转
+			return &banexg.TransferResult{
+				Code:        code,
+				Amount:      amount,
+				FromAccount: fromAccount,
+				ToAccount:   toAccount,
+				Status:      banexg.TransferStatusOk,
+				Timestamp:   e.MilliSeconds(),
+			}, nil
+		}
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "fromAccount and toAccount are same: %s", fromAcc)
+	}
+	args := utils.SafeParams(params)
+	transferId := utils.PopMapVal(args, "transferId", "")
+	if transferId == "" {
+		transferId = newTransferID()
+	}
+	args["transferId"] = transferId
+	args["coin"] = e.GetCurrencyID(code)
+	args["amount"] = strconv.FormatFloat(amount, 'f', -1, 64)
+	args["fromAccountType"] = fromAcc
+	args["toAccountType"] = toAcc
+	tryNum := e.GetRetryNum("Transfer", 1)
+	rsp := requestRetry[map[string]interface{}](e, MethodPrivatePostV5AssetTransferInterTransfer, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	status := banexg.TransferStatusOk
+	if text, ok := rsp.Result["status"].(string); ok && text != "" {
+		status = parseTransferStatus(text)
+	}
+	if id, ok := rsp.Result["transferId"].(string); ok && id != "" {
+		transferId = id
+	}
+	return &banexg.TransferResult{
+		ID:          transferId,
+		Code:        code,
+		Amount:      amount,
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+		Status:      status,
+		Timestamp:   e.MilliSeconds(),
+		Info:        rsp.Result,
+	}, nil
+}
+
+/*
+FetchTransfers
+fetch a history of internal transfers made on an account
+
+	:see: https://bybit-exchange.github.io/docs/v5/asset/inter-transfer-list
+	:param str [code]: unified currency code of the currency transferred
+	:param int [since]: the earliest time in ms to fetch transfers for
+	:param int [limit]: the maximum number of transfers structures to retrieve, max 50
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+	:param int [params.until]: the latest time in ms to fetch transfers for, range should be less than 7 days
+	:returns TransferResult[]: a list of transfer structures
+*/
+func (e *Bybit) FetchTransfers(code string, since int64, limit int, params map[string]interface{}) ([]*banexg.TransferResult, *errs.Error) {
+	args := utils.SafeParams(params)
+	if code != "" {
+		args["coin"] = e.GetCurrencyID(code)
+	}
+	if since > 0 {
+		args["startTime"] = since
+	}
+	until := utils.PopMapVal(args, banexg.ParamUntil, int64(0))
+	if until > 0 {
+		args["endTime"] = until
+	}
+	if limit > 0 {
+		args["limit"] = min(limit, 50)
+	}
+	tryNum := e.GetRetryNum("FetchTransfers", 1)
+	rsp := requestRetry[struct {
+		List           []map[string]interface{} `json:"list"`
+		NextPageCursor string                   `json:"nextPageCursor"`
+	}](e, MethodPrivateGetV5AssetTransferQueryInterTransferList, args, tryNum)
+	if rsp.Error != nil {
+		return nil, rsp.Error
+	}
+	var arr = rsp.Result.List
+	var items = make([]*TransferRow, 0, len(arr))
+	err_ := utils.DecodeStructMap(arr, &items, "json")
+	if err_ != nil {
+		return nil, errs.New(errs.CodeUnmarshalFail, err_)
+	}
+	var res = make([]*banexg.TransferResult, 0, len(items))
+	for i, it := range items {
+		res = append(res, it.ToStdTransfer(e, arr[i]))
+	}
+	return res, nil
+}
+
+func (r *TransferRow) ToStdTransfer(e *Bybit, info map[string]interface{}) *banexg.TransferResult {
+	amount, _ := strconv.ParseFloat(r.Amount, 64)
+	stamp, _ := strconv.ParseInt(r.Timestamp, 10, 64)
+	return &banexg.TransferResult{
+		ID:          r.TransferId,
+		Code:        e.SafeCurrencyCode(r.Coin),
+		Amount:      amount,
+		FromAccount: parseTransferAcc(r.FromAccountType),
+		ToAccount:   parseTransferAcc(r.ToAccountType),
+		Status:      parseTransferStatus(r.Status),
+		Timestamp:   stamp,
+		Info:        info,
+	}
+}
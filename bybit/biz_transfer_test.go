@@ -0,0 +1,43 @@
+package bybit
+
+import (
+	"testing"
+
+	"github.com/banbox/banexg"
+)
+
+func TestGetTransferAcc(t *testing.T) {
+	cases := map[string]string{
+		banexg.MarketSpot:    "UNIFIED",
+		banexg.MarketLinear:  "UNIFIED",
+		banexg.MarketFunding: "FUND",
+		"unified":            "UNIFIED",
+		"CONTRACT":           "CONTRACT",
+	}
+	for account, expect := range cases {
+		res, err := getTransferAcc(account)
+		if err != nil || res != expect {
+			t.Errorf("getTransferAcc %s expect %s, got %s %v", account, expect, res, err)
+		}
+	}
+	if _, err := getTransferAcc("unknown"); err == nil {
+		t.Errorf("getTransferAcc should fail for unknown account")
+	}
+}
+
+func TestTransferUnifiedNoop(t *testing.T) {
+	exg := getOfflineBybit()
+	res, err := exg.Transfer("USDT", 10, banexg.MarketSpot, banexg.MarketLinear, nil)
+	if err != nil {
+		t.Fatalf("Transfer spot->linear should succeed, got %v", err)
+	}
+	if res.Status != banexg.TransferStatusOk || res.FromAccount != banexg.MarketSpot || res.ToAccount != banexg.MarketLinear {
+		t.Errorf("unexpected transfer result: %+v", res)
+	}
+	if acc := parseTransferAcc("UNIFIED"); acc != banexg.MarketSpot {
+		t.Errorf("parseTransferAcc(UNIFIED) = %s, want %s", acc, banexg.MarketSpot)
+	}
+	if _, err = exg.Transfer("USDT", 10, "funding", "funding", nil); err == nil {
+		t.Errorf("Transfer funding->funding should fail")
+	}
+}
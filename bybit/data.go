@@ -3,8 +3,9 @@ package bybit
 import "github.com/banbox/banexg"
 
 const (
-	HostPublic  = "public"
-	HostPrivate = "private"
+	HostPublic    = "public"
+	HostPrivate   = "private"
+	HostWsPrivate = "wsPrivate"
 )
 
 const (
@@ -18,12 +18,22 @@ func New(Options map[string]interface{}) (*Bybit, *errs.Error) {
 			Options:   Options,
 			Hosts: &banexg.ExgHosts{
 				Test: map[string]string{
-					HostPublic:  "https://api-testnet." + hostName,
-					HostPrivate: "https://api-testnet." + hostName,
+					HostPublic:           "https://api-testnet." + hostName,
+					HostPrivate:          "https://api-testnet." + hostName,
+					banexg.MarketSpot:    "wss://stream-testnet." + hostName + "/v5/public/spot",
+					banexg.MarketLinear:  "wss://stream-testnet." + hostName + "/v5/public/linear",
+					banexg.MarketInverse: "wss://stream-testnet." + hostName + "/v5/public/inverse",
+					banexg.MarketOption:  "wss://stream-testnet." + hostName + "/v5/public/option",
+					HostWsPrivate:        "wss://stream-testnet." + hostName + "/v5/private",
 				},
 				Prod: map[string]string{
-					HostPublic:  "https://api." + hostName,
-					HostPrivate: "https://api." + hostName,
+					HostPublic:           "https://api." + hostName,
+					HostPrivate:          "https://api." + hostName,
+					banexg.MarketSpot:    "wss://stream." + hostName + "/v5/public/spot",
+					banexg.MarketLinear:  "wss://stream." + hostName + "/v5/public/linear",
+					banexg.MarketInverse: "wss://stream." + hostName + "/v5/public/inverse",
+					banexg.MarketOption:  "wss://stream." + hostName + "/v5/public/option",
+					HostWsPrivate:        "wss://stream." + hostName + "/v5/private",
 				},
 				Www: "https://www.bybit.com",
 				Doc: []string{
@@ -366,37 +376,57 @@ func New(Options map[string]interface{}) (*Bybit, *errs.Error) {
 			},
 			Has: map[string]map[string]int{
 				"": {
-					banexg.ApiFetchTicker:           banexg.HasOk,
-					banexg.ApiFetchTickers:          banexg.HasOk,
-					banexg.ApiFetchTickerPrice:      banexg.HasFail,
-					banexg.ApiLoadLeverageBrackets:  banexg.HasOk,
-					banexg.ApiFetchCurrencies:       banexg.HasOk,
-					banexg.ApiGetLeverage:           banexg.HasOk,
-					banexg.ApiFetchOHLCV:            banexg.HasOk,
-					banexg.ApiFetchOrderBook:        banexg.HasOk,
-					banexg.ApiFetchOrder:            banexg.HasOk,
-					banexg.ApiFetchOrders:           banexg.HasFail,
-					banexg.ApiFetchBalance:          banexg.HasOk,
-					banexg.ApiFetchAccountPositions: banexg.HasOk,
-					banexg.ApiFetchPositions:        banexg.HasOk,
-					banexg.ApiFetchOpenOrders:       banexg.HasOk,
-					banexg.ApiCreateOrder:           banexg.HasOk,
-					banexg.ApiEditOrder:             banexg.HasOk,
-					banexg.ApiCancelOrder:           banexg.HasOk,
-					banexg.ApiSetLeverage:           banexg.HasOk,
-					banexg.ApiCalcMaintMargin:       banexg.HasOk,
-					banexg.ApiWatchOrderBooks:       banexg.HasOk,
-					banexg.ApiUnWatchOrderBooks:     banexg.HasOk,
-					banexg.ApiWatchOHLCVs:           banexg.HasOk,
-					banexg.ApiUnWatchOHLCVs:         banexg.HasOk,
-					banexg.ApiWatchMarkPrices:       banexg.HasOk,
-					banexg.ApiUnWatchMarkPrices:     banexg.HasOk,
-					banexg.ApiWatchTrades:           banexg.HasOk,
-					banexg.ApiUnWatchTrades:         banexg.HasOk,
-					banexg.ApiWatchMyTrades:         banexg.HasOk,
-					banexg.ApiWatchBalance:          banexg.HasOk,
-					banexg.ApiWatchPositions:        banexg.HasOk,
-					banexg.ApiWatchAccountConfig:    banexg.HasFail,
+					banexg.ApiFetchTicker:                  banexg.HasOk,
+					banexg.ApiFetchTickers:                 banexg.HasOk,
+					banexg.ApiFetchTrades:                  banexg.HasOk,
+					banexg.ApiFetchTickerPrice:             banexg.HasOk,
+					banexg.ApiFetchOpenInterest:            banexg.HasOk,
+					banexg.ApiFetchOpenInterestHistory:     banexg.HasOk,
+					banexg.ApiFetchLongShortRatioHistory:   banexg.HasOk,
+					banexg.ApiFetchTakerVolumeHistory:      banexg.HasFail,
+					banexg.ApiFetchLiquidations:            banexg.HasFail,
+					banexg.ApiLoadLeverageBrackets:         banexg.HasOk,
+					banexg.ApiFetchCurrencies:              banexg.HasOk,
+					banexg.ApiGetLeverage:                  banexg.HasOk,
+					banexg.ApiFetchOHLCV:                   banexg.HasOk,
+					banexg.ApiFetchOrderBook:               banexg.HasOk,
+					banexg.ApiFetchOrder:                   banexg.HasOk,
+					banexg.ApiFetchOrders:                  banexg.HasFail,
+					banexg.ApiFetchBalance:                 banexg.HasOk,
+					banexg.ApiFetchAccountPositions:        banexg.HasOk,
+					banexg.ApiFetchPositions:               banexg.HasOk,
+					banexg.ApiFetchOpenOrders:              banexg.HasOk,
+					banexg.ApiCreateOrder:                  banexg.HasOk,
+					banexg.ApiEditOrder:                    banexg.HasOk,
+					banexg.ApiCancelOrder:                  banexg.HasOk,
+					banexg.ApiCreateOrderBy:                banexg.HasOk,
+					banexg.ApiSetLeverage:                  banexg.HasOk,
+					banexg.ApiSetMarginMode:                banexg.HasOk,
+					banexg.ApiSetPositionMode:              banexg.HasOk,
+					banexg.ApiFetchAccountConfig:           banexg.HasOk,
+					banexg.ApiFetchAccountStatus:           banexg.HasFail,
+					banexg.ApiAddMargin:                    banexg.HasOk,
+					banexg.ApiReduceMargin:                 banexg.HasOk,
+					banexg.ApiFetchMarginAdjustmentHistory: banexg.HasFail,
+					banexg.ApiBorrowMargin:                 banexg.HasFail,
+					banexg.ApiRepayMargin:                  banexg.HasFail,
+					banexg.ApiFetchBorrowRates:             banexg.HasFail,
+					banexg.ApiFetchBorrowInterest:          banexg.HasFail,
+					banexg.ApiTransfer:                     banexg.HasOk,
+					banexg.ApiFetchTransfers:               banexg.HasOk,
+					banexg.ApiCalcMaintMargin:              banexg.HasOk,
+					banexg.ApiWatchOrderBooks:              banexg.HasOk,
+					banexg.ApiUnWatchOrderBooks:            banexg.HasOk,
+					banexg.ApiWatchOHLCVs:                  banexg.HasOk,
+					banexg.ApiUnWatchOHLCVs:                banexg.HasOk,
+					banexg.ApiWatchMarkPrices:              banexg.HasOk,
+					banexg.ApiUnWatchMarkPrices:            banexg.HasOk,
+					banexg.ApiWatchTrades:                  banexg.HasOk,
+					banexg.ApiUnWatchTrades:                banexg.HasOk,
+					banexg.ApiWatchMyTrades:                banexg.HasOk,
+					banexg.ApiWatchBalance:                 banexg.HasOk,
+					banexg.ApiWatchPositions:               banexg.HasOk,
+					banexg.ApiWatchAccountConfig:           banexg.HasFail,
 				},
 			},
 			CredKeys: map[string]bool{"ApiKey": true, "Secret": true},
@@ -405,6 +435,9 @@ func New(Options map[string]interface{}) (*Bybit, *errs.Error) {
 	exg.Sign = makeSign(exg)
 	exg.FetchCurrencies = makeFetchCurr(exg)
 	exg.FetchMarkets = makeFetchMarkets(exg)
+	exg.OnWsMsg = makeHandleWsMsg(exg)
+	exg.OnWsReCon = makeHandleWsReCon(exg)
+	exg.AuthWS = exg.authWs
 	err := exg.Init()
 	return exg, err
 }
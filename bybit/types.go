@@ -2,11 +2,21 @@ package bybit
 
 import (
 	"github.com/banbox/banexg"
+	"github.com/sasha-s/go-deadlock"
 )
 
 type Bybit struct {
 	*banexg.Exchange
-	RecvWindow int // 允许的和服务器最大毫秒时间差
+	RecvWindow       int                           // 允许的和服务器最大毫秒时间差
+	LeverageBrackets map[string]*SymbolLvgBrackets // symbol: Leverage Brackets
+	wsRequestId      int                           // 自增的ws请求ID
+	wsPingClients    map[*banexg.WsClient]bool     // 已启动心跳的ws客户端
+	wsAuthConns      map[string]bool               // clientKey#connID: 私有ws连接鉴权状态，false为等待结果
+	wsAuthJobs       map[string]*wsAuthJob         // req_id: 等待结果的auth请求
+	wsLock           deadlock.Mutex                // for wsRequestId, wsPingClients, wsAuthConns, wsAuthJobs
+	bookSeqs         map[string]int64              // symbol: 订单簿最新的跨深度序号seq，由OdBookLock保护
+	lvgLoaded        map[string]bool               // marketType: 已加载风险限额档位
+	lvgLock          deadlock.Mutex                // for LeverageBrackets, lvgLoaded
 }
 
 /*
@@ -185,8 +195,287 @@ type FutureTicker struct {
 	Basis             string `json:"basis"`
 }
 
+/*
+*****************************   OrderBook   ***********************************
+ */
+
+type OrderBook struct {
+	Symbol    string      `json:"s"`
+	Asks      [][2]string `json:"a"`
+	Bids      [][2]string `json:"b"`
+	TimeStamp int64       `json:"ts"`
+	UpdateID  int64       `json:"u"`
+	Seq       int64       `json:"seq"`
+}
+
 type FundRate struct {
 	Symbol               string `json:"symbol"`
 	FundingRate          string `json:"fundingRate"`
 	FundingRateTimestamp string `json:"fundingRateTimestamp"`
 }
+
+/*
+*****************************   Trades   ***********************************
+ */
+
+type PubTrade struct {
+	ExecId       string `json:"execId"`
+	Symbol       string `json:"symbol"`
+	Price        string `json:"price"`
+	Size         string `json:"size"`
+	Side         string `json:"side"`
+	Time         string `json:"time"`
+	IsBlockTrade bool   `json:"isBlockTrade"`
+}
+
+/*
+WsKline
+kline.{interval}.{symbol} 推送的K线
+*/
+type WsKline struct {
+	Start     int64  `json:"start"`
+	End       int64  `json:"end"`
+	Interval  string `json:"interval"`
+	Open      string `json:"open"`
+	Close     string `json:"close"`
+	High      string `json:"high"`
+	Low       string `json:"low"`
+	Volume    string `json:"volume"`
+	Turnover  string `json:"turnover"`
+	Confirm   bool   `json:"confirm"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+/*
+WsTrade
+publicTrade.{symbol} 推送的公开成交，S为吃单方向
+*/
+type WsTrade struct {
+	Time       int64  `json:"T"`
+	Symbol     string `json:"s"`
+	Side       string `json:"S"`
+	Size       string `json:"v"`
+	Price      string `json:"p"`
+	TradeId    string `json:"i"`
+	BlockTrade bool   `json:"BT"`
+}
+
+/*
+*****************************   Wallet   ***********************************
+ */
+
+type TransferRow struct {
+	TransferId      string `json:"transferId"`
+	Coin            string `json:"coin"`
+	Amount          string `json:"amount"`
+	FromAccountType string `json:"fromAccountType"`
+	ToAccountType   string `json:"toAccountType"`
+	Timestamp       string `json:"timestamp"`
+	Status          string `json:"status"`
+}
+
+type WalletBalance struct {
+	AccountType            string        `json:"accountType"`
+	AccountIMRate          string        `json:"accountIMRate"`
+	AccountMMRate          string        `json:"accountMMRate"`
+	TotalEquity            string        `json:"totalEquity"`
+	TotalWalletBalance     string        `json:"totalWalletBalance"`
+	TotalMarginBalance     string        `json:"totalMarginBalance"` // 保证金余额：抵押品价值+未实现盈亏
+	TotalAvailableBalance  string        `json:"totalAvailableBalance"`
+	TotalPerpUPL           string        `json:"totalPerpUPL"`
+	TotalInitialMargin     string        `json:"totalInitialMargin"`
+	TotalMaintenanceMargin string        `json:"totalMaintenanceMargin"`
+	Coin                   []*WalletCoin `json:"coin"`
+}
+
+type WalletCoin struct {
+	Coin                string `json:"coin"`
+	Equity              string `json:"equity"`
+	UsdValue            string `json:"usdValue"`
+	WalletBalance       string `json:"walletBalance"`
+	Locked              string `json:"locked"` // 现货挂单冻结
+	BorrowAmount        string `json:"borrowAmount"`
+	AccruedInterest     string `json:"accruedInterest"`
+	TotalOrderIM        string `json:"totalOrderIM"`
+	TotalPositionIM     string `json:"totalPositionIM"`
+	TotalPositionMM     string `json:"totalPositionMM"`
+	UnrealisedPnl       string `json:"unrealisedPnl"`
+	CumRealisedPnl      string `json:"cumRealisedPnl"`
+	AvailableToWithdraw string `json:"availableToWithdraw"`
+	MarginCollateral    bool   `json:"marginCollateral"` // 平台是否允许此币种作为保证金
+	CollateralSwitch    bool   `json:"collateralSwitch"` // 用户是否开启此币种作为保证金
+}
+
+/*
+*****************************   OpenInterest   ***********************************
+ */
+
+type OpenInterest struct {
+	OpenInterest string `json:"openInterest"`
+	Timestamp    string `json:"timestamp"`
+}
+
+type AccountRatio struct {
+	Symbol    string `json:"symbol"`
+	BuyRatio  string `json:"buyRatio"`
+	SellRatio string `json:"sellRatio"`
+	Timestamp string `json:"timestamp"`
+}
+
+/*
+*****************************   Position   ***********************************
+ */
+
+type Position struct {
+	PositionIdx    int    `json:"positionIdx"` // 0: one-way, 1: hedge buy side, 2: hedge sell side
+	RiskId         int    `json:"riskId"`
+	RiskLimitValue string `json:"riskLimitValue"`
+	Symbol         string `json:"symbol"`
+	Side           string `json:"side"` // Buy/Sell, empty for no position
+	Size           string `json:"size"`
+	AvgPrice       string `json:"avgPrice"`
+	PositionValue  string `json:"positionValue"`
+	TradeMode      int    `json:"tradeMode"` // 0: cross, 1: isolated
+	AutoAddMargin  int    `json:"autoAddMargin"`
+	PositionStatus string `json:"positionStatus"`
+	Leverage       string `json:"leverage"`
+	MarkPrice      string `json:"markPrice"`
+	LiqPrice       string `json:"liqPrice"`
+	BustPrice      string `json:"bustPrice"`
+	PositionIM     string `json:"positionIM"`
+	PositionMM     string `json:"positionMM"`
+	PositionBal    string `json:"positionBalance"`
+	TakeProfit     string `json:"takeProfit"`
+	StopLoss       string `json:"stopLoss"`
+	TrailingStop   string `json:"trailingStop"`
+	UnrealisedPnl  string `json:"unrealisedPnl"`
+	CurRealisedPnl string `json:"curRealisedPnl"`
+	CumRealisedPnl string `json:"cumRealisedPnl"`
+	IsReduceOnly   bool   `json:"isReduceOnly"`
+	CreatedTime    string `json:"createdTime"`
+	UpdatedTime    string `json:"updatedTime"`
+}
+
+/*
+WsPosition
+私有ws推送的仓位，开仓均价字段为entryPrice
+*/
+type WsPosition struct {
+	Position
+	Category   string `json:"category"`
+	EntryPrice string `json:"entryPrice"`
+}
+
+/*
+RiskLimit
+风险限额档位，Limit为该档位的名义价值上限
+*/
+type RiskLimit struct {
+	ID             int    `json:"id"`
+	Symbol         string `json:"symbol"`
+	Limit          string `json:"limit"`
+	MaintainMargin string `json:"maintainMargin"`
+	InitialMargin  string `json:"initialMargin"`
+	IsLowestRisk   int    `json:"isLowestRisk"`
+	MaxLeverage    string `json:"maxLeverage"`
+}
+
+/*
+LvgBracket
+标准杠杆分层信息，由风险限额档位计算得到
+*/
+type LvgBracket struct {
+	Bracket          int     // 层级
+	MaxLeverage      float64 // 该层允许的最高杠杆倍数
+	MaintMarginRatio float64 // 该层对应的维持保证金率
+	Cum              float64 // 速算数
+	Floor            float64 // 该层对应的名义价值下限
+	Capacity         float64 // 该层对应的名义价值上限
+}
+
+type SymbolLvgBrackets struct {
+	Symbol   string
+	Brackets []*LvgBracket
+}
+
+type AccountInfo struct {
+	UnifiedMarginStatus int    `json:"unifiedMarginStatus"` // 1: classic, 3/4/5/6: unified trade account
+	MarginMode          string `json:"marginMode"`          // ISOLATED_MARGIN, REGULAR_MARGIN, PORTFOLIO_MARGIN
+	IsMasterTrader      bool   `json:"isMasterTrader"`
+	SpotHedgingStatus   string `json:"spotHedgingStatus"`
+	UpdatedTime         string `json:"updatedTime"`
+}
+
+/*
+*****************************   Order   ***********************************
+ */
+
+type Order struct {
+	OrderId            string `json:"orderId"`
+	OrderLinkId        string `json:"orderLinkId"`
+	Symbol             string `json:"symbol"`
+	Price              string `json:"price"`
+	Qty                string `json:"qty"`
+	Side               string `json:"side"` // Buy/Sell
+	IsLeverage         string `json:"isLeverage"`
+	PositionIdx        int    `json:"positionIdx"`
+	OrderStatus        string `json:"orderStatus"`
+	CancelType         string `json:"cancelType"`
+	RejectReason       string `json:"rejectReason"`
+	AvgPrice           string `json:"avgPrice"`
+	LeavesQty          string `json:"leavesQty"`
+	LeavesValue        string `json:"leavesValue"`
+	CumExecQty         string `json:"cumExecQty"`
+	CumExecValue       string `json:"cumExecValue"`
+	CumExecFee         string `json:"cumExecFee"`
+	TimeInForce        string `json:"timeInForce"` // GTC/IOC/FOK/PostOnly
+	OrderType          string `json:"orderType"`   // Market/Limit
+	StopOrderType      string `json:"stopOrderType"`
+	TriggerPrice       string `json:"triggerPrice"`
+	TakeProfit         string `json:"takeProfit"`
+	StopLoss           string `json:"stopLoss"`
+	TriggerDirection   int    `json:"triggerDirection"` // 1: rise, 2: fall
+	TriggerBy          string `json:"triggerBy"`
+	LastPriceOnCreated string `json:"lastPriceOnCreated"`
+	ReduceOnly         bool   `json:"reduceOnly"`
+	CloseOnTrigger     bool   `json:"closeOnTrigger"`
+	CreatedTime        string `json:"createdTime"`
+	UpdatedTime        string `json:"updatedTime"`
+}
+
+type WsOrder struct {
+	Order
+	Category string `json:"category"`
+}
+
+/*
+Execution
+成交记录，私有ws的execution推送
+*/
+type Execution struct {
+	Category    string `json:"category"`
+	Symbol      string `json:"symbol"`
+	ExecId      string `json:"execId"`
+	ExecPrice   string `json:"execPrice"`
+	ExecQty     string `json:"execQty"`
+	ExecValue   string `json:"execValue"`
+	ExecFee     string `json:"execFee"`
+	FeeCurrency string `json:"feeCurrency"` // 仅现货
+	FeeRate     string `json:"feeRate"`
+	ExecType    string `json:"execType"` // Trade/Funding/BustTrade/Settle...
+	ExecTime    string `json:"execTime"`
+	IsMaker     bool   `json:"isMaker"`
+	OrderId     string `json:"orderId"`
+	OrderLinkId string `json:"orderLinkId"`
+	OrderPrice  string `json:"orderPrice"`
+	OrderQty    string `json:"orderQty"`
+	LeavesQty   string `json:"leavesQty"`
+	OrderType   string `json:"orderType"`
+	Side        string `json:"side"`
+	ClosedSize  string `json:"closedSize"`
+}
+
+type OrderIdRes struct {
+	OrderId     string `json:"orderId"`
+	OrderLinkId string `json:"orderLinkId"`
+}
@@ -0,0 +1,586 @@
+package bybit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/log"
+	"github.com/banbox/banexg/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	wsAuthExpireMS = 10000            // ws鉴权签名的有效期
+	wsAuthTimeout  = time.Second * 10 // 等待鉴权结果的超时时间
+)
+
+/*
+wsAuthJob
+已发送等待结果的auth请求，收到结果后才标记连接为已鉴权
+*/
+type wsAuthJob struct {
+	client  *banexg.WsClient
+	authKey string
+	done    chan *errs.Error
+}
+
+/*
+authWs
+私有ws鉴权，对账户私有ws客户端的所有连接发送auth请求并等待结果，已鉴权的连接跳过。
+签名为HMAC_SHA256("GET/realtime" + expires)
+*/
+func (e *Bybit) authWs(acc *banexg.Account, params map[string]interface{}) *errs.Error {
+	marketType, _ := e.GetArgsMarketType(utils.SafeParams(params), "")
+	client, err := e.GetClient(e.GetHost(HostWsPrivate), marketType, acc.Name)
+	if err != nil {
+		return err
+	}
+	conns, lock := client.LockConns()
+	connList := utils.ValsOfMap(conns)
+	lock.Unlock()
+	for _, conn := range connList {
+		err = e.authWsConn(client, conn, true)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+authWsConn
+发送auth请求，已发送或已鉴权的连接跳过。wait为true时等待服务器返回结果；
+重连时在读取协程中调用，不能等待，鉴权失败时由handleWsAuthRsp清除标记，下次调用重新鉴权
+*/
+func (e *Bybit) authWsConn(client *banexg.WsClient, conn *banexg.AsyncConn, wait bool) *errs.Error {
+	authKey := fmt.Sprintf("%s#%d", client.Key, conn.GetID())
+	e.wsLock.Lock()
+	_, done := e.wsAuthConns[authKey]
+	e.wsLock.Unlock()
+	if done {
+		return nil
+	}
+	_, creds, err := e.GetAccountCreds(client.AccName)
+	if err != nil {
+		return err
+	}
+	expires := e.Nonce() + wsAuthExpireMS
+	payload := "GET/realtime" + strconv.FormatInt(expires, 10)
+	sign, err := utils.Signature(payload, creds.Secret, "hmac", "sha256", "hex")
+	if err != nil {
+		return err
+	}
+	reqId := e.nextReqId()
+	job := &wsAuthJob{client: client, authKey: authKey, done: make(chan *errs.Error, 1)}
+	e.wsLock.Lock()
+	// false表示已发送auth请求，尚未收到结果
+	e.wsAuthConns[authKey] = false
+	e.wsAuthJobs[reqId] = job
+	e.wsLock.Unlock()
+	err = client.Write(conn, map[string]interface{}{
+		"op":     "auth",
+		"req_id": reqId,
+		"args":   []interface{}{creds.ApiKey, expires, sign},
+	}, nil)
+	if err != nil {
+		e.popWsAuthJob(client, reqId, false)
+		return err
+	}
+	if !wait {
+		return nil
+	}
+	timer := time.NewTimer(wsAuthTimeout)
+	defer timer.Stop()
+	select {
+	case err = <-job.done:
+		return err
+	case <-timer.C:
+		e.popWsAuthJob(client, reqId, false)
+		return errs.NewMsg(errs.CodeNetFail, "bybit ws auth timeout, acc: %s", client.AccName)
+	}
+}
+
+/*
+popWsAuthJob
+移除等待结果的auth请求，ok为true时标记连接为已鉴权，否则清除标记以便下次重新鉴权。
+返回的响应中没有req_id时，使用此客户端任一等待中的请求
+*/
+func (e *Bybit) popWsAuthJob(client *banexg.WsClient, reqId string, ok bool) *wsAuthJob {
+	e.wsLock.Lock()
+	defer e.wsLock.Unlock()
+	job, has := e.wsAuthJobs[reqId]
+	if !has {
+		for id, j := range e.wsAuthJobs {
+			if j.client == client {
+				job, reqId, has = j, id, true
+				break
+			}
+		}
+	}
+	if !has {
+		return nil
+	}
+	delete(e.wsAuthJobs, reqId)
+	if ok {
+		e.wsAuthConns[job.authKey] = true
+	} else {
+		delete(e.wsAuthConns, job.authKey)
+	}
+	return job
+}
+
+/*
+handleWsAuthRsp
+处理auth请求的结果，通知等待的调用方
+*/
+func (e *Bybit) handleWsAuthRsp(client *banexg.WsClient, msg map[string]string) {
+	ok := msg["success"] == "true"
+	job := e.popWsAuthJob(client, msg["req_id"], ok)
+	if ok {
+		log.Debug("ws auth ok", zap.String("acc", client.AccName))
+		if job != nil {
+			job.done <- nil
+		}
+		return
+	}
+	err := errs.NewMsg(errs.CodeAccKeyError, "bybit ws auth fail: %s", msg["ret_msg"])
+	log.Error("ws auth fail", zap.String("acc", client.AccName), zap.String("url", client.URL),
+		zap.String("msg", msg["ret_msg"]))
+	if job != nil {
+		job.done <- err
+	}
+}
+
+/*
+resetWsAuth
+连接重连后需要重新鉴权
+*/
+func (e *Bybit) resetWsAuth(client *banexg.WsClient, connID int) {
+	if client.AccName == "" {
+		return
+	}
+	authKey := fmt.Sprintf("%s#%d", client.Key, connID)
+	e.wsLock.Lock()
+	delete(e.wsAuthConns, authKey)
+	for id, job := range e.wsAuthJobs {
+		if job.authKey == authKey {
+			delete(e.wsAuthJobs, id)
+		}
+	}
+	e.wsLock.Unlock()
+}
+
+/*
+getAuthClient
+返回账户的私有ws客户端，所有品类共用一个私有连接
+*/
+func (e *Bybit) getAuthClient(params map[string]interface{}) (*banexg.WsClient, *errs.Error) {
+	_, err := e.LoadMarkets(false, nil)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := e.GetAccount(e.GetAccName(params))
+	if err != nil {
+		return nil, err
+	}
+	args := utils.SafeParams(params)
+	marketType, _ := e.GetArgsMarketType(args, "")
+	client, err := e.GetClient(e.GetHost(HostWsPrivate), marketType, acc.Name)
+	if err != nil {
+		return nil, err
+	}
+	e.keepWsAlive(client)
+	err = e.AuthWS(acc, args)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+/*
+subPrivate
+订阅私有topic，已订阅的跳过
+*/
+func (e *Bybit) subPrivate(client *banexg.WsClient, topics ...string) *errs.Error {
+	var keys = make([]string, 0, len(topics))
+	for _, t := range topics {
+		if !client.HasSubsKey(t) {
+			keys = append(keys, t)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return e.WriteWSMsg(client, 0, true, keys)
+}
+
+/*
+WatchBalance
+watch balance and get the amount of funds available for trading or funds locked in orders
+
+	:see: https://bybit-exchange.github.io/docs/v5/websocket/private/wallet
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+*/
+func (e *Bybit) WatchBalance(params map[string]interface{}) (chan *banexg.Balances, *errs.Error) {
+	client, err := e.getAuthClient(params)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := e.FetchBalance(params)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := e.GetAccount(client.AccName)
+	if err != nil {
+		return nil, err
+	}
+	acc.LockBalance.Lock()
+	acc.MarBalances[client.MarketType] = balances
+	acc.LockBalance.Unlock()
+	err = e.subPrivate(client, "wallet")
+	if err != nil {
+		return nil, err
+	}
+	args := utils.SafeParams(params)
+	chanKey := client.Prefix("balance")
+	create := func(cap int) chan *banexg.Balances { return make(chan *banexg.Balances, cap) }
+	out := banexg.GetWsOutChan(e.Exchange, chanKey, create, args)
+	e.AddWsChanRefs(chanKey, "account")
+	out <- balances
+	return out, nil
+}
+
+/*
+WatchPositions
+watch all open positions of linear/inverse contracts
+
+	:see: https://bybit-exchange.github.io/docs/v5/websocket/private/position
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+*/
+func (e *Bybit) WatchPositions(params map[string]interface{}) (chan []*banexg.Position, *errs.Error) {
+	client, err := e.getAuthClient(params)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := e.GetAccount(client.AccName)
+	if err != nil {
+		return nil, err
+	}
+	args := utils.SafeParams(params)
+	marketType, _, err := e.LoadArgsMarketType(args)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := e.FetchPositions(nil, params)
+	if err != nil {
+		return nil, err
+	}
+	acc.LockPos.Lock()
+	acc.MarPositions[marketType] = positions
+	acc.LockPos.Unlock()
+	err = e.subPrivate(client, "position")
+	if err != nil {
+		return nil, err
+	}
+	chanKey := client.Prefix("positions")
+	create := func(cap int) chan []*banexg.Position { return make(chan []*banexg.Position, cap) }
+	out := banexg.GetWsOutChan(e.Exchange, chanKey, create, args)
+	e.AddWsChanRefs(chanKey, "account")
+	out <- positions
+	return out, nil
+}
+
+/*
+WatchMyTrades
+订阅订单状态变化和成交。成交只从execution推送输出，每笔包含成交数量和手续费；
+order推送只输出新建、撤销、拒绝等无成交的状态变化，不含成交数量和手续费
+
+	:see: https://bybit-exchange.github.io/docs/v5/websocket/private/order
+	:see: https://bybit-exchange.github.io/docs/v5/websocket/private/execution
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+*/
+func (e *Bybit) WatchMyTrades(params map[string]interface{}) (chan *banexg.MyTrade, *errs.Error) {
+	client, err := e.getAuthClient(params)
+	if err != nil {
+		return nil, err
+	}
+	err = e.subPrivate(client, "order", "execution")
+	if err != nil {
+		return nil, err
+	}
+	args := utils.SafeParams(params)
+	chanKey := client.Prefix("mytrades")
+	create := func(cap int) chan *banexg.MyTrade { return make(chan *banexg.MyTrade, cap) }
+	out := banexg.GetWsOutChan(e.Exchange, chanKey, create, args)
+	e.AddWsChanRefs(chanKey, "account")
+	return out, nil
+}
+
+/*
+parseCategory
+v5 category转为市场类型，为空时使用defType
+*/
+func parseCategory(category, defType string) string {
+	switch category {
+	case "spot":
+		return banexg.MarketSpot
+	case "linear":
+		return banexg.MarketLinear
+	case "inverse":
+		return banexg.MarketInverse
+	case "option":
+		return banexg.MarketOption
+	default:
+		return defType
+	}
+}
+
+/*
+handleWallet
+钱包推送包含账户全部币种，直接替换余额
+*/
+func (e *Bybit) handleWallet(client *banexg.WsClient, msg map[string]string) {
+	acc, err := e.GetAccount(client.AccName)
+	if err != nil {
+		log.Error("account for ws not found", zap.String("name", client.AccName))
+		return
+	}
+	text, _ := msg["data"]
+	var list = make([]map[string]interface{}, 0)
+	err_ := utils.UnmarshalString(text, &list, utils.JsonNumDefault)
+	if err_ != nil {
+		log.Error("unmarshal wallet update fail", zap.String("text", text), zap.Error(err_))
+		return
+	}
+	balances, err := parseWalletBalance(e, list)
+	if err != nil {
+		log.Error("parse wallet update fail", zap.Error(err))
+		return
+	}
+	balances.TimeStamp, _ = utils.SafeMapVal(msg, "creationTime", int64(0))
+	acc.LockBalance.Lock()
+	acc.MarBalances[client.MarketType] = balances
+	acc.LockBalance.Unlock()
+	banexg.WriteOutChan(e.Exchange, client.Prefix("balance"), balances, true)
+}
+
+/*
+wsPosKey
+按positionIdx区分仓位：单向持仓为symbol#0，双向持仓多空分别为1、2
+*/
+func wsPosKey(p *banexg.Position) string {
+	idx := 0
+	if p.Hedged {
+		idx = getPositionIdx(p.Side)
+	}
+	return fmt.Sprintf("%s#%d", p.Symbol, idx)
+}
+
+/*
+handlePositions
+更新合约持仓，推送中仓位数量为0表示已平仓
+*/
+func (e *Bybit) handlePositions(client *banexg.WsClient, msg map[string]string) {
+	acc, err := e.GetAccount(client.AccName)
+	if err != nil {
+		log.Error("account for ws client not found", zap.String("name", client.AccName))
+		return
+	}
+	text, _ := msg["data"]
+	var items = make([]*WsPosition, 0)
+	infos, err_ := utils.UnmarshalStringMapArr(text, &items)
+	if err_ != nil {
+		log.Error("unmarshal position update fail", zap.String("text", text), zap.Error(err_))
+		return
+	}
+	evtTime, _ := utils.SafeMapVal(msg, "creationTime", int64(0))
+	var leverages = make(map[string]int)
+	var updates = make(map[string][]*banexg.Position)
+	for i, p := range items {
+		marketType := parseCategory(p.Category, client.MarketType)
+		market := e.GetMarketById(p.Symbol, marketType)
+		if market == nil {
+			log.Warn("no market for ws position", zap.String("symbol", p.Symbol))
+			continue
+		}
+		if p.AvgPrice == "" {
+			p.AvgPrice = p.EntryPrice
+		}
+		pos := p.ToStdPos(market, infos[i])
+		if pos.Hedged && pos.Side == banexg.PosSideBoth {
+			// 双向持仓平仓后side为空，按positionIdx确定方向
+			if p.PositionIdx == 1 {
+				pos.Side = banexg.PosSideLong
+			} else {
+				pos.Side = banexg.PosSideShort
+			}
+		}
+		if pos.TimeStamp == 0 {
+			pos.TimeStamp = evtTime
+		}
+		if pos.Leverage > 0 {
+			leverages[market.Symbol] = pos.Leverage
+		}
+		updates[marketType] = append(updates[marketType], pos)
+	}
+	e.setAccLeverages(acc.Name, leverages)
+	for marketType, posList := range updates {
+		acc.LockPos.Lock()
+		posMap := make(map[string]*banexg.Position)
+		for _, p := range acc.MarPositions[marketType] {
+			posMap[wsPosKey(p)] = p
+		}
+		for _, p := range posList {
+			posMap[wsPosKey(p)] = p
+		}
+		positions := make([]*banexg.Position, 0, len(posMap))
+		for _, p := range posMap {
+			if p.Contracts == 0 {
+				continue
+			}
+			positions = append(positions, p)
+		}
+		acc.MarPositions[marketType] = positions
+		acc.LockPos.Unlock()
+		banexg.WriteOutChan(e.Exchange, client.Prefix("positions"), positions, true)
+	}
+}
+
+/*
+handleOrderUpdate
+订单状态变化，部分成交和完全成交由execution输出，这里跳过；
+其他状态输出的MyTrade不含成交数量和手续费，避免与execution重复计算
+*/
+func (e *Bybit) handleOrderUpdate(client *banexg.WsClient, msg map[string]string) {
+	text, _ := msg["data"]
+	var items = make([]*WsOrder, 0)
+	infos, err := utils.UnmarshalStringMapArr(text, &items)
+	if err != nil {
+		log.Error("unmarshal order update fail", zap.String("text", text), zap.Error(err))
+		return
+	}
+	chanKey := client.Prefix("mytrades")
+	for i, o := range items {
+		marketType := parseCategory(o.Category, client.MarketType)
+		if e.GetMarketById(o.Symbol, marketType) == nil {
+			log.Error("no market found for my trade", zap.String("symbol", o.Symbol))
+			continue
+		}
+		od := o.ToStdOrder(e, marketType, infos[i])
+		if od.Status == banexg.OdStatusPartFilled || od.Status == banexg.OdStatusFilled {
+			continue
+		}
+		var feeCurr string
+		if od.Fee != nil {
+			feeCurr = od.Fee.Currency
+		}
+		res := &banexg.MyTrade{
+			Trade: banexg.Trade{
+				Symbol:    od.Symbol,
+				Side:      od.Side,
+				Type:      od.Type,
+				Price:     od.Price,
+				Order:     od.ID,
+				Timestamp: od.LastUpdateTimestamp,
+				Fee:       &banexg.Fee{Currency: feeCurr},
+			},
+			ClientID:   od.ClientOrderID,
+			State:      od.Status,
+			PosSide:    od.PositionSide,
+			ReduceOnly: od.ReduceOnly,
+			Info:       infos[i],
+		}
+		banexg.WriteOutChan(e.Exchange, chanKey, res, false)
+	}
+}
+
+/*
+handleExecution
+每笔成交输出一个MyTrade，资金费、交割结算等非交易记录跳过
+*/
+func (e *Bybit) handleExecution(client *banexg.WsClient, msg map[string]string) {
+	text, _ := msg["data"]
+	var items = make([]*Execution, 0)
+	infos, err := utils.UnmarshalStringMapArr(text, &items)
+	if err != nil {
+		log.Error("unmarshal execution fail", zap.String("text", text), zap.Error(err))
+		return
+	}
+	chanKey := client.Prefix("mytrades")
+	for i, it := range items {
+		if it.ExecType == "Funding" || it.ExecType == "Settle" {
+			continue
+		}
+		marketType := parseCategory(it.Category, client.MarketType)
+		market := e.GetMarketById(it.Symbol, marketType)
+		if market == nil {
+			log.Error("no market found for my trade", zap.String("symbol", it.Symbol))
+			continue
+		}
+		res := it.ToStdMyTrade(e, market, infos[i])
+		banexg.WriteOutChan(e.Exchange, chanKey, res, false)
+	}
+}
+
+func (t *Execution) ToStdMyTrade(e *Bybit, market *banexg.Market, info map[string]interface{}) *banexg.MyTrade {
+	price, _ := strconv.ParseFloat(t.ExecPrice, 64)
+	amount, _ := strconv.ParseFloat(t.ExecQty, 64)
+	cost, _ := strconv.ParseFloat(t.ExecValue, 64)
+	feeCost, _ := strconv.ParseFloat(t.ExecFee, 64)
+	orderQty, _ := strconv.ParseFloat(t.OrderQty, 64)
+	leavesQty, _ := strconv.ParseFloat(t.LeavesQty, 64)
+	stamp, _ := strconv.ParseInt(t.ExecTime, 10, 64)
+	if cost == 0 {
+		cost = price * amount
+	}
+	side := strings.ToLower(t.Side)
+	feeCurr := t.FeeCurrency
+	if feeCurr != "" {
+		feeCurr = e.SafeCurrencyCode(feeCurr)
+	} else if market.Contract {
+		feeCurr = market.Settle
+	} else if side == banexg.OdSideBuy {
+		feeCurr = market.Base
+	} else {
+		feeCurr = market.Quote
+	}
+	state := banexg.OdStatusPartFilled
+	if leavesQty == 0 {
+		state = banexg.OdStatusFilled
+	}
+	res := &banexg.MyTrade{
+		Trade: banexg.Trade{
+			ID:        t.ExecId,
+			Symbol:    market.Symbol,
+			Side:      side,
+			Type:      strings.ToLower(t.OrderType),
+			Amount:    amount,
+			Price:     price,
+			Cost:      cost,
+			Order:     t.OrderId,
+			Timestamp: stamp,
+			Maker:     t.IsMaker,
+			Fee: &banexg.Fee{
+				IsMaker:   t.IsMaker,
+				Currency:  feeCurr,
+				Cost:      feeCost,
+				QuoteCost: feeCost,
+			},
+		},
+		Filled:   orderQty - leavesQty,
+		ClientID: t.OrderLinkId,
+		State:    state,
+		Info:     info,
+	}
+	if feeCurr == market.Base {
+		res.Fee.QuoteCost *= price
+	}
+	if rate, err := strconv.ParseFloat(t.FeeRate, 64); err == nil {
+		res.Fee.Rate = rate
+	}
+	return res
+}
@@ -0,0 +1,178 @@
+package bybit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/errs"
+	"github.com/sasha-s/go-deadlock"
+)
+
+func TestHandleOrderUpdateSkipFills(t *testing.T) {
+	exg := getOfflineBybit(btcLinear)
+	client := getOfflineClient(exg, banexg.MarketLinear)
+	out := banexg.GetWsOutChan(exg.Exchange, client.Prefix("mytrades"),
+		func(cap int) chan *banexg.MyTrade { return make(chan *banexg.MyTrade, cap) }, nil)
+	exg.handleOrderUpdate(client, map[string]string{"data": `[
+{"category":"linear","symbol":"BTCUSDT","orderId":"1","side":"Buy","orderType":"Limit","price":"30000","qty":"0.02","orderStatus":"New","cumExecQty":"0","updatedTime":"1700000000000"},
+{"category":"linear","symbol":"BTCUSDT","orderId":"1","side":"Buy","orderType":"Limit","price":"30000","qty":"0.02","orderStatus":"PartiallyFilled","cumExecQty":"0.01","avgPrice":"30000","updatedTime":"1700000001000"},
+{"category":"linear","symbol":"BTCUSDT","orderId":"1","side":"Buy","orderType":"Limit","price":"30000","qty":"0.02","orderStatus":"Filled","cumExecQty":"0.02","avgPrice":"30000","updatedTime":"1700000002000"},
+{"category":"linear","symbol":"BTCUSDT","orderId":"2","side":"Sell","orderType":"Limit","price":"31000","qty":"0.01","orderStatus":"Cancelled","cumExecQty":"0.005","avgPrice":"31000","updatedTime":"1700000003000"}
+]`})
+	exg.handleExecution(client, map[string]string{"data": `[
+{"category":"linear","symbol":"BTCUSDT","execId":"e1","orderId":"1","side":"Buy","orderType":"Limit","execType":"Trade","execPrice":"30000","execQty":"0.01","execFee":"0.18","orderQty":"0.02","leavesQty":"0.01","execTime":"1700000001000"},
+{"category":"linear","symbol":"BTCUSDT","execId":"e2","orderId":"1","side":"Buy","orderType":"Limit","execType":"Trade","execPrice":"30000","execQty":"0.01","execFee":"0.18","orderQty":"0.02","leavesQty":"0","execTime":"1700000002000"},
+{"category":"linear","symbol":"BTCUSDT","execId":"e3","orderId":"","side":"Buy","execType":"Funding","execPrice":"30000","execQty":"0.02","execFee":"0.01","execTime":"1700000004000"}
+]`})
+	close(out)
+	var states []string
+	var filled float64 // 各推送的成交数量之和
+	for tr := range out {
+		states = append(states, tr.State)
+		filled += tr.Amount
+	}
+	expect := []string{banexg.OdStatusOpen, banexg.OdStatusCanceled, banexg.OdStatusPartFilled, banexg.OdStatusFilled}
+	if len(states) != len(expect) {
+		t.Fatalf("expect states %v, got %v", expect, states)
+	}
+	for i, s := range expect {
+		if states[i] != s {
+			t.Errorf("trade %d expect state %s, got %s", i, s, states[i])
+		}
+	}
+	if filled != 0.02 {
+		t.Errorf("fill amount should only come from executions, expect 0.02, got %v", filled)
+	}
+}
+
+func TestHandlePositionsMerge(t *testing.T) {
+	exg := getOfflineBybit(btcLinear)
+	exg.Accounts = map[string]*banexg.Account{
+		"user1": {
+			Name:         "user1",
+			MarPositions: map[string][]*banexg.Position{},
+			Leverages:    map[string]int{},
+			LockPos:      &deadlock.Mutex{},
+			LockLeverage: &deadlock.Mutex{},
+		},
+	}
+	client := getOfflineClient(exg, banexg.MarketLinear)
+	client.AccName = "user1"
+	out := banexg.GetWsOutChan(exg.Exchange, client.Prefix("positions"),
+		func(cap int) chan []*banexg.Position { return make(chan []*banexg.Position, cap) }, nil)
+	// 双向持仓：多空各一个仓位
+	exg.handlePositions(client, map[string]string{"creationTime": "1700000000000", "data": `[
+{"category":"linear","symbol":"BTCUSDT","positionIdx":1,"side":"Buy","size":"0.02","avgPrice":"30000","leverage":"10"},
+{"category":"linear","symbol":"BTCUSDT","positionIdx":2,"side":"Sell","size":"0.01","avgPrice":"31000","leverage":"10"}
+]`})
+	// 只推送空头变化，多头应保留
+	exg.handlePositions(client, map[string]string{"creationTime": "1700000001000", "data": `[
+{"category":"linear","symbol":"BTCUSDT","positionIdx":2,"side":"Sell","size":"0.03","avgPrice":"30500","leverage":"10"}
+]`})
+	// 空头平仓后side为空，按positionIdx移除空头
+	exg.handlePositions(client, map[string]string{"creationTime": "1700000002000", "data": `[
+{"category":"linear","symbol":"BTCUSDT","positionIdx":2,"side":"","size":"0","avgPrice":"0","leverage":"10"}
+]`})
+	close(out)
+	var pushes [][]*banexg.Position
+	for posList := range out {
+		pushes = append(pushes, posList)
+	}
+	if len(pushes) != 3 {
+		t.Fatalf("expect 3 position pushes, got %d", len(pushes))
+	}
+	sizeOf := func(posList []*banexg.Position) map[string]float64 {
+		res := make(map[string]float64)
+		for _, p := range posList {
+			res[p.Side] = p.Contracts
+		}
+		return res
+	}
+	expects := []map[string]float64{
+		{banexg.PosSideLong: 0.02, banexg.PosSideShort: 0.01},
+		{banexg.PosSideLong: 0.02, banexg.PosSideShort: 0.03},
+		{banexg.PosSideLong: 0.02},
+	}
+	for i, exp := range expects {
+		got := sizeOf(pushes[i])
+		if len(got) != len(exp) {
+			t.Errorf("push %d expect %v, got %v", i, exp, got)
+			continue
+		}
+		for side, size := range exp {
+			if got[side] != size {
+				t.Errorf("push %d expect %s size %v, got %v", i, side, size, got[side])
+			}
+		}
+	}
+	acc := exg.Accounts["user1"]
+	if len(acc.MarPositions[banexg.MarketLinear]) != 1 {
+		t.Errorf("account should keep 1 position, got %d", len(acc.MarPositions[banexg.MarketLinear]))
+	}
+	if acc.Leverages[btcLinear.Symbol] != 10 {
+		t.Errorf("leverage should be 10, got %d", acc.Leverages[btcLinear.Symbol])
+	}
+}
+
+func TestExecutionFeeCurrency(t *testing.T) {
+	exg := getOfflineBybit(btcLinear, btcSpot)
+	cases := []struct {
+		market   *banexg.Market
+		side     string
+		feeCurr  string
+		fee      string
+		expCurr  string
+		expQuote float64
+	}{
+		{btcLinear, "Buy", "", "0.1", "USDT", 0.1},
+		{btcSpot, "Buy", "", "0.0001", "BTC", 3},
+		{btcSpot, "Sell", "", "0.1", "USDT", 0.1},
+		{btcSpot, "Sell", "BTC", "0.0001", "BTC", 3},
+	}
+	for _, c := range cases {
+		it := &Execution{Symbol: c.market.ID, Side: c.side, FeeCurrency: c.feeCurr, ExecPrice: "30000",
+			ExecQty: "0.01", ExecFee: c.fee, OrderQty: "0.01", LeavesQty: "0"}
+		res := it.ToStdMyTrade(exg, c.market, nil)
+		if res.Fee.Currency != c.expCurr {
+			t.Errorf("%s %s fee currency expect %s, got %s", c.market.Symbol, c.side, c.expCurr, res.Fee.Currency)
+		}
+		if math.Abs(res.Fee.QuoteCost-c.expQuote) > 1e-6 {
+			t.Errorf("%s %s fee quote cost expect %v, got %v", c.market.Symbol, c.side, c.expQuote, res.Fee.QuoteCost)
+		}
+		if res.State != banexg.OdStatusFilled {
+			t.Errorf("%s %s state expect filled, got %s", c.market.Symbol, c.side, res.State)
+		}
+	}
+}
+
+func TestHandleWsAuthRsp(t *testing.T) {
+	exg := getOfflineBybit()
+	client := getOfflineClient(exg, banexg.MarketLinear)
+	client.Key = "user1"
+	addJob := func(reqId string) *wsAuthJob {
+		job := &wsAuthJob{client: client, authKey: "user1#1", done: make(chan *errs.Error, 1)}
+		exg.wsAuthConns[job.authKey] = false
+		exg.wsAuthJobs[reqId] = job
+		return job
+	}
+	// 鉴权失败时清除标记，下次重新鉴权
+	job := addJob("1")
+	exg.handleWsAuthRsp(client, map[string]string{"op": "auth", "req_id": "1", "success": "false",
+		"ret_msg": "Invalid apikey"})
+	if err := <-job.done; err == nil {
+		t.Errorf("auth fail should return error")
+	}
+	if _, ok := exg.wsAuthConns["user1#1"]; ok {
+		t.Errorf("auth mark should be removed after fail")
+	}
+	// 响应中没有req_id时匹配此客户端等待中的请求
+	job = addJob("2")
+	exg.handleWsAuthRsp(client, map[string]string{"op": "auth", "success": "true"})
+	if err := <-job.done; err != nil {
+		t.Errorf("auth ok should not return error: %v", err)
+	}
+	if ok := exg.wsAuthConns["user1#1"]; !ok || len(exg.wsAuthJobs) != 0 {
+		t.Errorf("conn should be marked authed, got %v %v", exg.wsAuthConns, exg.wsAuthJobs)
+	}
+}
@@ -0,0 +1,843 @@
+package bybit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/bntp"
+	"github.com/banbox/banexg/errs"
+	"github.com/banbox/banexg/log"
+	"github.com/banbox/banexg/utils"
+	"go.uber.org/zap"
+)
+
+var (
+	wsPingIntv  = time.Second * 20 // bybit建议每20秒发送一次ping保持连接
+	wsBatchSize = 10               // 现货每次订阅最多10个参数
+	// 各市场支持的订单簿推送深度
+	wsBookDepths = map[string][]int{
+		banexg.MarketSpot:    {1, 50, 200, 1000},
+		banexg.MarketLinear:  {1, 50, 200, 1000},
+		banexg.MarketInverse: {1, 50, 200, 1000},
+		banexg.MarketOption:  {25, 100},
+	}
+	wsKlineMins = []int{1, 3, 5, 15, 30, 60, 120, 240, 360, 720}
+)
+
+type wsBookSub struct {
+	Symbols []string `json:"symbols"`
+	Limit   int      `json:"limit"`
+}
+
+func makeHandleWsMsg(e *Bybit) banexg.FuncOnWsMsg {
+	return func(client *banexg.WsClient, item *banexg.WsMsg) {
+		msg := item.Object
+		topic, _ := msg["topic"]
+		if topic == "" {
+			e.handleWsOpRsp(client, item)
+			return
+		}
+		name := strings.SplitN(topic, ".", 2)[0]
+		switch name {
+		case "orderbook":
+			e.handleOrderBook(client, msg)
+		case "kline":
+			e.handleOHLCV(client, msg)
+		case "publicTrade":
+			e.handleTrade(client, msg)
+		case "tickers":
+			e.handleTickers(client, msg)
+		case "wallet":
+			e.handleWallet(client, msg)
+		case "position":
+			e.handlePositions(client, msg)
+		case "order":
+			e.handleOrderUpdate(client, msg)
+		case "execution":
+			e.handleExecution(client, msg)
+		default:
+			log.Warn("unhandle ws msg", zap.String("msg", item.Text))
+		}
+	}
+}
+
+/*
+handleWsOpRsp
+处理auth/subscribe/unsubscribe/ping等操作的返回结果
+*/
+func (e *Bybit) handleWsOpRsp(client *banexg.WsClient, item *banexg.WsMsg) {
+	op, _ := item.Object["op"]
+	if op == "ping" || op == "pong" {
+		return
+	}
+	if op == "" {
+		log.Warn("no topic ws msg", zap.String("msg", item.Text))
+		return
+	}
+	if op == "auth" {
+		e.handleWsAuthRsp(client, item.Object)
+		return
+	}
+	if success, _ := item.Object["success"]; success == "false" {
+		log.Error("ws op fail", zap.String("op", op), zap.String("url", client.URL),
+			zap.String("msg", item.Object["ret_msg"]))
+		return
+	}
+	log.Debug("ws op ok", zap.String("op", op), zap.String("job", item.Object["req_id"]))
+}
+
+func makeHandleWsReCon(e *Bybit) banexg.FuncOnWsReCon {
+	return func(client *banexg.WsClient, connID int) *errs.Error {
+		e.resetWsAuth(client, connID)
+		subKeys := client.GetSubKeys(connID)
+		if len(subKeys) == 0 {
+			return nil
+		}
+		zapFields := []zap.Field{zap.String("url", client.URL), zap.Int("id", connID),
+			zap.Int("job", len(subKeys))}
+		log.Info("re-subscribe ws", zapFields...)
+		err := e.WriteWSMsg(client, connID, true, subKeys)
+		if err != nil {
+			return err
+		}
+		log.Info("re-subscribe ok", zapFields...)
+		return nil
+	}
+}
+
+// GetWsClient get WsClient for public data
+func (e *Bybit) GetWsClient(marketType string) (*banexg.WsClient, *errs.Error) {
+	host := e.GetHost(marketType)
+	if host == "" {
+		return nil, errs.NewMsg(errs.CodeParamInvalid, "unsupport wss host for %s: %s", e.Name, marketType)
+	}
+	client, err := e.GetClient(host, marketType, "")
+	if err != nil {
+		return nil, err
+	}
+	e.keepWsAlive(client)
+	return client, nil
+}
+
+/*
+keepWsAlive
+bybit不响应协议层的ping，需定期发送{"op":"ping"}，否则连接会被服务器断开。
+每个WsClient只启动一个心跳协程，所有连接关闭后退出
+*/
+func (e *Bybit) keepWsAlive(client *banexg.WsClient) {
+	if e.WsDecoder != nil {
+		// replay mode, no real connection
+		return
+	}
+	e.wsLock.Lock()
+	if _, ok := e.wsPingClients[client]; ok {
+		e.wsLock.Unlock()
+		return
+	}
+	e.wsPingClients[client] = true
+	e.wsLock.Unlock()
+	go func() {
+		defer func() {
+			e.wsLock.Lock()
+			delete(e.wsPingClients, client)
+			e.wsLock.Unlock()
+		}()
+		for {
+			time.Sleep(wsPingIntv)
+			conns, lock := client.LockConns()
+			connList := utils.ValsOfMap(conns)
+			lock.Unlock()
+			if len(connList) == 0 {
+				return
+			}
+			for _, conn := range connList {
+				err := e.writeWsOp(client, conn, "ping", nil)
+				if err != nil {
+					log.Warn("send ws ping fail", zap.String("url", client.URL), zap.Int("id", conn.GetID()),
+						zap.String("err", err.Short()))
+				}
+			}
+		}
+	}()
+}
+
+func (e *Bybit) nextReqId() string {
+	e.wsLock.Lock()
+	e.wsRequestId += 1
+	reqId := e.wsRequestId
+	e.wsLock.Unlock()
+	return strconv.Itoa(reqId)
+}
+
+func (e *Bybit) writeWsOp(client *banexg.WsClient, conn *banexg.AsyncConn, op string, args interface{}) *errs.Error {
+	var request = map[string]interface{}{
+		"op":     op,
+		"req_id": e.nextReqId(),
+	}
+	if args != nil {
+		request["args"] = args
+	}
+	return client.Write(conn, request, nil)
+}
+
+/*
+WriteWSMsg 向交易所写入ws订阅消息。
+isSub true订阅、false取消订阅
+keys 订阅的topic，如orderbook.50.BTCUSDT、wallet
+*/
+func (e *Bybit) WriteWSMsg(client *banexg.WsClient, connID int, isSub bool, keys []string) *errs.Error {
+	if !isSub {
+		return e.unSubscribe(client, keys)
+	}
+	for start := 0; start < len(keys); start += wsBatchSize {
+		batch := keys[start:min(start+wsBatchSize, len(keys))]
+		_, conn := client.UpdateSubs(connID, true, batch)
+		if conn == nil {
+			return errs.NewMsg(errs.CodeRunTime, "get ws conn fail")
+		}
+		connID = conn.GetID()
+		if client.AccName != "" {
+			// 私有连接需先鉴权
+			err := e.authWsConn(client, conn, false)
+			if err != nil {
+				return err
+			}
+		}
+		err := e.writeWsOp(client, conn, "subscribe", batch)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+unSubscribe
+取消订阅需发送到订阅时所在的连接
+*/
+func (e *Bybit) unSubscribe(client *banexg.WsClient, keys []string) *errs.Error {
+	conns, lock := client.LockConns()
+	connList := utils.ValsOfMap(conns)
+	lock.Unlock()
+	for _, conn := range connList {
+		subKeys := make(map[string]bool)
+		for _, k := range client.GetSubKeys(conn.GetID()) {
+			subKeys[k] = true
+		}
+		var connKeys = make([]string, 0, len(keys))
+		for _, k := range keys {
+			if subKeys[k] {
+				connKeys = append(connKeys, k)
+			}
+		}
+		if len(connKeys) == 0 {
+			continue
+		}
+		client.UpdateSubs(conn.GetID(), false, connKeys)
+		for start := 0; start < len(connKeys); start += wsBatchSize {
+			batch := connKeys[start:min(start+wsBatchSize, len(connKeys))]
+			err := e.writeWsOp(client, conn, "unsubscribe", batch)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *Bybit) getWsKeys(symbols []string, cvt func(m *banexg.Market, i int) string) ([]string, *errs.Error) {
+	keys := make([]string, 0, len(symbols))
+	for i, sym := range symbols {
+		market, err := e.GetMarket(sym)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, cvt(market, i))
+	}
+	return keys, nil
+}
+
+func parseWsData(text string) (map[string]string, error) {
+	var data = make(map[string]interface{})
+	err := utils.UnmarshalString(text, &data, utils.JsonNumStr)
+	if err != nil {
+		return nil, err
+	}
+	return utils.MapValStr(data), nil
+}
+
+/*
+WatchOrderBooks
+watches information on open orders with bid(buy) and ask(sell) prices, volumes and other data
+
+	:see: https://bybit-exchange.github.io/docs/v5/websocket/public/orderbook
+	:param []string symbols: unified symbols of the market to fetch the order book for
+	:param int [limit]: depth of order book, spot/linear/inverse: 1,50,200,1000; option: 25,100
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+*/
+func (e *Bybit) WatchOrderBooks(symbols []string, limit int, params map[string]interface{}) (chan *banexg.OrderBook, *errs.Error) {
+	chanKey, args, limit, err := e.prepareBookArgs(true, limit, symbols, params)
+	if err != nil || chanKey == "" {
+		return nil, err
+	}
+	create := func(cap int) chan *banexg.OrderBook { return make(chan *banexg.OrderBook, cap) }
+	out := banexg.GetWsOutChan(e.Exchange, chanKey, create, args)
+	e.AddWsChanRefs(chanKey, symbols...)
+	e.DumpWS("WatchOrderBooks", &wsBookSub{Symbols: symbols, Limit: limit})
+	return out, nil
+}
+
+func (e *Bybit) UnWatchOrderBooks(symbols []string, params map[string]interface{}) *errs.Error {
+	chanKey, _, _, err := e.prepareBookArgs(false, 0, symbols, params)
+	if err != nil || chanKey == "" {
+		return err
+	}
+	e.DelWsChanRefs(chanKey, symbols...)
+	return nil
+}
+
+/*
+getWsBookDepth
+返回不小于limit的最小可用深度，limit为0时使用50(期权25)
+*/
+func getWsBookDepth(marketType string, limit int) int {
+	depths, ok := wsBookDepths[marketType]
+	if !ok {
+		depths = wsBookDepths[banexg.MarketLinear]
+	}
+	if limit <= 0 {
+		limit = 50
+		if marketType == banexg.MarketOption {
+			limit = 25
+		}
+	}
+	for _, d := range depths {
+		if d >= limit {
+			return d
+		}
+	}
+	return depths[len(depths)-1]
+}
+
+func (e *Bybit) prepareBookArgs(isSub bool, limit int, symbols []string, params map[string]interface{}) (string, map[string]interface{}, int, *errs.Error) {
+	if len(symbols) == 0 {
+		return "", nil, 0, errs.NewMsg(errs.CodeParamRequired, "symbols required for WatchOrderBooks")
+	}
+	args, market, err := e.LoadArgsMarket(symbols[0], params)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	client, err := e.GetWsClient(market.Type)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	// 记录订阅的深度信息，取消订阅时使用
+	bookLimits, lock := client.LockOdBookLimits()
+	var depths = make(map[string]int)
+	if isSub {
+		limit = getWsBookDepth(market.Type, limit)
+		for _, code := range symbols {
+			bookLimits[code] = limit
+			depths[code] = limit
+		}
+	} else {
+		for _, code := range symbols {
+			if val, ok := bookLimits[code]; ok {
+				depths[code] = val
+				delete(bookLimits, code)
+			}
+		}
+	}
+	lock.Unlock()
+	if len(depths) == 0 {
+		// no sub symbols, return
+		return "", nil, 0, nil
+	}
+	var subCodes = make([]string, 0, len(depths))
+	for _, code := range symbols {
+		if _, ok := depths[code]; ok {
+			subCodes = append(subCodes, code)
+		}
+	}
+	keys, err := e.getWsKeys(subCodes, func(m *banexg.Market, i int) string {
+		return fmt.Sprintf("orderbook.%d.%s", depths[subCodes[i]], m.ID)
+	})
+	if err != nil {
+		return "", nil, 0, err
+	}
+	err = e.WriteWSMsg(client, 0, isSub, keys)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	chanKey := client.Prefix(market.Type + "@depth")
+	return chanKey, args, limit, nil
+}
+
+/*
+handleOrderBook
+处理订单簿推送：snapshot全量替换，delta增量更新。
+delta的u应等于上次u+1，否则重新订阅以获取新快照；u=1表示服务重启后的快照。
+seq为跨深度序号，小于等于已处理seq的推送视为过期
+*/
+func (e *Bybit) handleOrderBook(client *banexg.WsClient, msg map[string]string) {
+	topic, _ := msg["topic"]
+	client.SetSubsKeyStamp(topic, bntp.UTCStamp())
+	data, err_ := parseWsData(msg["data"])
+	if err_ != nil {
+		log.Error("unmarshal ws orderbook fail", zap.String("topic", topic), zap.Error(err_))
+		return
+	}
+	marketId, _ := data["s"]
+	market := e.GetMarketById(marketId, client.MarketType)
+	urlZap := zap.String("url", client.URL)
+	if market == nil {
+		log.Error("no market for ws depth update", urlZap, zap.String("symbol", marketId))
+		return
+	}
+	symbol := market.Symbol
+	var zero = int64(0)
+	u, _ := utils.SafeMapVal(data, "u", zero)
+	seq, _ := utils.SafeMapVal(data, "seq", zero)
+	stamp, _ := utils.SafeMapVal(msg, "ts", zero)
+	if stamp == 0 {
+		stamp = e.MilliSeconds()
+	}
+	isSnapshot := msg["type"] == "snapshot" || u == 1
+	e.OdBookLock.Lock()
+	book, ok := e.OrderBooks[symbol]
+	if !ok {
+		bookLimits, lock := client.LockOdBookLimits()
+		limit, _ := bookLimits[symbol]
+		if limit <= 0 {
+			limit = getWsBookDepth(client.MarketType, 0)
+			bookLimits[symbol] = limit
+		}
+		lock.Unlock()
+		book = &banexg.OrderBook{
+			Symbol: symbol,
+			Cache:  make([]map[string]string, 0),
+			Limit:  limit,
+			Asks:   banexg.NewOdBookSide(false, limit, nil),
+			Bids:   banexg.NewOdBookSide(true, limit, nil),
+		}
+		e.OrderBooks[symbol] = book
+	}
+	nonce := book.Nonce
+	outOfDate := false
+	if isSnapshot {
+		book.SetSide(data["a"], false, true)
+		book.SetSide(data["b"], true, true)
+	} else if nonce == 0 || u <= nonce || seq > 0 && seq <= e.bookSeqs[symbol] {
+		// 等待快照中，或过期推送，忽略
+		e.OdBookLock.Unlock()
+		return
+	} else if u != nonce+1 {
+		outOfDate = true
+	} else {
+		book.SetSide(data["a"], false, false)
+		book.SetSide(data["b"], true, false)
+	}
+	if outOfDate {
+		book.Reset()
+		delete(e.bookSeqs, symbol)
+	} else {
+		book.Nonce = u
+		book.TimeStamp = stamp
+		e.bookSeqs[symbol] = seq
+	}
+	e.OdBookLock.Unlock()
+	if outOfDate {
+		// order book is out of date, re-subscribe to get a new snapshot
+		log.Warn("ws order book out-of-date, re-subscribe", urlZap, zap.String("code", symbol),
+			zap.Int64("cur", nonce), zap.Int64("latest", u))
+		go e.reSubscribe(client, []string{topic})
+		return
+	}
+	chanKey := client.Prefix(client.MarketType + "@depth")
+	banexg.WriteOutChan(e.Exchange, chanKey, book, true)
+}
+
+func (e *Bybit) reSubscribe(client *banexg.WsClient, keys []string) {
+	err := e.WriteWSMsg(client, 0, false, keys)
+	if err == nil {
+		err = e.WriteWSMsg(client, 0, true, keys)
+	}
+	if err != nil {
+		log.Error("re-subscribe ws fail", zap.String("url", client.URL), zap.Strings("keys", keys),
+			zap.Error(err))
+	}
+}
+
+func (e *Bybit) WatchTrades(symbols []string, params map[string]interface{}) (chan *banexg.Trade, *errs.Error) {
+	chanKey, args, err := e.prepareWatchTrades(true, symbols, params)
+	if err != nil {
+		return nil, err
+	}
+	create := func(cap int) chan *banexg.Trade { return make(chan *banexg.Trade, cap) }
+	out := banexg.GetWsOutChan(e.Exchange, chanKey, create, args)
+	e.AddWsChanRefs(chanKey, symbols...)
+	e.DumpWS("WatchTrades", symbols)
+	return out, nil
+}
+
+func (e *Bybit) UnWatchTrades(symbols []string, params map[string]interface{}) *errs.Error {
+	chanKey, _, err := e.prepareWatchTrades(false, symbols, params)
+	if err != nil {
+		return err
+	}
+	e.DelWsChanRefs(chanKey, symbols...)
+	return nil
+}
+
+func (e *Bybit) prepareWatchTrades(isSub bool, symbols []string, params map[string]interface{}) (string, map[string]interface{}, *errs.Error) {
+	if len(symbols) == 0 {
+		return "", nil, errs.NewMsg(errs.CodeParamRequired, "symbols is required")
+	}
+	args, market, err := e.LoadArgsMarket(symbols[0], params)
+	if err != nil {
+		return "", nil, err
+	}
+	client, err := e.GetWsClient(market.Type)
+	if err != nil {
+		return "", nil, err
+	}
+	keys, err := e.getWsKeys(symbols, func(m *banexg.Market, _ int) string {
+		return "publicTrade." + m.ID
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	err = e.WriteWSMsg(client, 0, isSub, keys)
+	if err != nil {
+		return "", nil, err
+	}
+	chanKey := client.Prefix(market.Type + "@trade")
+	return chanKey, args, nil
+}
+
+func (e *Bybit) handleTrade(client *banexg.WsClient, msg map[string]string) {
+	topic, _ := msg["topic"]
+	client.SetSubsKeyStamp(topic, bntp.UTCStamp())
+	var items = make([]*WsTrade, 0)
+	infos, err := utils.UnmarshalStringMapArr(msg["data"], &items)
+	if err != nil {
+		log.Error("unmarshal ws trade fail", zap.String("topic", topic), zap.Error(err))
+		return
+	}
+	chanKey := client.Prefix(client.MarketType + "@trade")
+	for i, it := range items {
+		symbol := e.SafeSymbol(it.Symbol, "", client.MarketType)
+		if symbol == "" {
+			continue
+		}
+		price, _ := strconv.ParseFloat(it.Price, 64)
+		amount, _ := strconv.ParseFloat(it.Size, 64)
+		trade := &banexg.Trade{
+			ID:        it.TradeId,
+			Symbol:    symbol,
+			Side:      strings.ToLower(it.Side),
+			Amount:    amount,
+			Price:     price,
+			Cost:      price * amount,
+			Timestamp: it.Time,
+			// S为吃单方向，卖方吃单时买方为maker
+			Maker: it.Side == "Sell",
+			Info:  infos[i],
+		}
+		banexg.WriteOutChan(e.Exchange, chanKey, trade, true)
+	}
+}
+
+/*
+WatchOHLCVs
+watches historical candlestick data containing the open, high, low, and close price, and the volume of a market
+
+	:see: https://bybit-exchange.github.io/docs/v5/websocket/public/kline
+	:param [][2]string jobs: array of arrays containing unified symbols and timeframes, example {{'BTC/USDT', '1m'}, {'LTC/USDT', '5m'}}
+	:param dict [params]: extra parameters specific to the exchange API endpoint
+*/
+func (e *Bybit) WatchOHLCVs(jobs [][2]string, params map[string]interface{}) (chan *banexg.PairTFKline, *errs.Error) {
+	chanKey, symbols, args, err := e.prepareOHLCVSub(true, jobs, params)
+	if err != nil {
+		return nil, err
+	}
+	create := func(cap int) chan *banexg.PairTFKline { return make(chan *banexg.PairTFKline, cap) }
+	out := banexg.GetWsOutChan(e.Exchange, chanKey, create, args)
+	e.AddWsChanRefs(chanKey, symbols...)
+	e.DumpWS("WatchOHLCVs", jobs)
+	return out, nil
+}
+
+func (e *Bybit) UnWatchOHLCVs(jobs [][2]string, params map[string]interface{}) *errs.Error {
+	chanKey, symbols, _, err := e.prepareOHLCVSub(false, jobs, params)
+	if err != nil {
+		return err
+	}
+	e.DelWsChanRefs(chanKey, symbols...)
+	return nil
+}
+
+func (e *Bybit) prepareOHLCVSub(isSub bool, jobs [][2]string, params map[string]interface{}) (string, []string, map[string]interface{}, *errs.Error) {
+	if len(jobs) == 0 {
+		return "", nil, nil, errs.NewMsg(errs.CodeParamRequired, "symbols is required")
+	}
+	args, market, err := e.LoadArgsMarket(jobs[0][0], params)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	client, err := e.GetWsClient(market.Type)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	symbols := make([]string, 0, len(jobs))
+	intvs := make([]string, 0, len(jobs))
+	for _, j := range jobs {
+		intv, err := getWsInterval(j[1])
+		if err != nil {
+			return "", nil, nil, err
+		}
+		symbols = append(symbols, j[0])
+		intvs = append(intvs, intv)
+	}
+	keys, err := e.getWsKeys(symbols, func(m *banexg.Market, i int) string {
+		return fmt.Sprintf("kline.%s.%s", intvs[i], m.ID)
+	})
+	if err != nil {
+		return "", nil, nil, err
+	}
+	err = e.WriteWSMsg(client, 0, isSub, keys)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	chanKey := client.Prefix(market.Type + "@kline")
+	return chanKey, symbols, args, nil
+}
+
+/*
+getWsInterval
+1m -> 1, 1h -> 60, 1d -> D, 1w -> W, 1M -> M
+*/
+func getWsInterval(timeframe string) (string, *errs.Error) {
+	secs := utils.TFToSecs(timeframe)
+	switch secs {
+	case utils.SecsDay:
+		return "D", nil
+	case utils.SecsWeek:
+		return "W", nil
+	case utils.SecsMon:
+		return "M", nil
+	}
+	if secs%60 == 0 && utils.ArrContains(wsKlineMins, secs/60) {
+		return strconv.Itoa(secs / 60), nil
+	}
+	return "", errs.NewMsg(errs.CodeInvalidTimeFrame, "unsupported timeframe for %s: %s", "bybit", timeframe)
+}
+
+func parseWsInterval(intv string) string {
+	switch intv {
+	case "D":
+		return "1d"
+	case "W":
+		return "1w"
+	case "M":
+		return "1M"
+	}
+	mins, _ := strconv.Atoi(intv)
+	return utils.SecsToTF(mins * 60)
+}
+
+func (e *Bybit) handleOHLCV(client *banexg.WsClient, msg map[string]string) {
+	topic, _ := msg["topic"]
+	client.SetSubsKeyStamp(topic, bntp.UTCStamp())
+	// kline.{interval}.{symbol}
+	arr := strings.SplitN(topic, ".", 3)
+	if len(arr) < 3 {
+		log.Error("invalid kline topic", zap.String("topic", topic))
+		return
+	}
+	symbol := e.SafeSymbol(arr[2], "", client.MarketType)
+	if symbol == "" {
+		return
+	}
+	var items = make([]*WsKline, 0)
+	err := utils.UnmarshalString(msg["data"], &items, utils.JsonNumDefault)
+	if err != nil {
+		log.Error("unmarshal ws kline fail", zap.String("topic", topic), zap.Error(err))
+		return
+	}
+	chanKey := client.Prefix(client.MarketType + "@kline")
+	for _, k := range items {
+		o, _ := strconv.ParseFloat(k.Open, 64)
+		c, _ := strconv.ParseFloat(k.Close, 64)
+		h, _ := strconv.ParseFloat(k.High, 64)
+		l, _ := strconv.ParseFloat(k.Low, 64)
+		v, _ := strconv.ParseFloat(k.Volume, 64)
+		var kline = &banexg.PairTFKline{
+			Symbol:    symbol,
+			TimeFrame: parseWsInterval(k.Interval),
+			Kline: banexg.Kline{
+				Time:   k.Start,
+				Open:   o,
+				Close:  c,
+				High:   h,
+				Low:    l,
+				Volume: v,
+			},
+		}
+		banexg.WriteOutChan(e.Exchange, chanKey, kline, true)
+	}
+}
+
+/*
+WatchMarkPrices
+订阅标记价格，由tickers推送得到，仅推送包含markPrice的消息
+
+	:see: https://bybit-exchange.github.io/docs/v5/websocket/public/ticker
+	:param []string symbols: unified linear/inverse/option symbols, required
+	:param dict [params]: extra parameters
+*/
+func (e *Bybit) WatchMarkPrices(symbols []string, params map[string]interface{}) (chan map[string]float64, *errs.Error) {
+	chanKey, args, err := e.prepareMarkPrices(true, symbols, params)
+	if err != nil {
+		return nil, err
+	}
+	create := func(cap int) chan map[string]float64 { return make(chan map[string]float64, cap) }
+	out := banexg.GetWsOutChan(e.Exchange, chanKey, create, args)
+	e.AddWsChanRefs(chanKey, symbols...)
+	e.DumpWS("WatchMarkPrices", symbols)
+	return out, nil
+}
+
+func (e *Bybit) UnWatchMarkPrices(symbols []string, params map[string]interface{}) *errs.Error {
+	chanKey, _, err := e.prepareMarkPrices(false, symbols, params)
+	if err != nil {
+		return err
+	}
+	e.DelWsChanRefs(chanKey, symbols...)
+	return nil
+}
+
+func (e *Bybit) prepareMarkPrices(isSub bool, symbols []string, params map[string]interface{}) (string, map[string]interface{}, *errs.Error) {
+	if len(symbols) == 0 {
+		return "", nil, errs.NewMsg(errs.CodeParamRequired, "symbols required for %s.WatchMarkPrices", e.Name)
+	}
+	args := utils.SafeParams(params)
+	marketType, _, err := e.LoadArgsMarketType(args, symbols...)
+	if err != nil {
+		return "", nil, err
+	}
+	if !e.IsContract(marketType) && marketType != banexg.MarketOption {
+		return "", nil, errs.NewMsg(errs.CodeUnsupportMarket, "WatchMarkPrices support linear/inverse/option, current: %s", marketType)
+	}
+	client, err := e.GetWsClient(marketType)
+	if err != nil {
+		return "", nil, err
+	}
+	keys, err := e.getWsKeys(symbols, func(m *banexg.Market, _ int) string {
+		return "tickers." + m.ID
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	err = e.WriteWSMsg(client, 0, isSub, keys)
+	if err != nil {
+		return "", nil, err
+	}
+	chanKey := client.Prefix(marketType + "@markPrice")
+	return chanKey, args, nil
+}
+
+/*
+handleTickers
+处理tickers推送；合约的delta推送只包含变化的字段，无markPrice时跳过
+*/
+func (e *Bybit) handleTickers(client *banexg.WsClient, msg map[string]string) {
+	topic, _ := msg["topic"]
+	client.SetSubsKeyStamp(topic, bntp.UTCStamp())
+	data, err := parseWsData(msg["data"])
+	if err != nil {
+		log.Error("unmarshal ws tickers fail", zap.String("topic", topic), zap.Error(err))
+		return
+	}
+	marketId, _ := data["symbol"]
+	symbol := e.SafeSymbol(marketId, "", client.MarketType)
+	if symbol == "" {
+		return
+	}
+	markPrice, _ := utils.SafeMapVal(data, "markPrice", float64(0))
+	if markPrice == 0 {
+		return
+	}
+	e.MarkPriceLock.Lock()
+	prices, ok := e.MarkPrices[client.MarketType]
+	if !ok {
+		prices = map[string]float64{}
+		e.MarkPrices[client.MarketType] = prices
+	}
+	prices[symbol] = markPrice
+	e.MarkPriceLock.Unlock()
+	chanKey := client.Prefix(client.MarketType + "@markPrice")
+	banexg.WriteOutChan(e.Exchange, chanKey, map[string]float64{symbol: markPrice}, true)
+}
+
+func (e *Bybit) regReplayHandles() {
+	e.WsReplayFn = map[string]func(item *banexg.WsLog) *errs.Error{
+		"WatchOrderBooks": func(item *banexg.WsLog) *errs.Error {
+			var sub = &wsBookSub{}
+			err_ := utils.UnmarshalString(item.Content, sub, utils.JsonNumDefault)
+			if err_ != nil {
+				return errs.New(errs.CodeUnmarshalFail, err_)
+			}
+			log.Debug("replay WatchOrderBooks", zap.Strings("codes", sub.Symbols))
+			_, err := e.WatchOrderBooks(sub.Symbols, sub.Limit, nil)
+			return err
+		},
+		"WatchTrades": func(item *banexg.WsLog) *errs.Error {
+			var symbols = make([]string, 0)
+			err_ := utils.UnmarshalString(item.Content, &symbols, utils.JsonNumDefault)
+			if err_ != nil {
+				return errs.New(errs.CodeUnmarshalFail, err_)
+			}
+			log.Debug("replay WatchTrades", zap.Strings("codes", symbols))
+			_, err := e.WatchTrades(symbols, nil)
+			return err
+		},
+		"WatchOHLCVs": func(item *banexg.WsLog) *errs.Error {
+			var jobs = make([][2]string, 0)
+			err_ := utils.UnmarshalString(item.Content, &jobs, utils.JsonNumDefault)
+			if err_ != nil {
+				return errs.New(errs.CodeUnmarshalFail, err_)
+			}
+			log.Debug("replay WatchOHLCVs", zap.Int("num", len(jobs)))
+			_, err := e.WatchOHLCVs(jobs, nil)
+			return err
+		},
+		"WatchMarkPrices": func(item *banexg.WsLog) *errs.Error {
+			var symbols = make([]string, 0)
+			err_ := utils.UnmarshalString(item.Content, &symbols, utils.JsonNumDefault)
+			if err_ != nil {
+				return errs.New(errs.CodeUnmarshalFail, err_)
+			}
+			log.Debug("replay WatchMarkPrices", zap.Strings("codes", symbols))
+			_, err := e.WatchMarkPrices(symbols, nil)
+			return err
+		},
+		"wsMsg": func(item *banexg.WsLog) *errs.Error {
+			var arr = make([]string, 0)
+			err_ := utils.UnmarshalString(item.Content, &arr, utils.JsonNumDefault)
+			if err_ != nil {
+				return errs.New(errs.CodeUnmarshalFail, err_)
+			}
+			client, err := e.GetClient(arr[0], arr[1], arr[2])
+			if err != nil {
+				return err
+			}
+			log.Debug("replay wsMsg", zap.String("msg", arr[3]))
+			client.HandleRawMsg([]byte(arr[3]))
+			return nil
+		},
+	}
+}
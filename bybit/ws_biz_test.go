@@ -0,0 +1,186 @@
+package bybit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/banbox/banexg"
+	"github.com/banbox/banexg/utils"
+	"github.com/gorilla/websocket"
+)
+
+/*
+recordWsServer
+本地ws服务器，记录客户端写入的消息，不返回任何推送，用于检查订阅和重新订阅请求
+*/
+type recordWsServer struct {
+	*httptest.Server
+	writes chan string
+}
+
+func newRecordWsServer() *recordWsServer {
+	res := &recordWsServer{writes: make(chan string, 100)}
+	upgrader := websocket.Upgrader{}
+	res.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			res.writes <- string(data)
+		}
+	}))
+	return res
+}
+
+// URL 返回ws协议的服务器地址
+func (s *recordWsServer) URL() string {
+	return "ws" + strings.TrimPrefix(s.Server.URL, "http")
+}
+
+// nextOp 等待下一条写入的消息，返回op和args，忽略心跳
+func (s *recordWsServer) nextOp(t *testing.T) (string, []string) {
+	for {
+		select {
+		case text := <-s.writes:
+			var msg = struct {
+				Op   string   `json:"op"`
+				Args []string `json:"args"`
+			}{}
+			if err := utils.UnmarshalString(text, &msg, utils.JsonNumDefault); err != nil {
+				t.Fatalf("unmarshal ws msg fail: %s %v", text, err)
+			}
+			if msg.Op == "ping" {
+				continue
+			}
+			return msg.Op, msg.Args
+		case <-time.After(time.Second * 3):
+			t.Fatalf("wait ws msg timeout")
+			return "", nil
+		}
+	}
+}
+
+func TestWsIntervalRoundTrip(t *testing.T) {
+	cases := map[string]string{
+		"1m": "1", "3m": "3", "5m": "5", "15m": "15", "30m": "30", "1h": "60", "2h": "120",
+		"4h": "240", "6h": "360", "12h": "720", "1d": "D", "1w": "W", "1M": "M",
+	}
+	for tf, intv := range cases {
+		res, err := getWsInterval(tf)
+		if err != nil || res != intv {
+			t.Errorf("getWsInterval %s expect %s, got %s %v", tf, intv, res, err)
+			continue
+		}
+		if back := parseWsInterval(res); back != tf {
+			t.Errorf("parseWsInterval %s expect %s, got %s", res, tf, back)
+		}
+	}
+	for _, tf := range []string{"2m", "8h", "3d"} {
+		if res, err := getWsInterval(tf); err == nil {
+			t.Errorf("getWsInterval %s should fail, got %s", tf, res)
+		}
+	}
+}
+
+func TestHandleOrderBookGap(t *testing.T) {
+	exg := getOfflineBybit(btcLinear)
+	conn := newRecordWsServer()
+	defer conn.Close()
+	exg.Hosts.Prod[banexg.MarketLinear] = conn.URL()
+	out, err := exg.WatchOrderBooks([]string{btcLinear.Symbol}, 50, nil)
+	if err != nil {
+		t.Fatalf("watch order book fail: %v", err)
+	}
+	topic := "orderbook.50.BTCUSDT"
+	if op, args := conn.nextOp(t); op != "subscribe" || len(args) != 1 || args[0] != topic {
+		t.Fatalf("expect subscribe %s, got %s %v", topic, op, args)
+	}
+	client, err := exg.GetWsClient(banexg.MarketLinear)
+	if err != nil {
+		t.Fatalf("get ws client fail: %v", err)
+	}
+	push := func(typ, data string) {
+		exg.handleOrderBook(client, map[string]string{"topic": topic, "type": typ, "ts": "1700000000000", "data": data})
+	}
+	// 快照前的增量推送忽略
+	push("delta", `{"s":"BTCUSDT","b":[["29990","1"]],"a":[],"u":9,"seq":90}`)
+	push("snapshot", `{"s":"BTCUSDT","b":[["30000","1"],["29999","2"]],"a":[["30001","1"],["30002","3"]],"u":10,"seq":100}`)
+	push("delta", `{"s":"BTCUSDT","b":[["30000","0"]],"a":[["30001","5"]],"u":11,"seq":101}`)
+	// 过期推送：u不大于当前，或seq不大于已处理
+	push("delta", `{"s":"BTCUSDT","b":[["29999","9"]],"a":[],"u":11,"seq":101}`)
+	push("delta", `{"s":"BTCUSDT","b":[["29999","9"]],"a":[],"u":12,"seq":101}`)
+	var book *banexg.OrderBook
+	for i := 0; i < 2; i++ {
+		select {
+		case book = <-out:
+		case <-time.After(time.Second):
+			t.Fatalf("expect 2 books, got %d", i)
+		}
+	}
+	if len(out) > 0 {
+		t.Fatalf("stale delta should not output book")
+	}
+	exg.OdBookLock.Lock()
+	nonce, seq := book.Nonce, exg.bookSeqs[btcLinear.Symbol]
+	bid, _ := book.Bids.Level(0)
+	ask, askVol := book.Asks.Level(0)
+	exg.OdBookLock.Unlock()
+	if nonce != 11 || seq != 101 || bid != 29999 || ask != 30001 || askVol != 5 {
+		t.Errorf("bad book after delta, nonce %v seq %v bid %v ask %v %v", nonce, seq, bid, ask, askVol)
+	}
+	// u不连续，重置订单簿并重新订阅
+	push("delta", `{"s":"BTCUSDT","b":[["29998","1"]],"a":[],"u":13,"seq":103}`)
+	if op, args := conn.nextOp(t); op != "unsubscribe" || len(args) != 1 || args[0] != topic {
+		t.Errorf("expect unsubscribe %s, got %s %v", topic, op, args)
+	}
+	if op, args := conn.nextOp(t); op != "subscribe" || len(args) != 1 || args[0] != topic {
+		t.Errorf("expect subscribe %s, got %s %v", topic, op, args)
+	}
+	exg.OdBookLock.Lock()
+	nonce = book.Nonce
+	_, hasSeq := exg.bookSeqs[btcLinear.Symbol]
+	exg.OdBookLock.Unlock()
+	if nonce != 0 || hasSeq {
+		t.Errorf("book should be reset after gap, nonce %v, has seq %v", nonce, hasSeq)
+	}
+	// u=1为服务重启后的快照
+	push("delta", `{"s":"BTCUSDT","b":[["29000","1"]],"a":[["29001","1"]],"u":1,"seq":200}`)
+	select {
+	case book = <-out:
+		if book.Nonce != 1 {
+			t.Errorf("expect nonce 1 after restart snapshot, got %v", book.Nonce)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("restart snapshot should output book")
+	}
+}
+
+func TestGetWsBookDepth(t *testing.T) {
+	cases := []struct {
+		marketType string
+		limit      int
+		expect     int
+	}{
+		{banexg.MarketLinear, 0, 50},
+		{banexg.MarketSpot, 0, 50},
+		{banexg.MarketOption, 0, 25},
+		{banexg.MarketLinear, 20, 50},
+		{banexg.MarketLinear, 500, 1000},
+		{banexg.MarketLinear, 5000, 1000},
+		{banexg.MarketOption, 30, 100},
+	}
+	for _, c := range cases {
+		if res := getWsBookDepth(c.marketType, c.limit); res != c.expect {
+			t.Errorf("getWsBookDepth %s %d expect %d, got %d", c.marketType, c.limit, c.expect, res)
+		}
+	}
+}
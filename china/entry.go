@@ -47,6 +47,7 @@ func New(Options map[string]interface{}) (*China, *errs.Error) {
 					banexg.ApiCreateOrder:           banexg.HasFail,
 					banexg.ApiEditOrder:             banexg.HasFail,
 					banexg.ApiCancelOrder:           banexg.HasFail,
+					banexg.ApiCreateOrderBy:         banexg.HasFail,
 					banexg.ApiSetLeverage:           banexg.HasFail,
 					banexg.ApiCalcMaintMargin:       banexg.HasFail,
 					banexg.ApiWatchOrderBooks:       banexg.HasFail,
@@ -1,6 +1,7 @@
 package banexg
 
 import (
+	"context"
 	"fmt"
 	"github.com/banbox/banexg/bntp"
 	"github.com/banbox/banexg/errs"
@@ -12,6 +13,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func (p *Precision) ToString() string {
@@ -429,6 +431,23 @@ func (m *Market) GetTradeTimes() [][2]int64 {
 	return times
 }
 
+/*
+DefaultNetwork
+return the network marked IsDefault, or the only network; nil if not found
+返回标记为默认的网络，只有一个网络时返回此网络；找不到返回nil
+*/
+func (c *Currency) DefaultNetwork() *ChainNetwork {
+	for _, n := range c.Networks {
+		if n != nil && n.IsDefault {
+			return n
+		}
+	}
+	if len(c.Networks) == 1 {
+		return c.Networks[0]
+	}
+	return nil
+}
+
 func GetHostRetryWait(host string, randAdd bool) int64 {
 	var waitMS int64
 	hostWaitLock.Lock()
@@ -462,3 +481,229 @@ func GetHostFlowChan(host string) chan struct{} {
 	hostFlowLock.Unlock()
 	return out
 }
+
+/*
+AcquireHostFlow
+Occupy a concurrency slot of host, block until available or ctx done. call the returned func to release.
+占用域名的一个并发名额，阻塞直到可用或ctx结束；调用返回的函数释放
+*/
+func AcquireHostFlow(ctx context.Context, host string) (func(), *errs.Error) {
+	sem := GetHostFlowChan(host)
+	select {
+	case sem <- struct{}{}:
+		return func() {
+			<-sem
+		}, nil
+	case <-ctx.Done():
+		return nil, CtxError(ctx)
+	}
+}
+
+/*
+WaitHostRetry
+Wait if 429 or 418 occurs on host recently, return early with error if ctx done
+如果域名最近出现429或418则等待，ctx结束时提前返回错误
+*/
+func WaitHostRetry(ctx context.Context, host string) *errs.Error {
+	waitMS := GetHostRetryWait(host, true)
+	if waitMS <= 0 {
+		return nil
+	}
+	return SleepCtx(ctx, time.Millisecond*time.Duration(waitMS))
+}
+
+/*
+SleepCtx
+Sleep for the given duration, return error immediately when ctx is canceled or deadline exceeded
+睡眠指定时长，ctx被取消或超时时立刻返回错误
+*/
+func SleepCtx(ctx context.Context, dur time.Duration) *errs.Error {
+	if dur <= 0 {
+		return CtxError(ctx)
+	}
+	timer := time.NewTimer(dur)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return CtxError(ctx)
+	}
+}
+
+/*
+CtxError
+return CodeCanceled error if ctx is done, else nil
+ctx已结束时返回CodeCanceled错误，否则返回nil
+*/
+func CtxError(ctx context.Context) *errs.Error {
+	if ctx == nil {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return errs.New(errs.CodeCanceled, err)
+	}
+	return nil
+}
+
+/*
+GetParamCtx
+get context.Context from ParamContext of params, return context.Background() if missing
+从params的ParamContext中获取context.Context，不存在时返回context.Background()
+*/
+func GetParamCtx(params map[string]interface{}) context.Context {
+	if ctx, ok := params[ParamContext].(context.Context); ok && ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+/*
+popParamCtx
+Extract ParamContext from params, return a copied params without it. params of caller is not modified,
+as it may be reused for next request in loop.
+从params中提取ParamContext，返回不含此键的params副本。不修改调用方的params，因其可能在循环中被复用
+*/
+func popParamCtx(ctx context.Context, params map[string]interface{}) (context.Context, map[string]interface{}) {
+	val, ok := params[ParamContext]
+	if !ok {
+		return ctx, params
+	}
+	res := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		if k != ParamContext {
+			res[k] = v
+		}
+	}
+	if c, ok := val.(context.Context); ok && c != nil {
+		ctx = c
+	}
+	return ctx, res
+}
+
+/*
+Validate
+检查下单参数是否有效，以及是否有互斥的选项同时设置。应在签名请求之前调用
+*/
+func (a *OrderArgs) Validate() *errs.Error {
+	if a == nil {
+		return errs.NewMsg(errs.CodeParamRequired, "order args is nil")
+	}
+	if a.Symbol == "" {
+		return errs.NewMsg(errs.CodeParamRequired, "symbol is required")
+	}
+	if a.Type == "" {
+		return errs.NewMsg(errs.CodeParamRequired, "order type is required")
+	}
+	if a.Side != OdSideBuy && a.Side != OdSideSell {
+		return errs.NewMsg(errs.CodeParamInvalid, "side must be buy or sell, current: %s", a.Side)
+	}
+	if a.Amount < 0 || a.Price < 0 || a.Cost < 0 {
+		return errs.NewMsg(errs.CodeParamInvalid, "amount/price/cost can not be negative")
+	}
+	if a.Amount == 0 && !a.ClosePosition && a.Cost == 0 {
+		return errs.NewMsg(errs.CodeParamRequired, "amount is required")
+	}
+	if a.PostOnly {
+		if a.Type == OdTypeMarket {
+			return errs.NewMsg(errs.CodeParamInvalid, "market orders cannot be postOnly")
+		}
+		if a.TimeInForce == TimeInForceIOC || a.TimeInForce == TimeInForceFOK {
+			return errs.NewMsg(errs.CodeParamInvalid, "postOnly orders cannot have timeInForce: %s", a.TimeInForce)
+		}
+	}
+	if a.StopLossPrice > 0 && a.TakeProfitPrice > 0 {
+		return errs.NewMsg(errs.CodeParamInvalid, "stopLossPrice and takeProfitPrice are mutually exclusive, use two orders")
+	}
+	if a.TriggerPrice > 0 && (a.StopLossPrice > 0 || a.TakeProfitPrice > 0) {
+		return errs.NewMsg(errs.CodeParamInvalid, "triggerPrice cannot be used with stopLossPrice/takeProfitPrice")
+	}
+	if a.ClosePosition && (a.ReduceOnly || a.Amount > 0) {
+		return errs.NewMsg(errs.CodeParamInvalid, "closePosition cannot be used with reduceOnly/amount")
+	}
+	if a.TrailingDelta != 0 && a.CallbackRate != 0 {
+		return errs.NewMsg(errs.CodeParamInvalid, "trailingDelta and callbackRate are mutually exclusive")
+	}
+	if a.Cost > 0 {
+		if a.Type != OdTypeMarket {
+			return errs.NewMsg(errs.CodeParamInvalid, "cost is only valid for market orders")
+		}
+		if a.Amount > 0 {
+			return errs.NewMsg(errs.CodeParamInvalid, "cost and amount are mutually exclusive")
+		}
+	}
+	for key := range a.typedParams() {
+		if _, ok := a.Params[key]; ok {
+			return errs.NewMsg(errs.CodeParamInvalid, "%s is set by both OrderArgs field and Params", key)
+		}
+	}
+	return nil
+}
+
+func (a *OrderArgs) typedParams() map[string]interface{} {
+	var res = make(map[string]interface{})
+	if a.ClientOrderId != "" {
+		res[ParamClientOrderId] = a.ClientOrderId
+	}
+	if a.PositionSide != "" {
+		res[ParamPositionSide] = a.PositionSide
+	}
+	if a.MarginMode != "" {
+		res[ParamMarginMode] = a.MarginMode
+	}
+	if a.TimeInForce != "" {
+		res[ParamTimeInForce] = a.TimeInForce
+	}
+	if a.PostOnly {
+		res[ParamPostOnly] = true
+	}
+	if a.ReduceOnly {
+		res[ParamReduceOnly] = true
+	}
+	if a.ClosePosition {
+		res[ParamClosePosition] = true
+	}
+	if a.TriggerPrice > 0 {
+		res[ParamTriggerPrice] = a.TriggerPrice
+	}
+	if a.StopLossPrice > 0 {
+		res[ParamStopLossPrice] = a.StopLossPrice
+	}
+	if a.TakeProfitPrice > 0 {
+		res[ParamTakeProfitPrice] = a.TakeProfitPrice
+	}
+	if a.TrailingDelta != 0 {
+		res[ParamTrailingDelta] = a.TrailingDelta
+	}
+	if a.CallbackRate != 0 {
+		res[ParamCallbackRate] = a.CallbackRate
+	}
+	if a.Cost > 0 {
+		res[ParamCost] = a.Cost
+	}
+	return res
+}
+
+/*
+ToParams
+将类型化字段合并到Params的副本中，返回可直接传给CreateOrder的params
+*/
+func (a *OrderArgs) ToParams() map[string]interface{} {
+	var res = utils.SafeParams(a.Params)
+	for k, v := range a.typedParams() {
+		res[k] = v
+	}
+	return res
+}
+
+/*
+CreateOrderBy
+检查类型化下单参数后调用exg.CreateOrder，供各交易所实现BanExchange.CreateOrderBy
+*/
+func CreateOrderBy(exg BanExchange, args *OrderArgs) (*Order, *errs.Error) {
+	err := args.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return exg.CreateOrder(args.Symbol, args.Type, args.Side, args.Amount, args.Price, args.ToParams())
+}
@@ -1,8 +1,12 @@
 package banexg
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
+
+	"github.com/banbox/banexg/errs"
 )
 
 func TestSliceInsert(t *testing.T) {
@@ -45,3 +49,72 @@ func TestOdBookSide(t *testing.T) {
 		t.Errorf("SumVolTo fail")
 	}
 }
+
+func TestOrderArgsValidate(t *testing.T) {
+	base := OrderArgs{Symbol: "ETH/USDT:USDT", Type: OdTypeLimit, Side: OdSideBuy, Amount: 1, Price: 1000}
+	cases := []struct {
+		name  string
+		edit  func(a *OrderArgs)
+		valid bool
+	}{
+		{"ok", func(a *OrderArgs) {}, true},
+		{"bad side", func(a *OrderArgs) { a.Side = "long" }, false},
+		{"postOnly ioc", func(a *OrderArgs) { a.PostOnly, a.TimeInForce = true, TimeInForceIOC }, false},
+		{"sl and tp", func(a *OrderArgs) { a.StopLossPrice, a.TakeProfitPrice = 900, 1100 }, false},
+		{"closePosition amount", func(a *OrderArgs) { a.ClosePosition = true }, false},
+		{"closePosition", func(a *OrderArgs) { a.ClosePosition, a.Amount = true, 0 }, true},
+		{"dup param", func(a *OrderArgs) {
+			a.ReduceOnly = true
+			a.Params = map[string]interface{}{ParamReduceOnly: false}
+		}, false},
+	}
+	for _, c := range cases {
+		args := base
+		c.edit(&args)
+		err := args.Validate()
+		if (err == nil) != c.valid {
+			t.Errorf("%s: expect valid=%v, got err: %v", c.name, c.valid, err)
+		}
+	}
+	args := base
+	args.StopLossPrice = 900
+	params := args.ToParams()
+	if params[ParamStopLossPrice] != 900.0 {
+		t.Errorf("ToParams missing stopLossPrice: %v", params)
+	}
+}
+
+func TestCtxCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	start := time.Now()
+	err := SleepCtx(ctx, time.Second*30)
+	if err == nil || err.Code != errs.CodeCanceled {
+		t.Fatalf("SleepCtx should be canceled, got: %v", err)
+	}
+	if cost := time.Since(start); cost > time.Second {
+		t.Fatalf("SleepCtx not return in time: %v", cost)
+	}
+	// host flow semaphore should not block after ctx done
+	host := "ctx.test.host"
+	var releases []func()
+	for i := 0; i < HostHttpConcurr; i++ {
+		release, err := AcquireHostFlow(context.Background(), host)
+		if err != nil {
+			t.Fatalf("acquire host flow fail: %v", err)
+		}
+		releases = append(releases, release)
+	}
+	if _, err = AcquireHostFlow(ctx, host); err == nil {
+		t.Fatalf("AcquireHostFlow should fail when ctx done")
+	}
+	for _, release := range releases {
+		release()
+	}
+	// ctx in params should be removed without modifying params of caller
+	params := map[string]interface{}{ParamContext: ctx, ParamLimit: 10}
+	res, args := popParamCtx(context.Background(), params)
+	if res != ctx || len(args) != 1 || len(params) != 2 {
+		t.Fatalf("popParamCtx fail: %v %v", args, params)
+	}
+}
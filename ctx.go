@@ -0,0 +1,274 @@
+package banexg
+
+import (
+	"context"
+	"github.com/banbox/banexg/errs"
+)
+
+/*
+CtxExchange
+BanExchange bound with a context.Context, the ctx is passed to every rest api call by ParamContext,
+all http requests, retry sleeps, 429 waits and host flow control stop immediately when ctx is done.
+Watch* websocket subscriptions are not bound to ctx, use UnWatch* or Close to stop them.
+绑定了context.Context的BanExchange，ctx通过ParamContext传递给每个rest接口调用，
+ctx结束时，所有http请求、重试等待、429等待、域名流量控制都会立刻退出。
+Watch*订阅不受ctx控制，需调用UnWatch*或Close停止
+*/
+type CtxExchange struct {
+	BanExchange
+	ctx context.Context
+}
+
+/*
+WithContext
+return a BanExchange which carry the given ctx on every call, the original exchange is shared and not modified.
+返回在每次调用时携带ctx的BanExchange，与原交易所共享状态且不修改原交易所
+*/
+func WithContext(exg BanExchange, ctx context.Context) BanExchange {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if c, ok := exg.(*CtxExchange); ok {
+		exg = c.BanExchange
+	}
+	return &CtxExchange{BanExchange: exg, ctx: ctx}
+}
+
+func (e *CtxExchange) Context() context.Context {
+	return e.ctx
+}
+
+/*
+withCtx
+return a copy of params with ParamContext set, params from caller is not modified
+返回设置了ParamContext的params副本，不修改调用方的params
+*/
+func (e *CtxExchange) withCtx(params map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(params)+1)
+	for k, v := range params {
+		res[k] = v
+	}
+	res[ParamContext] = e.ctx
+	return res
+}
+
+func (e *CtxExchange) LoadMarkets(reload bool, params map[string]interface{}) (MarketMap, *errs.Error) {
+	return e.BanExchange.LoadMarkets(reload, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchTicker(symbol string, params map[string]interface{}) (*Ticker, *errs.Error) {
+	return e.BanExchange.FetchTicker(symbol, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchTickers(symbols []string, params map[string]interface{}) ([]*Ticker, *errs.Error) {
+	return e.BanExchange.FetchTickers(symbols, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchTickerPrice(symbol string, params map[string]interface{}) (map[string]float64, *errs.Error) {
+	return e.BanExchange.FetchTickerPrice(symbol, e.withCtx(params))
+}
+
+func (e *CtxExchange) LoadLeverageBrackets(reload bool, params map[string]interface{}) *errs.Error {
+	return e.BanExchange.LoadLeverageBrackets(reload, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchOHLCV(symbol, timeframe string, since int64, limit int, params map[string]interface{}) ([]*Kline, *errs.Error) {
+	return e.BanExchange.FetchOHLCV(symbol, timeframe, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchTrades(symbol string, since int64, limit int, params map[string]interface{}) ([]*Trade, *errs.Error) {
+	return e.BanExchange.FetchTrades(symbol, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchOrderBook(symbol string, limit int, params map[string]interface{}) (*OrderBook, *errs.Error) {
+	return e.BanExchange.FetchOrderBook(symbol, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchLastPrices(symbols []string, params map[string]interface{}) ([]*LastPrice, *errs.Error) {
+	return e.BanExchange.FetchLastPrices(symbols, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchFundingRate(symbol string, params map[string]interface{}) (*FundingRateCur, *errs.Error) {
+	return e.BanExchange.FetchFundingRate(symbol, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchFundingRates(symbols []string, params map[string]interface{}) ([]*FundingRateCur, *errs.Error) {
+	return e.BanExchange.FetchFundingRates(symbols, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchFundingRateHistory(symbol string, since int64, limit int, params map[string]interface{}) ([]*FundingRate, *errs.Error) {
+	return e.BanExchange.FetchFundingRateHistory(symbol, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchOpenInterest(symbol string, params map[string]interface{}) (*OpenInterest, *errs.Error) {
+	return e.BanExchange.FetchOpenInterest(symbol, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchOpenInterestHistory(symbol, period string, since int64, limit int, params map[string]interface{}) ([]*OpenInterest, *errs.Error) {
+	return e.BanExchange.FetchOpenInterestHistory(symbol, period, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchLongShortRatioHistory(symbol, period string, since int64, limit int, params map[string]interface{}) ([]*LongShortRatio, *errs.Error) {
+	return e.BanExchange.FetchLongShortRatioHistory(symbol, period, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchTakerVolumeHistory(symbol, period string, since int64, limit int, params map[string]interface{}) ([]*TakerVolume, *errs.Error) {
+	return e.BanExchange.FetchTakerVolumeHistory(symbol, period, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchLiquidations(symbol string, since int64, limit int, params map[string]interface{}) ([]*Liquidation, *errs.Error) {
+	return e.BanExchange.FetchLiquidations(symbol, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchOrder(symbol, orderId string, params map[string]interface{}) (*Order, *errs.Error) {
+	return e.BanExchange.FetchOrder(symbol, orderId, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchOrders(symbol string, since int64, limit int, params map[string]interface{}) ([]*Order, *errs.Error) {
+	return e.BanExchange.FetchOrders(symbol, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchBalance(params map[string]interface{}) (*Balances, *errs.Error) {
+	return e.BanExchange.FetchBalance(e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchAccountPositions(symbols []string, params map[string]interface{}) ([]*Position, *errs.Error) {
+	return e.BanExchange.FetchAccountPositions(symbols, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchPositions(symbols []string, params map[string]interface{}) ([]*Position, *errs.Error) {
+	return e.BanExchange.FetchPositions(symbols, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchOpenOrders(symbol string, since int64, limit int, params map[string]interface{}) ([]*Order, *errs.Error) {
+	return e.BanExchange.FetchOpenOrders(symbol, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchMyTrades(symbol string, since int64, limit int, params map[string]interface{}) ([]*MyTrade, *errs.Error) {
+	return e.BanExchange.FetchMyTrades(symbol, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchIncomeHistory(inType string, symbol string, since int64, limit int, params map[string]interface{}) ([]*Income, *errs.Error) {
+	return e.BanExchange.FetchIncomeHistory(inType, symbol, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) CreateOrder(symbol, odType, side string, amount, price float64, params map[string]interface{}) (*Order, *errs.Error) {
+	return e.BanExchange.CreateOrder(symbol, odType, side, amount, price, e.withCtx(params))
+}
+
+func (e *CtxExchange) EditOrder(symbol, orderId, side string, amount, price float64, params map[string]interface{}) (*Order, *errs.Error) {
+	return e.BanExchange.EditOrder(symbol, orderId, side, amount, price, e.withCtx(params))
+}
+
+func (e *CtxExchange) CancelOrder(id string, symbol string, params map[string]interface{}) (*Order, *errs.Error) {
+	return e.BanExchange.CancelOrder(id, symbol, e.withCtx(params))
+}
+
+func (e *CtxExchange) CreateOrderBy(args *OrderArgs) (*Order, *errs.Error) {
+	if args == nil {
+		return e.BanExchange.CreateOrderBy(args)
+	}
+	clone := *args
+	clone.Params = e.withCtx(args.Params)
+	return e.BanExchange.CreateOrderBy(&clone)
+}
+
+func (e *CtxExchange) CreateOrders(args []*OrderArgs, params map[string]interface{}) ([]*OrderRes, *errs.Error) {
+	return e.BanExchange.CreateOrders(args, e.withCtx(params))
+}
+
+func (e *CtxExchange) CancelOrders(symbol string, ids []string, params map[string]interface{}) ([]*OrderRes, *errs.Error) {
+	return e.BanExchange.CancelOrders(symbol, ids, e.withCtx(params))
+}
+
+func (e *CtxExchange) CancelAllOrders(symbol string, params map[string]interface{}) ([]*Order, *errs.Error) {
+	return e.BanExchange.CancelAllOrders(symbol, e.withCtx(params))
+}
+
+func (e *CtxExchange) CreateOrderList(kind string, legs []*OrderArgs, params map[string]interface{}) (*OrderList, *errs.Error) {
+	return e.BanExchange.CreateOrderList(kind, legs, e.withCtx(params))
+}
+
+func (e *CtxExchange) CancelOrderList(id string, symbol string, params map[string]interface{}) (*OrderList, *errs.Error) {
+	return e.BanExchange.CancelOrderList(id, symbol, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchOrderList(id string, symbol string, params map[string]interface{}) (*OrderList, *errs.Error) {
+	return e.BanExchange.FetchOrderList(id, symbol, e.withCtx(params))
+}
+
+func (e *CtxExchange) SetLeverage(leverage float64, symbol string, params map[string]interface{}) (map[string]interface{}, *errs.Error) {
+	return e.BanExchange.SetLeverage(leverage, symbol, e.withCtx(params))
+}
+
+func (e *CtxExchange) SetMarginMode(mode, symbol string, params map[string]interface{}) (map[string]interface{}, *errs.Error) {
+	return e.BanExchange.SetMarginMode(mode, symbol, e.withCtx(params))
+}
+
+func (e *CtxExchange) SetPositionMode(hedged bool, params map[string]interface{}) (map[string]interface{}, *errs.Error) {
+	return e.BanExchange.SetPositionMode(hedged, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchAccountConfig(symbols []string, params map[string]interface{}) ([]*AccountConfig, *errs.Error) {
+	return e.BanExchange.FetchAccountConfig(symbols, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchAccountStatus(params map[string]interface{}) (*AccountStatus, *errs.Error) {
+	return e.BanExchange.FetchAccountStatus(e.withCtx(params))
+}
+
+func (e *CtxExchange) AddMargin(symbol string, amount float64, params map[string]interface{}) (*MarginAdjustment, *errs.Error) {
+	return e.BanExchange.AddMargin(symbol, amount, e.withCtx(params))
+}
+
+func (e *CtxExchange) ReduceMargin(symbol string, amount float64, params map[string]interface{}) (*MarginAdjustment, *errs.Error) {
+	return e.BanExchange.ReduceMargin(symbol, amount, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchMarginAdjustmentHistory(symbol string, since int64, limit int, params map[string]interface{}) ([]*MarginAdjustment, *errs.Error) {
+	return e.BanExchange.FetchMarginAdjustmentHistory(symbol, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) BorrowMargin(code string, amount float64, symbol string, params map[string]interface{}) (*MarginLoan, *errs.Error) {
+	return e.BanExchange.BorrowMargin(code, amount, symbol, e.withCtx(params))
+}
+
+func (e *CtxExchange) RepayMargin(code string, amount float64, symbol string, params map[string]interface{}) (*MarginLoan, *errs.Error) {
+	return e.BanExchange.RepayMargin(code, amount, symbol, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchBorrowRates(codes []string, params map[string]interface{}) ([]*BorrowRate, *errs.Error) {
+	return e.BanExchange.FetchBorrowRates(codes, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchBorrowInterest(code, symbol string, since int64, limit int, params map[string]interface{}) ([]*BorrowInterest, *errs.Error) {
+	return e.BanExchange.FetchBorrowInterest(code, symbol, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) Transfer(code string, amount float64, fromAccount, toAccount string, params map[string]interface{}) (*TransferResult, *errs.Error) {
+	return e.BanExchange.Transfer(code, amount, fromAccount, toAccount, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchTransfers(code string, since int64, limit int, params map[string]interface{}) ([]*TransferResult, *errs.Error) {
+	return e.BanExchange.FetchTransfers(code, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchDepositAddress(code, network string, params map[string]interface{}) (*DepositAddress, *errs.Error) {
+	return e.BanExchange.FetchDepositAddress(code, network, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchDeposits(code string, since int64, limit int, params map[string]interface{}) ([]*Transaction, *errs.Error) {
+	return e.BanExchange.FetchDeposits(code, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) FetchWithdrawals(code string, since int64, limit int, params map[string]interface{}) ([]*Transaction, *errs.Error) {
+	return e.BanExchange.FetchWithdrawals(code, since, limit, e.withCtx(params))
+}
+
+func (e *CtxExchange) Withdraw(code string, amount float64, address, tag, network string, params map[string]interface{}) (*Transaction, *errs.Error) {
+	return e.BanExchange.Withdraw(code, amount, address, tag, network, e.withCtx(params))
+}
+
+func (e *CtxExchange) Call(method string, params map[string]interface{}) (*HttpRes, *errs.Error) {
+	return e.BanExchange.Call(method, e.withCtx(params))
+}
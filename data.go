@@ -29,6 +29,9 @@ const (
 	ParamSymbol             = "symbol"
 	ParamSymbols            = "symbols"
 	ParamPositionSide       = "positionSide"
+	ParamRatioType          = "ratioType" // LSRatioGlobalAccount/LSRatioTopAccount/LSRatioTopPosition
+	ParamFromAccount        = "fromAccount"
+	ParamToAccount          = "toAccount"
 	ParamProxy              = "proxy"
 	ParamName               = "name"
 	ParamMethod             = "method"
@@ -43,6 +46,8 @@ const (
 	ParamLoopIntv           = "loopIntv"
 	ParamDirection          = "direction"
 	ParamDebug              = "debug"
+	ParamContext            = "context"     // context.Context for cancel or deadline, not sent to exchange
+	ParamConditional        = "conditional" // bool, operate on conditional(strategy) orders of portfolio margin account
 )
 
 var (
@@ -104,6 +109,9 @@ const (
 	OptWsConn          = "WsConn"
 	OptAuthRefreshSecs = "AuthRefreshSecs"
 	OptPositionMethod  = "PositionMethod"
+	OptPortfolioMargin = "PortfolioMargin" // bool, 是否统一账户(Portfolio Margin)
+	OptOrderTransport  = "OrderTransport"  // 订单操作通道：rest(默认)/ws
+	OptUserStream      = "UserStream"      // 用户数据流订阅方式：listenKey(默认)/wsApi
 	OptDebugWs         = "DebugWs"
 	OptDebugApi        = "DebugApi"
 	OptApiCaches       = "ApiCaches"
@@ -115,6 +123,16 @@ const (
 	OptWsTimeout       = "WsTimeout"
 )
 
+const (
+	OrderTransportRest = "rest"
+	OrderTransportWs   = "ws" // 通过交易所websocket api下单，连接不可用时自动回退到rest
+)
+
+const (
+	UserStreamListenKey = "listenKey"
+	UserStreamWsApi     = "wsApi" // 通过已认证的websocket api会话订阅，无需listenKey续期(币安仅现货)
+)
+
 const (
 	PrecModeDecimalPlace = utils.PrecModeDecimalPlace // 保留小数点后位数
 	PrecModeSignifDigits = utils.PrecModeSignifDigits // 保留有效数字位数
@@ -126,7 +144,8 @@ const (
 	MarketMargin  = "margin" // 保证金杠杆现货交易 margin trade
 	MarketLinear  = "linear"
 	MarketInverse = "inverse"
-	MarketOption  = "option" // 期权 for option contracts
+	MarketOption  = "option"  // 期权 for option contracts
+	MarketFunding = "funding" // 资金账户，仅用于划转和余额 funding wallet, only for transfer & balance
 
 	MarketSwap   = "swap"   // 永续合约 for perpetual swap futures that don't have a delivery date
 	MarketFuture = "future" // 有交割日的期货 for expiring futures contracts that have a delivery/settlement date
@@ -137,6 +156,29 @@ const (
 	MarginIsolated = "isolated"
 )
 
+const (
+	LSRatioGlobalAccount = "globalAccount" // 全体账户多空人数比
+	LSRatioTopAccount    = "topAccount"    // 大户账户多空人数比
+	LSRatioTopPosition   = "topPosition"   // 大户持仓多空比
+)
+
+const (
+	MarginAdjAdd    = "add"
+	MarginAdjReduce = "reduce"
+)
+
+const (
+	TxTypeDeposit    = "deposit"
+	TxTypeWithdrawal = "withdrawal"
+)
+
+const (
+	TransferStatusOk       = "ok"
+	TransferStatusPending  = "pending"
+	TransferStatusFailed   = "failed"
+	TransferStatusCanceled = "canceled"
+)
+
 const (
 	OdStatusOpen       = "open"
 	OdStatusPartFilled = "part_filled"
@@ -147,6 +189,20 @@ const (
 	OdStatusExpired    = "expired"
 )
 
+// 关联订单组类型
+const (
+	OrderListOCO   = "oco"   // 二选一：一个成交或触发后另一个自动撤销
+	OrderListOTO   = "oto"   // 工作订单成交后才挂出待执行订单
+	OrderListOTOCO = "otoco" // 工作订单成交后挂出一组OCO订单
+)
+
+// 关联订单组状态
+const (
+	OdListStatusExecuting = "executing"
+	OdListStatusAllDone   = "all_done"
+	OdListStatusReject    = "reject"
+)
+
 // 此处订单类型全部使用币安订单类型小写
 const (
 	OdTypeMarket             = "market"
@@ -187,37 +243,78 @@ const (
 )
 
 const (
-	ApiFetchTicker           = "FetchTicker"
-	ApiFetchTickers          = "FetchTickers"
-	ApiFetchTickerPrice      = "FetchTickerPrice"
-	ApiLoadLeverageBrackets  = "LoadLeverageBrackets"
-	ApiFetchCurrencies       = "FetchCurrencies"
-	ApiGetLeverage           = "GetLeverage"
-	ApiFetchOHLCV            = "FetchOHLCV"
-	ApiFetchOrderBook        = "FetchOrderBook"
-	ApiFetchOrder            = "FetchOrder"
-	ApiFetchOrders           = "FetchOrders"
-	ApiFetchBalance          = "FetchBalance"
-	ApiFetchAccountPositions = "FetchAccountPositions"
-	ApiFetchPositions        = "FetchPositions"
-	ApiFetchOpenOrders       = "FetchOpenOrders"
-	ApiCreateOrder           = "CreateOrder"
-	ApiEditOrder             = "EditOrder"
-	ApiCancelOrder           = "CancelOrder"
-	ApiSetLeverage           = "SetLeverage"
-	ApiCalcMaintMargin       = "CalcMaintMargin"
-	ApiWatchOrderBooks       = "WatchOrderBooks"
-	ApiUnWatchOrderBooks     = "UnWatchOrderBooks"
-	ApiWatchOHLCVs           = "WatchOHLCVs"
-	ApiUnWatchOHLCVs         = "UnWatchOHLCVs"
-	ApiWatchMarkPrices       = "WatchMarkPrices"
-	ApiUnWatchMarkPrices     = "UnWatchMarkPrices"
-	ApiWatchTrades           = "WatchTrades"
-	ApiUnWatchTrades         = "UnWatchTrades"
-	ApiWatchMyTrades         = "WatchMyTrades"
-	ApiWatchBalance          = "WatchBalance"
-	ApiWatchPositions        = "WatchPositions"
-	ApiWatchAccountConfig    = "WatchAccountConfig"
+	ApiFetchTicker                  = "FetchTicker"
+	ApiFetchTickers                 = "FetchTickers"
+	ApiFetchTrades                  = "FetchTrades"
+	ApiFetchTickerPrice             = "FetchTickerPrice"
+	ApiFetchOpenInterest            = "FetchOpenInterest"
+	ApiFetchOpenInterestHistory     = "FetchOpenInterestHistory"
+	ApiFetchLongShortRatioHistory   = "FetchLongShortRatioHistory"
+	ApiFetchTakerVolumeHistory      = "FetchTakerVolumeHistory"
+	ApiFetchLiquidations            = "FetchLiquidations"
+	ApiLoadLeverageBrackets         = "LoadLeverageBrackets"
+	ApiFetchCurrencies              = "FetchCurrencies"
+	ApiGetLeverage                  = "GetLeverage"
+	ApiFetchOHLCV                   = "FetchOHLCV"
+	ApiFetchOrderBook               = "FetchOrderBook"
+	ApiFetchOrder                   = "FetchOrder"
+	ApiFetchOrders                  = "FetchOrders"
+	ApiFetchBalance                 = "FetchBalance"
+	ApiFetchAccountPositions        = "FetchAccountPositions"
+	ApiFetchPositions               = "FetchPositions"
+	ApiFetchOpenOrders              = "FetchOpenOrders"
+	ApiFetchMyTrades                = "FetchMyTrades"
+	ApiCreateOrder                  = "CreateOrder"
+	ApiEditOrder                    = "EditOrder"
+	ApiCancelOrder                  = "CancelOrder"
+	ApiCreateOrderBy                = "CreateOrderBy"
+	ApiCreateOrders                 = "CreateOrders"
+	ApiCancelOrders                 = "CancelOrders"
+	ApiCancelAllOrders              = "CancelAllOrders"
+	ApiCreateOrderList              = "CreateOrderList"
+	ApiCancelOrderList              = "CancelOrderList"
+	ApiFetchOrderList               = "FetchOrderList"
+	ApiSetLeverage                  = "SetLeverage"
+	ApiSetMarginMode                = "SetMarginMode"
+	ApiSetPositionMode              = "SetPositionMode"
+	ApiFetchAccountConfig           = "FetchAccountConfig"
+	ApiFetchAccountStatus           = "FetchAccountStatus"
+	ApiAddMargin                    = "AddMargin"
+	ApiReduceMargin                 = "ReduceMargin"
+	ApiFetchMarginAdjustmentHistory = "FetchMarginAdjustmentHistory"
+	ApiBorrowMargin                 = "BorrowMargin"
+	ApiRepayMargin                  = "RepayMargin"
+	ApiFetchBorrowRates             = "FetchBorrowRates"
+	ApiFetchBorrowInterest          = "FetchBorrowInterest"
+	ApiTransfer                     = "Transfer"
+	ApiFetchTransfers               = "FetchTransfers"
+	ApiFetchDepositAddress          = "FetchDepositAddress"
+	ApiFetchDeposits                = "FetchDeposits"
+	ApiFetchWithdrawals             = "FetchWithdrawals"
+	ApiWithdraw                     = "Withdraw"
+	ApiCalcMaintMargin              = "CalcMaintMargin"
+	ApiWatchOrderBooks              = "WatchOrderBooks"
+	ApiUnWatchOrderBooks            = "UnWatchOrderBooks"
+	ApiWatchOHLCVs                  = "WatchOHLCVs"
+	ApiUnWatchOHLCVs                = "UnWatchOHLCVs"
+	ApiWatchMarkPrices              = "WatchMarkPrices"
+	ApiUnWatchMarkPrices            = "UnWatchMarkPrices"
+	ApiWatchFundingRates            = "WatchFundingRates"
+	ApiUnWatchFundingRates          = "UnWatchFundingRates"
+	ApiWatchTickers                 = "WatchTickers"
+	ApiUnWatchTickers               = "UnWatchTickers"
+	ApiWatchBookTickers             = "WatchBookTickers"
+	ApiUnWatchBookTickers           = "UnWatchBookTickers"
+	ApiWatchOpenInterest            = "WatchOpenInterest"
+	ApiUnWatchOpenInterest          = "UnWatchOpenInterest"
+	ApiWatchLiquidations            = "WatchLiquidations"
+	ApiUnWatchLiquidations          = "UnWatchLiquidations"
+	ApiWatchTrades                  = "WatchTrades"
+	ApiUnWatchTrades                = "UnWatchTrades"
+	ApiWatchMyTrades                = "WatchMyTrades"
+	ApiWatchBalance                 = "WatchBalance"
+	ApiWatchPositions               = "WatchPositions"
+	ApiWatchAccountConfig           = "WatchAccountConfig"
 )
 
 var (
@@ -29,11 +29,23 @@ type BanExchange interface {
 	Info() *ExgInfo
 
 	FetchOHLCV(symbol, timeframe string, since int64, limit int, params map[string]interface{}) ([]*Kline, *errs.Error)
+	// FetchTrades Get recent public trades of the given market
+	FetchTrades(symbol string, since int64, limit int, params map[string]interface{}) ([]*Trade, *errs.Error)
 	FetchOrderBook(symbol string, limit int, params map[string]interface{}) (*OrderBook, *errs.Error)
 	FetchLastPrices(symbols []string, params map[string]interface{}) ([]*LastPrice, *errs.Error)
 	FetchFundingRate(symbol string, params map[string]interface{}) (*FundingRateCur, *errs.Error)
 	FetchFundingRates(symbols []string, params map[string]interface{}) ([]*FundingRateCur, *errs.Error)
 	FetchFundingRateHistory(symbol string, since int64, limit int, params map[string]interface{}) ([]*FundingRate, *errs.Error)
+	// FetchOpenInterest Get current open interest of a contract
+	FetchOpenInterest(symbol string, params map[string]interface{}) (*OpenInterest, *errs.Error)
+	// FetchOpenInterestHistory Get open interest history, period: 5m/15m/30m/1h/4h/1d...
+	FetchOpenInterestHistory(symbol, period string, since int64, limit int, params map[string]interface{}) ([]*OpenInterest, *errs.Error)
+	// FetchLongShortRatioHistory Get long/short ratio history, use ParamRatioType to choose ratio of global accounts/top accounts/top positions
+	FetchLongShortRatioHistory(symbol, period string, since int64, limit int, params map[string]interface{}) ([]*LongShortRatio, *errs.Error)
+	// FetchTakerVolumeHistory Get taker buy/sell volume history
+	FetchTakerVolumeHistory(symbol, period string, since int64, limit int, params map[string]interface{}) ([]*TakerVolume, *errs.Error)
+	// FetchLiquidations Get liquidation(force) orders of current account
+	FetchLiquidations(symbol string, since int64, limit int, params map[string]interface{}) ([]*Liquidation, *errs.Error)
 
 	// FetchOrder query given order
 	FetchOrder(symbol, orderId string, params map[string]interface{}) (*Order, *errs.Error)
@@ -46,15 +58,60 @@ type BanExchange interface {
 	FetchPositions(symbols []string, params map[string]interface{}) ([]*Position, *errs.Error)
 	// FetchOpenOrders Get all open orders on a symbol or all symbol.
 	FetchOpenOrders(symbol string, since int64, limit int, params map[string]interface{}) ([]*Order, *errs.Error)
+	// FetchMyTrades Get the account fills on a symbol, long time range is split into windows automatically
+	FetchMyTrades(symbol string, since int64, limit int, params map[string]interface{}) ([]*MyTrade, *errs.Error)
 	FetchIncomeHistory(inType string, symbol string, since int64, limit int, params map[string]interface{}) ([]*Income, *errs.Error)
 
 	CreateOrder(symbol, odType, side string, amount, price float64, params map[string]interface{}) (*Order, *errs.Error)
 	EditOrder(symbol, orderId, side string, amount, price float64, params map[string]interface{}) (*Order, *errs.Error)
 	CancelOrder(id string, symbol string, params map[string]interface{}) (*Order, *errs.Error)
+	// CreateOrderBy Create an order by typed args, args are validated before request
+	CreateOrderBy(args *OrderArgs) (*Order, *errs.Error)
+	// CreateOrders Create multiple orders in batch, each result carry its own error
+	CreateOrders(args []*OrderArgs, params map[string]interface{}) ([]*OrderRes, *errs.Error)
+	// CancelOrders Cancel multiple orders of a symbol, each result carry its own error
+	CancelOrders(symbol string, ids []string, params map[string]interface{}) ([]*OrderRes, *errs.Error)
+	// CancelAllOrders Cancel all open orders of a symbol
+	CancelAllOrders(symbol string, params map[string]interface{}) ([]*Order, *errs.Error)
+	// CreateOrderList Create linked orders, kind: OrderListOCO/OrderListOTO/OrderListOTOCO
+	CreateOrderList(kind string, legs []*OrderArgs, params map[string]interface{}) (*OrderList, *errs.Error)
+	// CancelOrderList Cancel an order list and all its open orders
+	CancelOrderList(id string, symbol string, params map[string]interface{}) (*OrderList, *errs.Error)
+	// FetchOrderList Get an order list with its child orders
+	FetchOrderList(id string, symbol string, params map[string]interface{}) (*OrderList, *errs.Error)
 
 	SetFees(fees map[string]map[string]float64)
 	CalculateFee(symbol, odType, side string, amount float64, price float64, isMaker bool, params map[string]interface{}) (*Fee, *errs.Error)
 	SetLeverage(leverage float64, symbol string, params map[string]interface{}) (map[string]interface{}, *errs.Error)
+	// SetMarginMode switch margin mode of symbol, mode: MarginCross/MarginIsolated
+	SetMarginMode(mode, symbol string, params map[string]interface{}) (map[string]interface{}, *errs.Error)
+	// SetPositionMode switch hedge(true) or one-way(false) position mode for contract market
+	SetPositionMode(hedged bool, params map[string]interface{}) (map[string]interface{}, *errs.Error)
+	// FetchAccountConfig Get leverage/margin mode/position mode of symbols
+	FetchAccountConfig(symbols []string, params map[string]interface{}) ([]*AccountConfig, *errs.Error)
+	// FetchAccountStatus Get the unified margin summary (uniMMR, equity, margins) of account
+	FetchAccountStatus(params map[string]interface{}) (*AccountStatus, *errs.Error)
+	// AddMargin add margin to isolated position, return the updated position if available
+	AddMargin(symbol string, amount float64, params map[string]interface{}) (*MarginAdjustment, *errs.Error)
+	// ReduceMargin remove margin from isolated position, return the updated position if available
+	ReduceMargin(symbol string, amount float64, params map[string]interface{}) (*MarginAdjustment, *errs.Error)
+	FetchMarginAdjustmentHistory(symbol string, since int64, limit int, params map[string]interface{}) ([]*MarginAdjustment, *errs.Error)
+	// BorrowMargin borrow asset for margin trading, symbol is required for isolated margin, empty for cross margin
+	BorrowMargin(code string, amount float64, symbol string, params map[string]interface{}) (*MarginLoan, *errs.Error)
+	// RepayMargin repay borrowed asset, symbol is required for isolated margin, empty for cross margin
+	RepayMargin(code string, amount float64, symbol string, params map[string]interface{}) (*MarginLoan, *errs.Error)
+	FetchBorrowRates(codes []string, params map[string]interface{}) ([]*BorrowRate, *errs.Error)
+	FetchBorrowInterest(code, symbol string, since int64, limit int, params map[string]interface{}) ([]*BorrowInterest, *errs.Error)
+	// Transfer move asset between wallets, fromAccount/toAccount: MarketSpot/MarketMargin/MarketLinear/MarketInverse/MarketOption/MarketFunding
+	Transfer(code string, amount float64, fromAccount, toAccount string, params map[string]interface{}) (*TransferResult, *errs.Error)
+	// FetchTransfers Get transfer history between wallets of current account
+	FetchTransfers(code string, since int64, limit int, params map[string]interface{}) ([]*TransferResult, *errs.Error)
+	// FetchDepositAddress Get deposit address of currency on given chain network, default network is used if empty
+	FetchDepositAddress(code, network string, params map[string]interface{}) (*DepositAddress, *errs.Error)
+	FetchDeposits(code string, since int64, limit int, params map[string]interface{}) ([]*Transaction, *errs.Error)
+	FetchWithdrawals(code string, since int64, limit int, params map[string]interface{}) ([]*Transaction, *errs.Error)
+	// Withdraw Make a withdrawal, network and amount are checked by Currency.Networks before request
+	Withdraw(code string, amount float64, address, tag, network string, params map[string]interface{}) (*Transaction, *errs.Error)
 	CalcMaintMargin(symbol string, cost float64) (float64, *errs.Error)
 	Call(method string, params map[string]interface{}) (*HttpRes, *errs.Error)
 
@@ -64,6 +121,16 @@ type BanExchange interface {
 	UnWatchOHLCVs(jobs [][2]string, params map[string]interface{}) *errs.Error
 	WatchMarkPrices(symbols []string, params map[string]interface{}) (chan map[string]float64, *errs.Error)
 	UnWatchMarkPrices(symbols []string, params map[string]interface{}) *errs.Error
+	WatchFundingRates(symbols []string, params map[string]interface{}) (chan []*FundingRateCur, *errs.Error)
+	UnWatchFundingRates(symbols []string, params map[string]interface{}) *errs.Error
+	WatchTickers(symbols []string, params map[string]interface{}) (chan []*Ticker, *errs.Error)
+	UnWatchTickers(symbols []string, params map[string]interface{}) *errs.Error
+	WatchBookTickers(symbols []string, params map[string]interface{}) (chan *BookTicker, *errs.Error)
+	UnWatchBookTickers(symbols []string, params map[string]interface{}) *errs.Error
+	WatchOpenInterest(symbols []string, params map[string]interface{}) (chan []*OpenInterest, *errs.Error)
+	UnWatchOpenInterest(symbols []string, params map[string]interface{}) *errs.Error
+	WatchLiquidations(symbols []string, params map[string]interface{}) (chan *Liquidation, *errs.Error)
+	UnWatchLiquidations(symbols []string, params map[string]interface{}) *errs.Error
 	WatchTrades(symbols []string, params map[string]interface{}) (chan *Trade, *errs.Error)
 	UnWatchTrades(symbols []string, params map[string]interface{}) *errs.Error
 	WatchMyTrades(params map[string]interface{}) (chan *MyTrade, *errs.Error)
@@ -1,7 +1,6 @@
 package longportapp
 
 import (
-	"context"
 	"strconv"
 	"time"
 
@@ -89,7 +88,7 @@ func (e *LongPortApp) FetchTicker(symbol string, params map[string]interface{})
 		return nil, errs.NewMsg(errs.CodeConnectFail, "quote context not initialized")
 	}
 
-	ctx := context.Background()
+	ctx := banexg.GetParamCtx(params)
 	securities := []string{symbol}
 
 	quotes, err := e.quoteContext.Quote(ctx, securities)
@@ -125,7 +124,7 @@ func (e *LongPortApp) FetchTickers(symbols []string, params map[string]interface
 		return nil, errs.NewMsg(errs.CodeConnectFail, "quote context not initialized")
 	}
 
-	ctx := context.Background()
+	ctx := banexg.GetParamCtx(params)
 	quotes, err := e.quoteContext.Quote(ctx, symbols)
 	if err != nil {
 		return nil, errs.NewMsg(errs.CodeRunTime, "failed to fetch quotes: %v", err)
@@ -163,7 +162,7 @@ func (e *LongPortApp) FetchOrderBook(symbol string, limit int, params map[string
 		return nil, errs.NewMsg(errs.CodeConnectFail, "quote context not initialized")
 	}
 
-	ctx := context.Background()
+	ctx := banexg.GetParamCtx(params)
 	depth, err := e.quoteContext.Depth(ctx, symbol)
 	if err != nil {
 		return nil, errs.NewMsg(errs.CodeRunTime, "failed to fetch depth: %v", err)
@@ -210,7 +209,7 @@ func (e *LongPortApp) FetchOHLCV(symbol, timeframe string, since int64, limit in
 		return nil, errs.NewMsg(errs.CodeParamInvalid, "unsupported timeframe: %s", timeframe)
 	}
 
-	ctx := context.Background()
+	ctx := banexg.GetParamCtx(params)
 
 	// 调用API - 使用正确的参数
 	candlesticks, err := e.quoteContext.Candlesticks(ctx, symbol, period, int32(limit), quote.AdjustTypeNo)
@@ -263,7 +262,7 @@ func (e *LongPortApp) FetchBalance(params map[string]interface{}) (*banexg.Balan
 		return nil, errs.NewMsg(errs.CodeConnectFail, "trade context not initialized")
 	}
 
-	ctx := context.Background()
+	ctx := banexg.GetParamCtx(params)
 	req := &trade.GetAccountBalance{}
 	balances, err := e.tradeContext.AccountBalance(ctx, req)
 	if err != nil {
@@ -310,7 +309,7 @@ func (e *LongPortApp) FetchPositions(symbols []string, params map[string]interfa
 		return nil, errs.NewMsg(errs.CodeConnectFail, "trade context not initialized")
 	}
 
-	ctx := context.Background()
+	ctx := banexg.GetParamCtx(params)
 	positionChannels, err := e.tradeContext.StockPositions(ctx, symbols)
 	if err != nil {
 		return nil, errs.NewMsg(errs.CodeRunTime, "failed to fetch positions: %v", err)
@@ -378,7 +377,7 @@ func (e *LongPortApp) CreateOrder(symbol, odType, side string, amount, price flo
 		return nil, errs.NewMsg(errs.CodeParamInvalid, "unsupported order side: %s", side)
 	}
 
-	ctx := context.Background()
+	ctx := banexg.GetParamCtx(params)
 
 	// 构建订单请求
 	order := &trade.SubmitOrder{
@@ -415,6 +414,11 @@ func (e *LongPortApp) CreateOrder(symbol, odType, side string, amount, price flo
 	return result, nil
 }
 
+// CreateOrderBy 使用类型化参数创建订单，提交前检查参数
+func (e *LongPortApp) CreateOrderBy(args *banexg.OrderArgs) (*banexg.Order, *errs.Error) {
+	return banexg.CreateOrderBy(e, args)
+}
+
 // convertOrderType 转换订单类型
 func (e *LongPortApp) convertOrderType(odType string) string {
 	switch odType {
@@ -445,7 +449,7 @@ func (e *LongPortApp) CancelOrder(id string, symbol string, params map[string]in
 		return nil, errs.NewMsg(errs.CodeConnectFail, "trade context not initialized")
 	}
 
-	ctx := context.Background()
+	ctx := banexg.GetParamCtx(params)
 	err := e.tradeContext.CancelOrder(ctx, id)
 	if err != nil {
 		return nil, errs.NewMsg(errs.CodeRunTime, "failed to cancel order: %v", err)
@@ -468,7 +472,7 @@ func (e *LongPortApp) FetchOrder(symbol, orderId string, params map[string]inter
 		return nil, errs.NewMsg(errs.CodeConnectFail, "trade context not initialized")
 	}
 
-	ctx := context.Background()
+	ctx := banexg.GetParamCtx(params)
 	orderDetail, err := e.tradeContext.OrderDetail(ctx, orderId)
 	if err != nil {
 		return nil, errs.NewMsg(errs.CodeRunTime, "failed to fetch order: %v", err)
@@ -483,7 +487,7 @@ func (e *LongPortApp) FetchOpenOrders(symbol string, since int64, limit int, par
 		return nil, errs.NewMsg(errs.CodeConnectFail, "trade context not initialized")
 	}
 
-	ctx := context.Background()
+	ctx := banexg.GetParamCtx(params)
 	req := &trade.GetTodayOrders{}
 
 	if symbol != "" {
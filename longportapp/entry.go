@@ -73,6 +73,7 @@ func New(Options map[string]interface{}) (*LongPortApp, *errs.Error) {
 					banexg.ApiCreateOrder:           banexg.HasOk,
 					banexg.ApiEditOrder:             banexg.HasOk,
 					banexg.ApiCancelOrder:           banexg.HasOk,
+					banexg.ApiCreateOrderBy:         banexg.HasOk,
 				},
 			},
 			CredKeys: map[string]bool{"ApiKey": true, "Secret": true},
@@ -126,8 +126,10 @@ type ExgInfo struct {
 	MarketsById      MarketArrMap                  // markets index by id
 	OrderBooks       map[string]*OrderBook         // symbol: OrderBook update by wss
 	MarkPrices       map[string]map[string]float64 // marketType: symbol: mark price
+	Tickers          map[string]map[string]*Ticker // marketType: symbol: ticker update by wss
 	OdBookLock       deadlock.Mutex
 	MarkPriceLock    deadlock.Mutex
+	TickerLock       deadlock.Mutex
 
 	PrecPadZero  bool   // padding zero for precision
 	MarketType   string // MarketSpot/MarketMargin/MarketLinear/MarketInverse/MarketOption
@@ -262,6 +264,7 @@ type ChainNetwork struct {
 	Precision float64
 	Deposit   bool
 	Withdraw  bool
+	IsDefault bool // 币种的默认网络
 	Limits    *CodeLimits
 	Info      map[string]interface{}
 }
@@ -367,6 +370,20 @@ type Ticker struct {
 	Info          map[string]interface{} `json:"info"`
 }
 
+/*
+BookTicker
+最优挂单（买一卖一）推送
+*/
+type BookTicker struct {
+	Symbol    string  `json:"symbol"`
+	Bid       float64 `json:"bid"`
+	BidQty    float64 `json:"bidQty"`
+	Ask       float64 `json:"ask"`
+	AskQty    float64 `json:"askQty"`
+	UpdateID  int64   `json:"updateId"`
+	EventTime int64   `json:"eventTime"` // 现货推送无此字段，为本地接收时间
+}
+
 /*
 **************************   Business Types   **************************
  */
@@ -434,6 +451,45 @@ type Position struct {
 	Info             map[string]interface{} `json:"info"`
 }
 
+type MarginAdjustment struct {
+	Symbol    string                 `json:"symbol"`
+	Type      string                 `json:"type"` // MarginAdjAdd/MarginAdjReduce
+	Amount    float64                `json:"amount"`
+	Code      string                 `json:"code"` // 保证金币种
+	Status    string                 `json:"status"`
+	Timestamp int64                  `json:"timestamp"`
+	Position  *Position              `json:"position,omitempty"` // 调整后的持仓，仅部分接口返回
+	Info      map[string]interface{} `json:"info"`
+}
+
+type MarginLoan struct {
+	ID        string                 `json:"id"`
+	Code      string                 `json:"code"`
+	Amount    float64                `json:"amount"`
+	Symbol    string                 `json:"symbol,omitempty"` // 逐仓杠杆的交易对，全仓为空
+	Timestamp int64                  `json:"timestamp"`
+	Info      map[string]interface{} `json:"info"`
+}
+
+type BorrowRate struct {
+	Code      string                 `json:"code"`
+	Rate      float64                `json:"rate"`   // 每个Period的利率
+	Period    int64                  `json:"period"` // 计息周期，毫秒
+	Timestamp int64                  `json:"timestamp"`
+	Info      map[string]interface{} `json:"info"`
+}
+
+type BorrowInterest struct {
+	Symbol       string                 `json:"symbol,omitempty"` // 逐仓杠杆的交易对
+	Code         string                 `json:"code"`
+	Interest     float64                `json:"interest"`     // 利息金额
+	InterestRate float64                `json:"interestRate"` // 利率
+	Amount       float64                `json:"amount"`       // 借款本金
+	MarginMode   string                 `json:"marginMode"`   // MarginCross/MarginIsolated
+	Timestamp    int64                  `json:"timestamp"`
+	Info         map[string]interface{} `json:"info"`
+}
+
 type Order struct {
 	Info                map[string]interface{} `json:"info"`
 	ID                  string                 `json:"id"`
@@ -462,6 +518,58 @@ type Order struct {
 	ReduceOnly          bool                   `json:"reduceOnly"`
 	Trades              []*Trade               `json:"trades"`
 	Fee                 *Fee                   `json:"fee"`
+	ListID              string                 `json:"listId"` // 所属关联订单组ID，非组内订单为空
+}
+
+/*
+OrderArgs
+类型化的下单参数，用于CreateOrderBy和批量下单。
+非零的类型化字段会写入Params中对应的ParamXxx键，Params中无类型化字段的键原样传给交易所
+*/
+type OrderArgs struct {
+	Symbol          string                 `json:"symbol"`
+	Type            string                 `json:"type"`
+	Side            string                 `json:"side"`
+	Amount          float64                `json:"amount"`
+	Price           float64                `json:"price"`
+	ClientOrderId   string                 `json:"clientOrderId,omitempty"`
+	PositionSide    string                 `json:"positionSide,omitempty"` // 双向持仓时的持仓方向，如LONG/SHORT
+	MarginMode      string                 `json:"marginMode,omitempty"`   // MarginCross/MarginIsolated
+	TimeInForce     string                 `json:"timeInForce,omitempty"`
+	PostOnly        bool                   `json:"postOnly,omitempty"`
+	ReduceOnly      bool                   `json:"reduceOnly,omitempty"`
+	ClosePosition   bool                   `json:"closePosition,omitempty"` // 触发后全部平仓，不可和Amount/ReduceOnly同时使用
+	TriggerPrice    float64                `json:"triggerPrice,omitempty"`
+	StopLossPrice   float64                `json:"stopLossPrice,omitempty"`
+	TakeProfitPrice float64                `json:"takeProfitPrice,omitempty"`
+	TrailingDelta   int                    `json:"trailingDelta,omitempty"`
+	CallbackRate    float64                `json:"callbackRate,omitempty"` // 跟踪止损回调百分比
+	Cost            float64                `json:"cost,omitempty"`         // 现货市价单按报价币金额下单，不可和Amount同时使用
+	Params          map[string]interface{} `json:"params,omitempty"`
+}
+
+/*
+OrderList
+关联订单组(OCO/OTO/OTOCO)，子订单的Order.ListID均为组ID
+*/
+type OrderList struct {
+	ID           string                 `json:"id"`
+	ClientListID string                 `json:"clientListId"`
+	Kind         string                 `json:"kind"` // OrderListOCO/OrderListOTO/OrderListOTOCO
+	Symbol       string                 `json:"symbol"`
+	Status       string                 `json:"status"` // OdListStatusExecuting/OdListStatusAllDone/OdListStatusReject
+	Timestamp    int64                  `json:"timestamp"`
+	Orders       []*Order               `json:"orders"`
+	Info         map[string]interface{} `json:"info"`
+}
+
+/*
+OrderRes
+批量订单操作中单个订单的结果，Error不为空表示此订单失败，不影响其他订单
+*/
+type OrderRes struct {
+	Order *Order      `json:"order"`
+	Error *errs.Error `json:"error"`
 }
 
 type Trade struct {
@@ -532,6 +640,81 @@ type Income struct {
 	TradeID    string  `json:"tradeId"`
 }
 
+type TransferResult struct {
+	ID          string                 `json:"id"`
+	Code        string                 `json:"code"`
+	Amount      float64                `json:"amount"`
+	FromAccount string                 `json:"fromAccount"` // MarketSpot/MarketLinear/MarketFunding...
+	ToAccount   string                 `json:"toAccount"`
+	Status      string                 `json:"status"`
+	Timestamp   int64                  `json:"timestamp"`
+	Info        map[string]interface{} `json:"info"`
+}
+
+type DepositAddress struct {
+	Code    string                 `json:"code"`
+	Network string                 `json:"network"`
+	Address string                 `json:"address"`
+	Tag     string                 `json:"tag"` // memo/tag required by some chains
+	Info    map[string]interface{} `json:"info"`
+}
+
+// Transaction deposit or withdrawal record
+type Transaction struct {
+	ID        string                 `json:"id"`
+	TxID      string                 `json:"txid"` // transaction hash on chain
+	Type      string                 `json:"type"` // TxTypeDeposit/TxTypeWithdrawal
+	Code      string                 `json:"code"`
+	Network   string                 `json:"network"`
+	Address   string                 `json:"address"`
+	Tag       string                 `json:"tag"`
+	Amount    float64                `json:"amount"`
+	Fee       float64                `json:"fee"`
+	Status    string                 `json:"status"` // TransferStatusOk/TransferStatusPending/TransferStatusFailed/TransferStatusCanceled
+	Timestamp int64                  `json:"timestamp"`
+	Info      map[string]interface{} `json:"info"`
+}
+
+type OpenInterest struct {
+	Symbol    string                 `json:"symbol"`
+	Amount    float64                `json:"amount"` // open interest in contracts (or base for linear)
+	Value     float64                `json:"value"`  // open interest value in quote, 0 if not provided
+	Timestamp int64                  `json:"timestamp"`
+	Info      map[string]interface{} `json:"info"`
+}
+
+type LongShortRatio struct {
+	Symbol     string                 `json:"symbol"`
+	LongRatio  float64                `json:"longRatio"`  // 多头占比 0~1
+	ShortRatio float64                `json:"shortRatio"` // 空头占比 0~1
+	Ratio      float64                `json:"ratio"`      // LongRatio / ShortRatio
+	Timestamp  int64                  `json:"timestamp"`
+	Info       map[string]interface{} `json:"info"`
+}
+
+type TakerVolume struct {
+	Symbol    string                 `json:"symbol"`
+	BuyVol    float64                `json:"buyVol"`    // 主动买入量
+	SellVol   float64                `json:"sellVol"`   // 主动卖出量
+	BuyValue  float64                `json:"buyValue"`  // 主动买入额，仅部分交易所提供
+	SellValue float64                `json:"sellValue"` // 主动卖出额，仅部分交易所提供
+	Ratio     float64                `json:"ratio"`     // BuyVol / SellVol
+	Timestamp int64                  `json:"timestamp"`
+	Info      map[string]interface{} `json:"info"`
+}
+
+type Liquidation struct {
+	Symbol    string                 `json:"symbol"`
+	Side      string                 `json:"side"` // 强平订单方向，sell表示多头被强平
+	Price     float64                `json:"price"`
+	Average   float64                `json:"average"`
+	Amount    float64                `json:"amount"`
+	Filled    float64                `json:"filled"`
+	Status    string                 `json:"status"`
+	Timestamp int64                  `json:"timestamp"`
+	Info      map[string]interface{} `json:"info"`
+}
+
 type FundingRate struct {
 	Symbol      string                 `json:"symbol"`
 	FundingRate float64                `json:"fundingRate"`
@@ -595,8 +778,26 @@ type WsMsg struct {
 }
 
 type AccountConfig struct {
-	Symbol   string
-	Leverage int
+	Symbol      string
+	Leverage    int
+	MarginMode  string // MarginCross/MarginIsolated
+	Hedged      bool   // 是否双向持仓
+	MultiAssets bool   // 是否联合保证金（多资产）模式
+}
+
+/*
+AccountStatus 统一账户(Portfolio Margin)的保证金概况
+*/
+type AccountStatus struct {
+	UniMMR       float64 // 统一账户维持保证金率
+	Equity       float64 // 以USD计价的账户权益
+	ActualEquity float64 // 不考虑质押率的账户权益
+	InitMargin   float64 // 账户初始保证金
+	MaintMargin  float64 // 账户维持保证金
+	Available    float64 // 可用余额
+	Status       string  // 账户状态：NORMAL/MARGIN_CALL/REDUCE_ONLY/ACTIVE_LIQUIDATION...
+	Timestamp    int64
+	Info         map[string]interface{}
 }
 
 type WsLog struct {
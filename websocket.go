@@ -51,6 +51,7 @@ type WsClient struct {
 	connLock      deadlock.Mutex
 	limitsLock    deadlock.Mutex // for odBookLimits
 	subsLock      deadlock.Mutex // for SubsKeyStamps
+	jobsLock      deadlock.Mutex // for JobInfos
 }
 
 type AsyncConn struct {
@@ -409,6 +410,26 @@ func (e *Exchange) AddWsChanRefs(chanKey string, keys ...string) {
 	}
 }
 
+// HasWsChanRefs 是否仍有引用使用此chan
+func (e *Exchange) HasWsChanRefs(chanKey string) bool {
+	e.lockWsRef.Lock()
+	data, ok := e.WsChanRefs[chanKey]
+	e.lockWsRef.Unlock()
+	return ok && len(data) > 0
+}
+
+// HasWsChanRef chan是否仍被指定key引用
+func (e *Exchange) HasWsChanRef(chanKey, key string) bool {
+	e.lockWsRef.Lock()
+	defer e.lockWsRef.Unlock()
+	data, ok := e.WsChanRefs[chanKey]
+	if !ok {
+		return false
+	}
+	_, ok = data[key]
+	return ok
+}
+
 func (e *Exchange) DelWsChanRefs(chanKey string, keys ...string) int {
 	e.lockWsRef.Lock()
 	data, ok := e.WsChanRefs[chanKey]
@@ -515,6 +536,21 @@ func (c *WsClient) GetConnSubStats(timeout int64) map[int]*SubStat {
 	return result
 }
 
+// HasSubsKey 是否已订阅指定key
+func (c *WsClient) HasSubsKey(key string) bool {
+	c.subsLock.Lock()
+	_, ok := c.SubscribeKeys[key]
+	c.subsLock.Unlock()
+	return ok
+}
+
+// DelJobInfo 删除未收到结果的任务，如请求超时
+func (c *WsClient) DelJobInfo(id string) {
+	c.jobsLock.Lock()
+	delete(c.JobInfos, id)
+	c.jobsLock.Unlock()
+}
+
 func (c *WsClient) SetSubsKeyStamp(key string, stamp int64) {
 	c.subsLock.Lock()
 	if target, ok := c.subsKeyMap[key]; ok {
@@ -574,9 +610,11 @@ func (c *WsClient) Write(conn *AsyncConn, msg interface{}, info *WsJobInfo) *err
 		if info.ID == "" {
 			return errs.NewMsg(errs.CodeParamRequired, "WsJobInfo.ID is required")
 		}
+		c.jobsLock.Lock()
 		if _, ok := c.JobInfos[info.ID]; !ok {
 			c.JobInfos[info.ID] = info
 		}
+		c.jobsLock.Unlock()
 	}
 	if c.Debug {
 		log.Debug("write ws msg", zap.String("url", c.URL), zap.Int("id", conn.GetID()),
@@ -707,10 +745,15 @@ func (c *WsClient) HandleRawMsg(msgRaw []byte) {
 		return
 	}
 	if !msg.IsArray && msg.ID != "" {
-		if sub, ok := c.JobInfos[msg.ID]; ok && sub.Method != nil {
+		c.jobsLock.Lock()
+		sub, ok := c.JobInfos[msg.ID]
+		if ok && sub.Method != nil {
+			delete(c.JobInfos, msg.ID)
+		}
+		c.jobsLock.Unlock()
+		if ok && sub.Method != nil {
 			// 订阅信息中提供了处理函数，则调用处理函数
 			sub.Method(c, msg.Object, sub)
-			delete(c.JobInfos, msg.ID)
 			return
 		}
 	}